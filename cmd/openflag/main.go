@@ -2,8 +2,9 @@ package main
 
 import (
 	"os"
+	_ "time/tzdata" // Embedded timezone database for time based constraints
 
-	"github.com/OpenFlag/OpenFlag/internal/app/openflag/cmd"
+	"github.com/OpenFlag/OpenFlag/pkg/openflag"
 
 	_ "go.uber.org/automaxprocs"
 )
@@ -13,7 +14,7 @@ const (
 )
 
 func main() {
-	root := cmd.NewRootCommand()
+	root := openflag.NewRootCommand()
 
 	if root != nil {
 		if err := root.Execute(); err != nil {
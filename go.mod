@@ -3,7 +3,6 @@ module github.com/OpenFlag/OpenFlag
 go 1.15
 
 require (
-	github.com/Pallinder/go-randomdata v1.2.0
 	github.com/asaskevich/govalidator v0.0.0-20200907205600-7a23bdc65eef // indirect
 	github.com/bmizerany/assert v0.0.0-20160611221934-b7ed37b82869
 	github.com/carlescere/scheduler v0.0.0-20170109141437-ee74d2f83d82
@@ -25,6 +24,7 @@ require (
 	github.com/spf13/viper v1.7.1
 	github.com/stretchr/testify v1.5.1
 	go.uber.org/automaxprocs v1.3.0
+	golang.org/x/text v0.3.3
 	google.golang.org/grpc v1.31.0
 	google.golang.org/protobuf v1.25.0
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0
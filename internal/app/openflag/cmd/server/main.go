@@ -81,12 +81,20 @@ func main(cfg config.Config) {
 	e.GET("/healthz", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
 
 	flagRepo := model.SQLFlagRepo{Driver: dbCfg.Driver, MasterDB: dbMaster, SlaveDB: dbSlave}
+	audienceRepo := model.SQLAudienceRepo{Driver: dbCfg.Driver, MasterDB: dbMaster, SlaveDB: dbSlave}
+	layerRepo := model.SQLLayerRepo{Driver: dbCfg.Driver, MasterDB: dbMaster, SlaveDB: dbSlave}
 	entityRepo := model.NewRedisEntityRepo(
-		redisMasterClient, redisSlaveClient, cfg.Evaluation.EntityContextCacheExpiration,
+		redisMasterClient, redisSlaveClient,
+		cfg.Evaluation.EntityContextCacheExpiration, cfg.Evaluation.EntitySeenExpiration,
 	)
+	listRepo := model.RedisListRepo{RedisMaster: redisMasterClient, RedisSlave: redisSlaveClient}
+	frequencyCapRepo := model.RedisFrequencyCapRepo{RedisMaster: redisMasterClient, RedisSlave: redisSlaveClient}
+	assignmentRepo := model.RedisAssignmentRepo{RedisMaster: redisMasterClient, RedisSlave: redisSlaveClient}
 
 	evaluationLogger := engine.NewLogger(cfg.Logger.Evaluation)
-	evaluationEngine := engine.New(evaluationLogger, flagRepo)
+	evaluationEngine := engine.New(
+		evaluationLogger, flagRepo, audienceRepo, layerRepo, listRepo, frequencyCapRepo, assignmentRepo, entityRepo,
+	)
 
 	if err := evaluationEngine.Fetch(); err != nil {
 		logrus.Fatalf("failed to fetch flags: %s", err.Error())
@@ -96,7 +104,15 @@ func main(cfg config.Config) {
 		logrus.Fatalf("Failed to start evaluation engine: %s", err.Error())
 	}
 
-	flagHandler := handler.FlagHandler{FlagRepo: flagRepo}
+	flagHandler := handler.FlagHandler{
+		FlagRepo: flagRepo, ListRepo: listRepo, LayerRepo: layerRepo, AssignmentRepo: assignmentRepo,
+		AudienceRepo: audienceRepo,
+	}
+	audienceHandler := handler.AudienceHandler{AudienceRepo: audienceRepo, FlagRepo: flagRepo}
+	layerHandler := handler.LayerHandler{LayerRepo: layerRepo, FlagRepo: flagRepo}
+	listHandler := handler.ListHandler{ListRepo: listRepo}
+	assignmentHandler := handler.AssignmentHandler{AssignmentRepo: assignmentRepo}
+	ruleHandler := handler.RuleHandler{}
 	evaluationHandler := handler.EvaluationHandler{Engine: evaluationEngine, EntityRepo: entityRepo}
 
 	v1 := e.Group("/api/v1")
@@ -105,10 +121,31 @@ func main(cfg config.Config) {
 	v1.DELETE("/flag/:id", flagHandler.Delete)
 	v1.PUT("/flag/:id", flagHandler.Update)
 	v1.GET("/flag/:id", flagHandler.FindByID)
+	v1.POST("/flag/lint", flagHandler.Lint)
 	v1.POST("/flag/tag", flagHandler.FindByTag)
 	v1.POST("/flag/history", flagHandler.FindByFlag)
 	v1.POST("/flags", flagHandler.FindFlags)
 
+	v1.POST("/audience", audienceHandler.Create)
+	v1.DELETE("/audience/:id", audienceHandler.Delete)
+	v1.PUT("/audience/:id", audienceHandler.Update)
+	v1.GET("/audience/:id", audienceHandler.FindByID)
+	v1.GET("/audiences", audienceHandler.FindAll)
+
+	v1.POST("/layer", layerHandler.Create)
+	v1.DELETE("/layer/:id", layerHandler.Delete)
+	v1.PUT("/layer/:id", layerHandler.Update)
+	v1.GET("/layer/:id", layerHandler.FindByID)
+	v1.GET("/layers", layerHandler.FindAll)
+
+	v1.POST("/list/:name", listHandler.Upload)
+	v1.GET("/list/:name", listHandler.Find)
+
+	v1.DELETE("/assignment/:flag", assignmentHandler.Reset)
+	v1.DELETE("/assignment/:flag/:entity_type/:entity_id", assignmentHandler.ResetEntity)
+
+	v1.POST("/rule/render", ruleHandler.Render)
+
 	v1.POST("/evaluation", evaluationHandler.Evaluate)
 
 	e.Static("/", "browser/openflag-ui/build")
@@ -68,6 +68,7 @@ type (
 	// Evaluation represents evaluation configuration struct.
 	Evaluation struct {
 		EntityContextCacheExpiration time.Duration `mapstructure:"entity-context-cache-expiration"`
+		EntitySeenExpiration         time.Duration `mapstructure:"entity-seen-expiration"`
 		UpdateFlagsCronPattern       string        `mapstructure:"update-flags-cron-pattern"`
 	}
 
@@ -61,6 +61,7 @@ redis:
 
 evaluation:
   entity-context-cache-expiration: 1h
+  entity-seen-expiration: 8760h
   update-flags-cron-pattern: "0 0/5 * * * *"
 
 monitoring:
@@ -0,0 +1,474 @@
+package constraint
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+)
+
+// Represents the kinds of the warnings that the analyzer reports.
+const (
+	UnreachableSegmentWarning  = "unreachable_segment"
+	ContradictionWarning       = "contradiction"
+	TautologyWarning           = "tautology"
+	DuplicateConstraintWarning = "duplicate_constraint"
+)
+
+// Warning represents a problem that the analyzer finds in the segments of a flag. The flag is still valid, but
+// it probably does not behave as it is meant to. Segment is the index of the segment that has the problem.
+type Warning struct {
+	Segment int    `json:"segment"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// numberRange represents the numbers that the numeric comparisons of a property accept.
+type numberRange struct {
+	lower     float64
+	upper     float64
+	lowerOpen bool
+	upperOpen bool
+}
+
+// Analyze analyzes the constraint trees of the segments of a flag in their evaluation order. It reports
+// the segments that can not be reached because an earlier segment matches all of their entities, the
+// constraints that never match (e.g. x < 5 ∩ x > 10), the constraints other than always that always match
+// (e.g. A ∪ !A) and the duplicate operands of intersections and unions. The analysis is conservative, so
+// it only reports what it can prove from the structure of the trees.
+func Analyze(segments []model.Constraint) ([]Warning, error) {
+	trees := make([]Constraint, 0, len(segments))
+
+	for _, segment := range segments {
+		c, err := New(segment.Name, segment.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		trees = append(trees, c)
+	}
+
+	var warnings []Warning
+
+	for i, tree := range trees {
+		warnings = append(warnings, analyzeTree(i, tree)...)
+
+		for j, earlier := range trees[:i] {
+			if message, ok := shadowed(tree, earlier, j); ok {
+				warnings = append(warnings, Warning{Segment: i, Kind: UnreachableSegmentWarning, Message: message})
+				break
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// analyzeTree reports the contradictions, the tautologies and the duplicate operands of a segment. Only
+// the outermost contradiction or tautology is reported, because it explains the nested ones.
+func analyzeTree(segment int, tree Constraint) []Warning {
+	var warnings []Warning
+
+	var inspect func(c Constraint)
+
+	inspect = func(c Constraint) {
+		switch {
+		case isContradiction(c):
+			warnings = append(warnings, Warning{
+				Segment: segment, Kind: ContradictionWarning, Message: describe(c) + " never matches",
+			})
+
+			return
+		case c.Name() != AlwaysConstraintName && isTautology(c):
+			warnings = append(warnings, Warning{
+				Segment: segment, Kind: TautologyWarning, Message: describe(c) + " always matches",
+			})
+
+			return
+		}
+
+		seen := map[string]bool{}
+
+		for _, child := range children(c) {
+			k := key(child)
+
+			if seen[k] && (c.Name() == IntersectionConstraintName || c.Name() == UnionConstraintName) {
+				warnings = append(warnings, Warning{
+					Segment: segment, Kind: DuplicateConstraintWarning, Message: "duplicate constraint " + describe(child),
+				})
+
+				continue
+			}
+
+			seen[k] = true
+
+			inspect(child)
+		}
+	}
+
+	inspect(tree)
+
+	return warnings
+}
+
+// shadowed returns why a segment is unreachable if the given earlier segment matches all of its entities.
+func shadowed(tree, earlier Constraint, index int) (string, bool) {
+	if isTautology(earlier) {
+		return fmt.Sprintf("segment is unreachable because segment %d always matches", index), true
+	}
+
+	if implies(tree, earlier) {
+		return fmt.Sprintf("segment is unreachable because segment %d matches all of its entities", index), true
+	}
+
+	return "", false
+}
+
+// children returns the nested constraints. The operands of the nested intersections and unions of the same kind
+// are flattened, e.g. the children of (A ∩ B) ∩ C are A, B and C.
+func children(c Constraint) []Constraint {
+	pc, ok := c.(ParentConstraint)
+	if !ok {
+		return nil
+	}
+
+	if c.Name() == NotConstraintName {
+		return pc.Children()
+	}
+
+	var result []Constraint
+
+	for _, child := range pc.Children() {
+		if child.Name() == c.Name() {
+			result = append(result, children(child)...)
+		} else {
+			result = append(result, child)
+		}
+	}
+
+	return result
+}
+
+// key returns a canonical representation of the constraint tree, so the trees that only differ in the order
+// of the operands of their intersections and unions have the same key.
+func key(c Constraint) string {
+	if _, ok := c.(ParentConstraint); !ok {
+		return c.Name() + string(parameters(c))
+	}
+
+	keys := []string{}
+
+	for _, child := range children(c) {
+		keys = append(keys, key(child))
+	}
+
+	if c.Name() != NotConstraintName {
+		sort.Strings(keys)
+	}
+
+	return c.Name() + "(" + strings.Join(keys, ",") + ")"
+}
+
+// deterministic returns true if the constraint tree matches an entity in the same way each time that it is
+// evaluated. The random constraints that are not sticky are re-rolled on each evaluation.
+func deterministic(c Constraint) bool {
+	result := true
+
+	Walk(c, func(c Constraint) {
+		if r, ok := c.(*RandomConstraint); ok && !r.Sticky {
+			result = false
+		}
+	})
+
+	return result
+}
+
+func isContradiction(c Constraint) bool {
+	switch x := c.(type) {
+	case *NotConstraint:
+		return isTautology(x.constraint)
+	case *IntersectionConstraint:
+		operands := children(c)
+
+		for _, operand := range operands {
+			if isContradiction(operand) {
+				return true
+			}
+		}
+
+		return complementary(operands) || disjointRanges(operands) || disjointValues(operands)
+	case *UnionConstraint:
+		for _, operand := range children(c) {
+			if !isContradiction(operand) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+func isTautology(c Constraint) bool {
+	switch x := c.(type) {
+	case *AlwaysConstraint:
+		return true
+	case *NotConstraint:
+		return isContradiction(x.constraint)
+	case *UnionConstraint:
+		operands := children(c)
+
+		for _, operand := range operands {
+			if isTautology(operand) {
+				return true
+			}
+		}
+
+		return complementary(operands)
+	case *IntersectionConstraint:
+		for _, operand := range children(c) {
+			if !isTautology(operand) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// complementary returns true if one of the operands is the negation of another one, e.g. A and !A.
+func complementary(operands []Constraint) bool {
+	keys := map[string]bool{}
+
+	for _, operand := range operands {
+		keys[key(operand)] = true
+	}
+
+	for _, operand := range operands {
+		if n, ok := operand.(*NotConstraint); ok && deterministic(n.constraint) && keys[key(n.constraint)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// disjointRanges returns true if the numeric comparisons of a property in an intersection accept no number.
+func disjointRanges(operands []Constraint) bool {
+	ranges := map[string]numberRange{}
+
+	for _, operand := range operands {
+		property, r, ok := leafRange(operand)
+		if !ok {
+			continue
+		}
+
+		if current, ok := ranges[property]; ok {
+			r = current.intersect(r)
+		}
+
+		if r.empty() {
+			return true
+		}
+
+		ranges[property] = r
+	}
+
+	return false
+}
+
+// disjointValues returns true if the contains and excludes constraints of a property in an intersection
+// accept no value.
+func disjointValues(operands []Constraint) bool {
+	allowed := map[string]map[string]bool{}
+	excluded := map[string]map[string]bool{}
+
+	for _, operand := range operands {
+		switch x := operand.(type) {
+		case *ContainsConstraint:
+			values := set(x.Values)
+
+			if current, ok := allowed[x.Property]; ok {
+				for value := range values {
+					if !current[value] {
+						delete(values, value)
+					}
+				}
+			}
+
+			allowed[x.Property] = values
+		case *ExcludesConstraint:
+			if excluded[x.Property] == nil {
+				excluded[x.Property] = map[string]bool{}
+			}
+
+			for _, value := range x.Values {
+				excluded[x.Property][value] = true
+			}
+		}
+	}
+
+	for property, values := range allowed {
+		remaining := 0
+
+		for value := range values {
+			if !excluded[property][value] {
+				remaining++
+			}
+		}
+
+		if remaining == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// implies returns true if the constraint tree a only matches the entities that b matches.
+func implies(a, b Constraint) bool {
+	if key(a) == key(b) {
+		return deterministic(a)
+	}
+
+	if isTautology(b) {
+		return true
+	}
+
+	if property, r, ok := leafRange(a); ok {
+		if other, s, ok := leafRange(b); ok && property == other {
+			return s.contains(r)
+		}
+	}
+
+	if x, ok := a.(*ContainsConstraint); ok {
+		if y, ok := b.(*ContainsConstraint); ok && x.Property == y.Property {
+			return subset(x.Values, y.Values)
+		}
+	}
+
+	return impliesOperands(a, b)
+}
+
+// impliesOperands checks the implication using the operands of the intersections and unions.
+func impliesOperands(a, b Constraint) bool {
+	switch a.(type) {
+	case *IntersectionConstraint:
+		for _, operand := range children(a) {
+			if implies(operand, b) {
+				return true
+			}
+		}
+	case *UnionConstraint:
+		all := true
+
+		for _, operand := range children(a) {
+			all = all && implies(operand, b)
+		}
+
+		if all {
+			return true
+		}
+	}
+
+	switch b.(type) {
+	case *UnionConstraint:
+		for _, operand := range children(b) {
+			if implies(a, operand) {
+				return true
+			}
+		}
+	case *IntersectionConstraint:
+		for _, operand := range children(b) {
+			if !implies(a, operand) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// leafRange returns the property and the range of the numbers that a numeric comparison accepts.
+func leafRange(c Constraint) (string, numberRange, bool) {
+	r := numberRange{lower: math.Inf(-1), upper: math.Inf(1)}
+
+	switch x := c.(type) {
+	case *LessThanConstraint:
+		r.upper, r.upperOpen = x.Value, true
+		return x.Property, r, true
+	case *LessThanEqualConstraint:
+		r.upper = x.Value
+		return x.Property, r, true
+	case *BiggerThanConstraint:
+		r.lower, r.lowerOpen = x.Value, true
+		return x.Property, r, true
+	case *BiggerThanEqualConstraint:
+		r.lower = x.Value
+		return x.Property, r, true
+	default:
+		return "", r, false
+	}
+}
+
+func (r numberRange) intersect(s numberRange) numberRange {
+	if s.lower > r.lower || (s.lower == r.lower && s.lowerOpen) {
+		r.lower, r.lowerOpen = s.lower, s.lowerOpen
+	}
+
+	if s.upper < r.upper || (s.upper == r.upper && s.upperOpen) {
+		r.upper, r.upperOpen = s.upper, s.upperOpen
+	}
+
+	return r
+}
+
+func (r numberRange) empty() bool {
+	return r.lower > r.upper || (r.lower == r.upper && (r.lowerOpen || r.upperOpen))
+}
+
+func (r numberRange) contains(s numberRange) bool {
+	lower := s.lower > r.lower || (s.lower == r.lower && (s.lowerOpen || !r.lowerOpen))
+	upper := s.upper < r.upper || (s.upper == r.upper && (s.upperOpen || !r.upperOpen))
+
+	return lower && upper
+}
+
+func set(values []string) map[string]bool {
+	result := map[string]bool{}
+
+	for _, value := range values {
+		result[value] = true
+	}
+
+	return result
+}
+
+func subset(values []string, of []string) bool {
+	s := set(of)
+
+	for _, value := range values {
+		if !s[value] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// describe returns the constraint as a rule or, if rules do not support it, with its name and parameters.
+func describe(c Constraint) string {
+	rule, err := RenderRule(model.Constraint{Name: c.Name(), Parameters: parameters(c)})
+	if err != nil {
+		return c.Name() + " " + string(parameters(c))
+	}
+
+	return rule
+}
@@ -0,0 +1,113 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type AnalyzerSuite struct {
+	suite.Suite
+}
+
+func (suite *AnalyzerSuite) TestAnalyze() {
+	cases := []struct {
+		name     string
+		rules    []string
+		warnings []constraint.Warning
+	}{
+		{
+			name:  "successfully analyze segments without any problem",
+			rules: []string{`country == "IR" and age >= 18`, `country in ["IR", "DE"]`, `always`},
+		},
+		{
+			name:  "successfully find segments after an always segment",
+			rules: []string{`country == "IR"`, `always`, `age > 18`},
+			warnings: []constraint.Warning{
+				{
+					Segment: 2,
+					Kind:    constraint.UnreachableSegmentWarning,
+					Message: "segment is unreachable because segment 1 always matches",
+				},
+			},
+		},
+		{
+			name:  "successfully find shadowed segments",
+			rules: []string{`age > 18 or beta == "true"`, `age > 20 and country == "IR"`, `age > 10`},
+			warnings: []constraint.Warning{
+				{
+					Segment: 1,
+					Kind:    constraint.UnreachableSegmentWarning,
+					Message: "segment is unreachable because segment 0 matches all of its entities",
+				},
+			},
+		},
+		{
+			name:  "successfully find contradictions",
+			rules: []string{`age < 5 and age > 10`, `country == "IR" and not country == "IR"`, `a == "x" and a == "y"`},
+			warnings: []constraint.Warning{
+				{Segment: 0, Kind: constraint.ContradictionWarning, Message: "age < 5 and age > 10 never matches"},
+				{
+					Segment: 1,
+					Kind:    constraint.ContradictionWarning,
+					Message: `country == "IR" and not country == "IR" never matches`,
+				},
+				{Segment: 2, Kind: constraint.ContradictionWarning, Message: `a == "x" and a == "y" never matches`},
+			},
+		},
+		{
+			name:  "successfully find nested contradictions and tautologies",
+			rules: []string{`country == "IR" and (age >= 18 or not age >= 18)`, `beta == "true" or (age <= 5 and age > 5)`},
+			warnings: []constraint.Warning{
+				{Segment: 0, Kind: constraint.TautologyWarning, Message: "age >= 18 or not age >= 18 always matches"},
+				{Segment: 1, Kind: constraint.ContradictionWarning, Message: "age <= 5 and age > 5 never matches"},
+			},
+		},
+		{
+			name:  "successfully find duplicate constraints",
+			rules: []string{`country == "IR" and age > 18 and country == "IR"`},
+			warnings: []constraint.Warning{
+				{Segment: 0, Kind: constraint.DuplicateConstraintWarning, Message: `duplicate constraint country == "IR"`},
+			},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			var segments []model.Constraint
+
+			for _, rule := range tc.rules {
+				c, err := constraint.CompileRule(rule)
+				suite.NoError(err)
+
+				segments = append(segments, *c)
+			}
+
+			warnings, err := constraint.Analyze(segments)
+			suite.NoError(err)
+			suite.Equal(tc.warnings, warnings)
+		})
+	}
+}
+
+func (suite *AnalyzerSuite) TestAnalyzeRandom() {
+	random := model.Constraint{Name: constraint.RandomConstraintName, Parameters: json.RawMessage(`{}`)}
+	sticky := model.Constraint{Name: constraint.RandomConstraintName, Parameters: json.RawMessage(`{"sticky": true}`)}
+
+	warnings, err := constraint.Analyze([]model.Constraint{random, random})
+	suite.NoError(err)
+	suite.Empty(warnings, "random constraints are re-rolled on each evaluation")
+
+	warnings, err = constraint.Analyze([]model.Constraint{sticky, sticky})
+	suite.NoError(err)
+	suite.Len(warnings, 1)
+	suite.Equal(constraint.UnreachableSegmentWarning, warnings[0].Kind)
+}
+
+func TestAnalyzerSuite(t *testing.T) {
+	suite.Run(t, new(AnalyzerSuite))
+}
@@ -0,0 +1,82 @@
+package constraint
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// AudienceConstraint represents Openflag audience constraint.
+// It references a named audience. The evaluation engine replaces it with the constraints of the audience
+// when it fetches the flags, so an unresolved audience constraint does not match any entity.
+type AudienceConstraint struct {
+	Audience string `json:"audience"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (a AudienceConstraint) Name() string {
+	return AudienceConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (a AudienceConstraint) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(
+			&a.Audience,
+			validation.Required,
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (a *AudienceConstraint) Initialize() error {
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (a AudienceConstraint) Evaluate(_ model.Entity) bool {
+	return false
+}
+
+// ReferencedAudience returns the audience name if the given constraint is an audience constraint.
+func ReferencedAudience(c model.Constraint) (string, bool) {
+	if c.Name != AudienceConstraintName {
+		return "", false
+	}
+
+	a := AudienceConstraint{}
+
+	if err := json.Unmarshal(c.Parameters, &a); err != nil {
+		return "", false
+	}
+
+	return a.Audience, true
+}
+
+// ResolveAudiences replaces the audience constraints of the given constraints with the constraint trees
+// of the referenced audiences. It returns an error if one of the audiences can not be found.
+func ResolveAudiences(
+	constraints map[string]model.Constraint, audiences map[string]model.Constraint,
+) (map[string]model.Constraint, error) {
+	result := make(map[string]model.Constraint, len(constraints))
+
+	for identifier, c := range constraints {
+		name, ok := ReferencedAudience(c)
+		if !ok {
+			result[identifier] = c
+			continue
+		}
+
+		audience, ok := audiences[name]
+		if !ok {
+			return nil, fmt.Errorf("audience %s not found", name)
+		}
+
+		result[identifier] = audience
+	}
+
+	return result, nil
+}
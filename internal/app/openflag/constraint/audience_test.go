@@ -0,0 +1,77 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type AudienceConstraintSuite struct {
+	ConstraintSuite
+}
+
+func (suite *AudienceConstraintSuite) TestAudienceConstraint() {
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create unresolved constraint and evaluate",
+			Constraint: model.Constraint{
+				Name:       constraint.AudienceConstraintName,
+				Parameters: json.RawMessage(`{"audience": "employees"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: model.Entity{EntityID: 1}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "failed to create constraint without audience",
+			Constraint: model.Constraint{
+				Name:       constraint.AudienceConstraintName,
+				Parameters: json.RawMessage(`{}`),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func (suite *AudienceConstraintSuite) TestResolveAudiences() {
+	employees := model.Constraint{
+		Name:       constraint.ContainsConstraintName,
+		Parameters: json.RawMessage(`{"values": ["1", "2"]}`),
+	}
+
+	less := model.Constraint{
+		Name:       constraint.LessThanConstraintName,
+		Parameters: json.RawMessage(`{"value": 10}`),
+	}
+
+	resolved, err := constraint.ResolveAudiences(
+		map[string]model.Constraint{
+			"A": {Name: constraint.AudienceConstraintName, Parameters: json.RawMessage(`{"audience": "employees"}`)},
+			"B": less,
+		},
+		map[string]model.Constraint{"employees": employees},
+	)
+	suite.NoError(err)
+	suite.Equal(map[string]model.Constraint{"A": employees, "B": less}, resolved)
+
+	_, err = constraint.ResolveAudiences(
+		map[string]model.Constraint{
+			"A": {Name: constraint.AudienceConstraintName, Parameters: json.RawMessage(`{"audience": "others"}`)},
+		},
+		map[string]model.Constraint{"employees": employees},
+	)
+	suite.Error(err)
+}
+
+func TestAudienceConstraintSuite(t *testing.T) {
+	suite.Run(t, new(AudienceConstraintSuite))
+}
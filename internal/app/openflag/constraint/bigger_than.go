@@ -1,15 +1,12 @@
 package constraint
 
 import (
-	"strconv"
-
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
-
-	"github.com/sirupsen/logrus"
 )
 
 // BiggerThanConstraint represents Openflag bigger than constraint.
 type BiggerThanConstraint struct {
+	path     Path
 	Value    float64 `json:"value"`
 	Property string  `json:"property,omitempty"`
 }
@@ -26,25 +23,22 @@ func (b BiggerThanConstraint) Validate() error {
 
 // Initialize is an implementation for the Constraint interface.
 func (b *BiggerThanConstraint) Initialize() error {
+	path, err := CompilePath(b.Property)
+	if err != nil {
+		return err
+	}
+
+	b.path = path
+
 	return nil
 }
 
 // Evaluate is an implementation for the Constraint interface.
 func (b BiggerThanConstraint) Evaluate(e model.Entity) bool {
-	property, ok := GetProperty(b.Property, e)
+	value, ok := b.path.Number(e)
 	if !ok {
 		return false
 	}
 
-	value, err := strconv.ParseFloat(property, 64)
-	if err != nil {
-		logrus.Errorf(
-			"invalid property for bigger than constraint => property: %s, value: %s, err: %s",
-			b.Property, property, err.Error(),
-		)
-
-		return false
-	}
-
 	return value > b.Value
 }
@@ -104,7 +104,7 @@ func (suite *BiggerThanEqualConstraintSuite) TestBiggerThanEqualConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "11"},
+						EntityContext: model.Context{"test": model.StringValue("11")},
 					},
 					ResultExpected: true,
 				},
@@ -112,7 +112,7 @@ func (suite *BiggerThanEqualConstraintSuite) TestBiggerThanEqualConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "9"},
+						EntityContext: model.Context{"test": model.StringValue("9")},
 					},
 					ResultExpected: false,
 				},
@@ -120,7 +120,7 @@ func (suite *BiggerThanEqualConstraintSuite) TestBiggerThanEqualConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "10"},
+						EntityContext: model.Context{"test": model.StringValue("10")},
 					},
 					ResultExpected: true,
 				},
@@ -143,7 +143,7 @@ func (suite *BiggerThanEqualConstraintSuite) TestBiggerThanEqualConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "1"},
+						EntityContext: model.Context{"test": model.StringValue("1")},
 					},
 					ResultExpected: true,
 				},
@@ -151,7 +151,7 @@ func (suite *BiggerThanEqualConstraintSuite) TestBiggerThanEqualConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "-1"},
+						EntityContext: model.Context{"test": model.StringValue("-1")},
 					},
 					ResultExpected: false,
 				},
@@ -174,7 +174,7 @@ func (suite *BiggerThanEqualConstraintSuite) TestBiggerThanEqualConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "t"},
+						EntityContext: model.Context{"test": model.StringValue("t")},
 					},
 					ResultExpected: false,
 				},
@@ -182,7 +182,7 @@ func (suite *BiggerThanEqualConstraintSuite) TestBiggerThanEqualConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "1"},
+						EntityContext: model.Context{"test": model.StringValue("1")},
 					},
 					ResultExpected: true,
 				},
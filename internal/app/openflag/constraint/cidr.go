@@ -0,0 +1,193 @@
+package constraint
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	cidrSeparator = "/"
+	ipv4Offset    = net.IPv6len - net.IPv4len
+	fullMaskByte  = 0xff
+)
+
+type (
+	// ipRange represents an inclusive range of IP addresses in 16-byte form.
+	ipRange struct {
+		start net.IP
+		end   net.IP
+	}
+
+	// ipRanges represents sorted and non-overlapping IP ranges.
+	ipRanges []ipRange
+)
+
+// CIDRConstraint represents Openflag CIDR constraint.
+// It reads an IP address from the given property and matches it against a list of IPv4/IPv6 CIDR blocks
+// or single IP addresses.
+type CIDRConstraint struct {
+	path     Path
+	ranges   ipRanges
+	CIDRs    []string `json:"cidrs"`
+	Property string   `json:"property"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (c CIDRConstraint) Name() string {
+	return CIDRConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (c CIDRConstraint) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.CIDRs,
+			validation.Required,
+			validation.Length(minValueLen, 0),
+			validation.By(func(value interface{}) error {
+				for _, cidr := range c.CIDRs {
+					if _, err := parseIPRange(cidr); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}),
+		),
+		validation.Field(
+			&c.Property,
+			validation.Required,
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (c *CIDRConstraint) Initialize() error {
+	path, err := CompilePath(c.Property)
+	if err != nil {
+		return err
+	}
+
+	c.path = path
+
+	ranges := ipRanges{}
+
+	for _, cidr := range c.CIDRs {
+		r, err := parseIPRange(cidr)
+		if err != nil {
+			return err
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	c.ranges = ranges.merge()
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (c CIDRConstraint) Evaluate(e model.Entity) bool {
+	property, ok := c.path.String(e)
+	if !ok {
+		return false
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(property))
+	if ip == nil {
+		return false
+	}
+
+	return c.ranges.contains(ip.To16())
+}
+
+// parseIPRange parses a CIDR block (e.g. "10.0.0.0/8") or a single IP address into an IP range.
+func parseIPRange(value string) (ipRange, error) {
+	value = strings.TrimSpace(value)
+
+	if !strings.Contains(value, cidrSeparator) {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return ipRange{}, fmt.Errorf("invalid IP address %s", value)
+		}
+
+		return ipRange{start: ip.To16(), end: ip.To16()}, nil
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return ipRange{}, err
+	}
+
+	start := network.IP.To16()
+	mask := network.Mask
+
+	if len(mask) == net.IPv4len {
+		mask = append(net.IPMask(bytes.Repeat([]byte{fullMaskByte}, ipv4Offset)), mask...)
+	}
+
+	end := make(net.IP, net.IPv6len)
+
+	for i := range start {
+		end[i] = start[i] | ^mask[i]
+	}
+
+	return ipRange{start: start, end: end}, nil
+}
+
+// merge sorts the ranges and merges overlapping and adjacent ones, so that lookups can use binary search.
+func (r ipRanges) merge() ipRanges {
+	sort.Slice(r, func(i, j int) bool {
+		return bytes.Compare(r[i].start, r[j].start) < 0
+	})
+
+	result := ipRanges{}
+
+	for _, current := range r {
+		if len(result) != 0 {
+			last := &result[len(result)-1]
+
+			if bytes.Compare(current.start, nextIP(last.end)) <= 0 {
+				if bytes.Compare(current.end, last.end) > 0 {
+					last.end = current.end
+				}
+
+				continue
+			}
+		}
+
+		result = append(result, current)
+	}
+
+	return result
+}
+
+func (r ipRanges) contains(ip net.IP) bool {
+	i := sort.Search(len(r), func(i int) bool {
+		return bytes.Compare(r[i].end, ip) >= 0
+	})
+
+	return i < len(r) && bytes.Compare(r[i].start, ip) <= 0
+}
+
+// nextIP returns the IP address after the given one. It returns the same address if there is not any.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next
+		}
+	}
+
+	return ip
+}
@@ -0,0 +1,161 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type CIDRConstraintSuite struct {
+	ConstraintSuite
+}
+
+func ipEntity(ip string) model.Entity {
+	return model.Entity{
+		EntityID:      1,
+		EntityContext: model.Context{"ip": model.StringValue(ip)},
+	}
+}
+
+func (suite *CIDRConstraintSuite) TestCIDRConstraint() {
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create constraint and evaluate 1",
+			Constraint: model.Constraint{
+				Name: constraint.CIDRConstraintName,
+				Parameters: json.RawMessage(
+					`{"cidrs": ["10.0.0.0/8", "192.168.1.0/24", "172.16.0.5", "2001:db8::/32"], "property": "ip"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: ipEntity("10.1.2.3"), ResultExpected: true},
+				{Entity: ipEntity("11.0.0.0"), ResultExpected: false},
+				{Entity: ipEntity("192.168.1.255"), ResultExpected: true},
+				{Entity: ipEntity("192.168.2.0"), ResultExpected: false},
+				{Entity: ipEntity("172.16.0.5"), ResultExpected: true},
+				{Entity: ipEntity("172.16.0.6"), ResultExpected: false},
+				{Entity: ipEntity("::ffff:10.0.0.1"), ResultExpected: true},
+				{Entity: ipEntity("2001:db8:ffff::1"), ResultExpected: true},
+				{Entity: ipEntity("2001:db9::1"), ResultExpected: false},
+				{Entity: ipEntity("invalid"), ResultExpected: false},
+				{Entity: model.Entity{EntityID: 1}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate 2",
+			Constraint: model.Constraint{
+				Name: constraint.CIDRConstraintName,
+				Parameters: json.RawMessage(
+					`{"cidrs": ["10.0.0.0/24", "10.0.0.128/25", "10.0.1.0/24", "0.0.0.0/32"], "property": "ip"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: ipEntity("10.0.0.200"), ResultExpected: true},
+				{Entity: ipEntity("10.0.1.200"), ResultExpected: true},
+				{Entity: ipEntity("10.0.2.0"), ResultExpected: false},
+				{Entity: ipEntity("0.0.0.0"), ResultExpected: true},
+			},
+		},
+		{
+			Name: "failed to create constraint with invalid cidr",
+			Constraint: model.Constraint{
+				Name: constraint.CIDRConstraintName,
+				Parameters: json.RawMessage(
+					`{"cidrs": ["10.0.0.0/33"], "property": "ip"}`,
+				),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with invalid ip",
+			Constraint: model.Constraint{
+				Name: constraint.CIDRConstraintName,
+				Parameters: json.RawMessage(
+					`{"cidrs": ["10.0.0.256"], "property": "ip"}`,
+				),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint without property",
+			Constraint: model.Constraint{
+				Name: constraint.CIDRConstraintName,
+				Parameters: json.RawMessage(
+					`{"cidrs": ["10.0.0.0/8"]}`,
+				),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint without cidrs",
+			Constraint: model.Constraint{
+				Name: constraint.CIDRConstraintName,
+				Parameters: json.RawMessage(
+					`{"cidrs": [], "property": "ip"}`,
+				),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func (suite *CIDRConstraintSuite) TestCIDRConstraintWithManyRanges() {
+	cidrs := []string{}
+	networks := []*net.IPNet{}
+
+	for i := 0; i < 4000; i++ {
+		cidr := fmt.Sprintf("%d.%d.%d.0/%d", 1+i%200, (i*7)%256, (i*13)%256, 20+i%9)
+
+		_, network, err := net.ParseCIDR(cidr)
+		suite.NoError(err)
+
+		cidrs = append(cidrs, cidr)
+		networks = append(networks, network)
+	}
+
+	parameters, err := json.Marshal(map[string]interface{}{"cidrs": cidrs, "property": "ip"})
+	suite.NoError(err)
+
+	c, err := constraint.New(constraint.CIDRConstraintName, parameters)
+	suite.NoError(err)
+
+	for i := 0; i < 5000; i++ {
+		ip := net.IPv4(byte(1+i%210), byte((i*31)%256), byte((i*17)%256), byte(i%256))
+
+		// Half of the addresses are picked near the defined networks to cover their boundaries.
+		if i%2 == 0 {
+			base := networks[i%len(networks)].IP.To4()
+			ip = net.IPv4(base[0], base[1], base[2]+byte(i%32), byte(i%256))
+		}
+
+		expected := false
+
+		for _, network := range networks {
+			if network.Contains(ip) {
+				expected = true
+				break
+			}
+		}
+
+		suite.Equal(expected, c.Evaluate(ipEntity(ip.String())), ip.String())
+	}
+}
+
+func TestCIDRConstraintSuite(t *testing.T) {
+	suite.Run(t, new(CIDRConstraintSuite))
+}
@@ -0,0 +1,16 @@
+package constraint
+
+import "time"
+
+// Clock represents a function that returns the current time.
+// Time based constraints use it so that their evaluations can be tested deterministically.
+type Clock func() time.Time
+
+// Now returns the current time using the clock or the system clock if it is not set.
+func (c Clock) Now() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+
+	return c()
+}
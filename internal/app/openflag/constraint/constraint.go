@@ -3,7 +3,6 @@ package constraint
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
 )
@@ -16,7 +15,14 @@ const (
 	MatchConstraintName           = "match"
 	RandomConstraintName          = "random"
 	RolloutConstraintName         = "rollout"
+	RampConstraintName            = "ramp"
 	CronConstraintName            = "cron"
+	TimeWindowConstraintName      = "time_window"
+	DateTimeConstraintName        = "datetime"
+	EntityAgeConstraintName       = "entity_age"
+	FrequencyCapConstraintName    = "frequency_cap"
+	CIDRConstraintName            = "cidr"
+	GeoConstraintName             = "geo"
 	IntersectionConstraintName    = "∩"
 	UnionConstraintName           = "∪"
 	LessThanConstraintName        = "<"
@@ -25,6 +31,25 @@ const (
 	BiggerThanEqualConstraintName = ">="
 	NotConstraintName             = "!"
 	ModConstraintName             = "%"
+
+	SemverEqualConstraintName           = "semver_equal"
+	SemverLessThanConstraintName        = "semver_less_than"
+	SemverLessThanEqualConstraintName   = "semver_less_than_equal"
+	SemverBiggerThanConstraintName      = "semver_bigger_than"
+	SemverBiggerThanEqualConstraintName = "semver_bigger_than_equal"
+	SemverRangeConstraintName           = "semver_range"
+
+	ContainsIgnoreCaseConstraintName = "contains_ignore_case"
+	StartsWithConstraintName         = "starts_with"
+	EndsWithConstraintName           = "ends_with"
+	SubstringConstraintName          = "substring"
+
+	ContainsAnyConstraintName = "contains_any"
+	ContainsAllConstraintName = "contains_all"
+
+	AudienceConstraintName     = "audience"
+	PrerequisiteConstraintName = "prerequisite"
+	ListConstraintName         = "list"
 )
 
 const (
@@ -44,26 +69,84 @@ type Constraint interface {
 	Evaluate(model.Entity) bool
 }
 
-// BasicConstraints returns basic constraints.
+// ParentConstraint represents a constraint that is composed of other constraints.
+type ParentConstraint interface {
+	// Children returns the nested constraints.
+	Children() []Constraint
+}
+
+// Walk calls the given function for the constraint and all of its nested constraints.
+func Walk(c Constraint, fn func(Constraint)) {
+	fn(c)
+
+	if pc, ok := c.(ParentConstraint); ok {
+		for _, child := range pc.Children() {
+			Walk(child, fn)
+		}
+	}
+}
+
+// BasicConstraints returns basic constraints, including the registered custom constraints.
 func BasicConstraints() []string {
-	return []string{
+	names := []string{
 		AlwaysConstraintName,
 		ContainsConstraintName,
 		ExcludesConstraintName,
 		MatchConstraintName,
+		ContainsIgnoreCaseConstraintName,
+		StartsWithConstraintName,
+		EndsWithConstraintName,
+		SubstringConstraintName,
+		ContainsAnyConstraintName,
+		ContainsAllConstraintName,
+		AudienceConstraintName,
+		PrerequisiteConstraintName,
+		ListConstraintName,
 		RandomConstraintName,
 		RolloutConstraintName,
+		RampConstraintName,
 		CronConstraintName,
+		TimeWindowConstraintName,
+		DateTimeConstraintName,
+		EntityAgeConstraintName,
+		FrequencyCapConstraintName,
+		CIDRConstraintName,
+		GeoConstraintName,
 		LessThanConstraintName,
 		LessThanEqualConstraintName,
 		BiggerThanConstraintName,
 		BiggerThanEqualConstraintName,
 		ModConstraintName,
+		SemverEqualConstraintName,
+		SemverLessThanConstraintName,
+		SemverLessThanEqualConstraintName,
+		SemverBiggerThanConstraintName,
+		SemverBiggerThanEqualConstraintName,
+		SemverRangeConstraintName,
+	}
+
+	for _, r := range Registered() {
+		names = append(names, r.Name)
 	}
+
+	return names
 }
 
-// Find finds the constraint using the given name.
+// Find finds the constraint using the given name. It looks the registered custom constraints up
+// if there is not any basic constraint with the name.
 func Find(name string) (Constraint, error) {
+	if c, err := findBasic(name); err == nil {
+		return c, nil
+	}
+
+	if r, ok := findRegistration(name); ok {
+		return r.Factory(), nil
+	}
+
+	return nil, errors.New("invalid constraint name")
+}
+
+func findBasic(name string) (Constraint, error) {
 	switch name {
 	case AlwaysConstraintName:
 		return &AlwaysConstraint{}, nil
@@ -75,12 +158,44 @@ func Find(name string) (Constraint, error) {
 		return &IntersectionConstraint{}, nil
 	case MatchConstraintName:
 		return &MatchConstraint{}, nil
+	case ContainsIgnoreCaseConstraintName:
+		return &ContainsIgnoreCaseConstraint{}, nil
+	case StartsWithConstraintName:
+		return &StartsWithConstraint{}, nil
+	case EndsWithConstraintName:
+		return &EndsWithConstraint{}, nil
+	case SubstringConstraintName:
+		return &SubstringConstraint{}, nil
+	case ContainsAnyConstraintName:
+		return &ContainsAnyConstraint{}, nil
+	case ContainsAllConstraintName:
+		return &ContainsAllConstraint{}, nil
+	case AudienceConstraintName:
+		return &AudienceConstraint{}, nil
+	case PrerequisiteConstraintName:
+		return &PrerequisiteConstraint{}, nil
+	case ListConstraintName:
+		return &ListConstraint{}, nil
 	case RandomConstraintName:
 		return &RandomConstraint{}, nil
 	case RolloutConstraintName:
 		return &RolloutConstraint{}, nil
+	case RampConstraintName:
+		return &RampConstraint{}, nil
 	case CronConstraintName:
 		return &CronConstraint{}, nil
+	case TimeWindowConstraintName:
+		return &TimeWindowConstraint{}, nil
+	case DateTimeConstraintName:
+		return &DateTimeConstraint{}, nil
+	case EntityAgeConstraintName:
+		return &EntityAgeConstraint{}, nil
+	case FrequencyCapConstraintName:
+		return &FrequencyCapConstraint{}, nil
+	case CIDRConstraintName:
+		return &CIDRConstraint{}, nil
+	case GeoConstraintName:
+		return &GeoConstraint{}, nil
 	case UnionConstraintName:
 		return &UnionConstraint{}, nil
 	case LessThanConstraintName:
@@ -95,6 +210,18 @@ func Find(name string) (Constraint, error) {
 		return &NotConstraint{}, nil
 	case ModConstraintName:
 		return &ModConstraint{}, nil
+	case SemverEqualConstraintName:
+		return &SemverEqualConstraint{}, nil
+	case SemverLessThanConstraintName:
+		return &SemverLessThanConstraint{}, nil
+	case SemverLessThanEqualConstraintName:
+		return &SemverLessThanEqualConstraint{}, nil
+	case SemverBiggerThanConstraintName:
+		return &SemverBiggerThanConstraint{}, nil
+	case SemverBiggerThanEqualConstraintName:
+		return &SemverBiggerThanEqualConstraint{}, nil
+	case SemverRangeConstraintName:
+		return &SemverRangeConstraint{}, nil
 	default:
 		return nil, errors.New("invalid constraint name")
 	}
@@ -107,6 +234,10 @@ func Validate(name string, parameters json.RawMessage) error {
 		return err
 	}
 
+	if err := validateParameters(name, parameters); err != nil {
+		return err
+	}
+
 	if err := json.Unmarshal(parameters, c); err != nil {
 		return err
 	}
@@ -125,6 +256,10 @@ func New(name string, parameters json.RawMessage) (Constraint, error) {
 		return nil, err
 	}
 
+	if err := validateParameters(name, parameters); err != nil {
+		return nil, err
+	}
+
 	if err := json.Unmarshal(parameters, c); err != nil {
 		return nil, err
 	}
@@ -140,23 +275,24 @@ func New(name string, parameters json.RawMessage) (Constraint, error) {
 	return c, nil
 }
 
-// GetProperty returns the property value of a constraint for applying.
-func GetProperty(property string, e model.Entity) (string, bool) {
-	var value string
+// GetValue returns the typed property value of a constraint for applying. The property can be a dotted path
+// or a JSON pointer to a nested value. The entity id is returned as a string, so that it keeps its precision.
+// Constraints should compile their paths once using CompilePath instead.
+func GetValue(property string, e model.Entity) (model.Value, bool) {
+	path, err := CompilePath(property)
+	if err != nil {
+		return model.Value{}, false
+	}
 
-	switch property {
-	case "":
-		value = fmt.Sprintf("%d", e.EntityID)
-	case EntityTypeProperty:
-		value = e.EntityType
-	default:
-		var ok bool
+	return path.Value(e)
+}
 
-		value, ok = e.EntityContext[property]
-		if !ok {
-			return "", false
-		}
+// GetProperty returns the property value of a constraint for applying in the string form.
+func GetProperty(property string, e model.Entity) (string, bool) {
+	value, ok := GetValue(property, e)
+	if !ok {
+		return "", false
 	}
 
-	return value, true
+	return value.String(), true
 }
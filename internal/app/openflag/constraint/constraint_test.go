@@ -53,7 +53,7 @@ func TestGetProperty(t *testing.T) {
 	e := model.Entity{
 		EntityID:      10,
 		EntityType:    "test",
-		EntityContext: map[string]string{"context": "context"},
+		EntityContext: model.Context{"context": model.StringValue("context")},
 	}
 
 	property, ok := constraint.GetProperty("", e)
@@ -11,6 +11,7 @@ const (
 
 // ContainsConstraint represents Openflag contains constraint.
 type ContainsConstraint struct {
+	path     Path
 	valueMap map[string]struct{}
 	Values   []string `json:"values"`
 	Property string   `json:"property,omitempty"`
@@ -34,6 +35,13 @@ func (c ContainsConstraint) Validate() error {
 
 // Initialize is an implementation for the Constraint interface.
 func (c *ContainsConstraint) Initialize() error {
+	path, err := CompilePath(c.Property)
+	if err != nil {
+		return err
+	}
+
+	c.path = path
+
 	valueMap := make(map[string]struct{})
 
 	for _, value := range c.Values {
@@ -47,7 +55,7 @@ func (c *ContainsConstraint) Initialize() error {
 
 // Evaluate is an implementation for the Constraint interface.
 func (c ContainsConstraint) Evaluate(e model.Entity) bool {
-	property, ok := GetProperty(c.Property, e)
+	property, ok := c.path.String(e)
 	if !ok {
 		return false
 	}
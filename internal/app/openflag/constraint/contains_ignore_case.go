@@ -0,0 +1,66 @@
+package constraint
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"golang.org/x/text/cases"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ContainsIgnoreCaseConstraint represents Openflag case-insensitive contains constraint.
+// It works like the contains constraint but compares the values using full Unicode case folding,
+// e.g. "straße" matches "STRASSE".
+type ContainsIgnoreCaseConstraint struct {
+	path     Path
+	valueMap map[string]struct{}
+	Values   []string `json:"values"`
+	Property string   `json:"property,omitempty"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (c ContainsIgnoreCaseConstraint) Name() string {
+	return ContainsIgnoreCaseConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (c ContainsIgnoreCaseConstraint) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Values,
+			validation.Required,
+			validation.Length(minValueLen, 0),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (c *ContainsIgnoreCaseConstraint) Initialize() error {
+	path, err := CompilePath(c.Property)
+	if err != nil {
+		return err
+	}
+
+	c.path = path
+
+	valueMap := make(map[string]struct{})
+
+	for _, value := range c.Values {
+		valueMap[cases.Fold().String(value)] = struct{}{}
+	}
+
+	c.valueMap = valueMap
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (c ContainsIgnoreCaseConstraint) Evaluate(e model.Entity) bool {
+	property, ok := c.path.String(e)
+	if !ok {
+		return false
+	}
+
+	_, ok = c.valueMap[cases.Fold().String(property)]
+
+	return ok
+}
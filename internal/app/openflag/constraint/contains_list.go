@@ -0,0 +1,117 @@
+package constraint
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// listOperator contains the shared parameters and logic of the constraints on list properties.
+// Properties that are not lists are treated as a list with a single item.
+type listOperator struct {
+	path     Path
+	valueMap map[string]struct{}
+	Values   []string `json:"values"`
+	Property string   `json:"property,omitempty"`
+}
+
+func (l listOperator) validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(
+			&l.Values,
+			validation.Required,
+			validation.Length(minValueLen, 0),
+		),
+	)
+}
+
+func (l *listOperator) initialize() error {
+	path, err := CompilePath(l.Property)
+	if err != nil {
+		return err
+	}
+
+	l.path = path
+
+	l.valueMap = make(map[string]struct{})
+
+	for _, value := range l.Values {
+		l.valueMap[value] = struct{}{}
+	}
+
+	return nil
+}
+
+// matches returns the number of the distinct values that the list property contains.
+func (l listOperator) matches(e model.Entity) (int, bool) {
+	items, ok := l.path.List(e)
+	if !ok {
+		return 0, false
+	}
+
+	found := make(map[string]struct{})
+
+	for _, item := range items {
+		if _, ok := l.valueMap[item]; ok {
+			found[item] = struct{}{}
+		}
+	}
+
+	return len(found), true
+}
+
+// ContainsAnyConstraint represents Openflag contains any constraint.
+// It matches when the list property contains at least one of the values, e.g. one of the roles.
+type ContainsAnyConstraint struct {
+	listOperator
+}
+
+// Name is an implementation for the Constraint interface.
+func (c ContainsAnyConstraint) Name() string {
+	return ContainsAnyConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (c ContainsAnyConstraint) Validate() error {
+	return c.validate()
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (c *ContainsAnyConstraint) Initialize() error {
+	return c.initialize()
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (c ContainsAnyConstraint) Evaluate(e model.Entity) bool {
+	found, ok := c.matches(e)
+
+	return ok && found > 0
+}
+
+// ContainsAllConstraint represents Openflag contains all constraint.
+// It matches when the list property contains all of the values.
+type ContainsAllConstraint struct {
+	listOperator
+}
+
+// Name is an implementation for the Constraint interface.
+func (c ContainsAllConstraint) Name() string {
+	return ContainsAllConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (c ContainsAllConstraint) Validate() error {
+	return c.validate()
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (c *ContainsAllConstraint) Initialize() error {
+	return c.initialize()
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (c ContainsAllConstraint) Evaluate(e model.Entity) bool {
+	found, ok := c.matches(e)
+
+	return ok && found == len(c.valueMap)
+}
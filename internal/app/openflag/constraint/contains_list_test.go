@@ -0,0 +1,101 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContainsListConstraintSuite struct {
+	ConstraintSuite
+}
+
+func (suite *ContainsListConstraintSuite) TestContainsListConstraints() {
+	roles := func(values ...string) model.Entity {
+		return model.Entity{EntityContext: model.Context{"roles": model.ListValue(values)}}
+	}
+
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create contains any constraint and evaluate",
+			Constraint: model.Constraint{
+				Name:       constraint.ContainsAnyConstraintName,
+				Parameters: json.RawMessage(`{"values": ["admin", "editor"], "property": "roles"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: roles("viewer", "editor"), ResultExpected: true},
+				{Entity: roles("viewer"), ResultExpected: false},
+				{Entity: roles(), ResultExpected: false},
+				{
+					Entity:         model.Entity{EntityContext: model.Context{"roles": model.StringValue("admin")}},
+					ResultExpected: true,
+				},
+				{Entity: model.Entity{}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create contains all constraint and evaluate",
+			Constraint: model.Constraint{
+				Name:       constraint.ContainsAllConstraintName,
+				Parameters: json.RawMessage(`{"values": ["admin", "editor", "admin"], "property": "roles"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: roles("editor", "viewer", "admin"), ResultExpected: true},
+				{Entity: roles("admin", "admin"), ResultExpected: false},
+				{
+					Entity:         model.Entity{EntityContext: model.Context{"roles": model.StringValue("admin")}},
+					ResultExpected: false,
+				},
+			},
+		},
+		{
+			Name: "failed to create contains any constraint without values",
+			Constraint: model.Constraint{
+				Name:       constraint.ContainsAnyConstraintName,
+				Parameters: json.RawMessage(`{"values": [], "property": "roles"}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create contains all constraint without values",
+			Constraint: model.Constraint{
+				Name:       constraint.ContainsAllConstraintName,
+				Parameters: json.RawMessage(`{"property": "roles"}`),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func (suite *ContainsListConstraintSuite) TestTypedNumericConstraints() {
+	c, err := constraint.New(constraint.BiggerThanEqualConstraintName, json.RawMessage(`{"value": 18, "property": "age"}`))
+	suite.NoError(err)
+
+	suite.True(c.Evaluate(model.Entity{EntityContext: model.Context{"age": model.NumberValue(18)}}))
+	suite.True(c.Evaluate(model.Entity{EntityContext: model.Context{"age": model.StringValue("21")}}))
+	suite.False(c.Evaluate(model.Entity{EntityContext: model.Context{"age": model.BoolValue(true)}}))
+	suite.False(c.Evaluate(model.Entity{EntityContext: model.Context{"age": model.ListValue([]string{"21"})}}))
+
+	c, err = constraint.New(constraint.ModConstraintName, json.RawMessage(`{"value": 2, "property": "count"}`))
+	suite.NoError(err)
+
+	suite.True(c.Evaluate(model.Entity{EntityContext: model.Context{"count": model.NumberValue(4)}}))
+	suite.False(c.Evaluate(model.Entity{EntityContext: model.Context{"count": model.NumberValue(4.5)}}))
+}
+
+func TestContainsListConstraintSuite(t *testing.T) {
+	suite.Run(t, new(ContainsListConstraintSuite))
+}
@@ -90,7 +90,7 @@ func (suite *ContainsConstraintSuite) TestContainsConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "11"},
+						EntityContext: model.Context{"test": model.StringValue("11")},
 					},
 					ResultExpected: true,
 				},
@@ -98,7 +98,7 @@ func (suite *ContainsConstraintSuite) TestContainsConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "9"},
+						EntityContext: model.Context{"test": model.StringValue("9")},
 					},
 					ResultExpected: false,
 				},
@@ -0,0 +1,112 @@
+package constraint
+
+import (
+	"errors"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	localDateTimeFormat = "2006-01-02T15:04:05"
+	defaultTimezone     = "UTC"
+)
+
+// DateTimeConstraint represents Openflag date time constraint.
+// It matches when the current time is between start and end (both inclusive). Both bounds are optional
+// and are RFC3339 timestamps. Timestamps without a UTC offset (e.g. "2020-11-01T09:00:00") are
+// interpreted in the given IANA timezone, which defaults to UTC.
+type DateTimeConstraint struct {
+	start    *time.Time
+	end      *time.Time
+	Start    string `json:"start,omitempty"`
+	End      string `json:"end,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	Clock    Clock  `json:"-"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (d DateTimeConstraint) Name() string {
+	return DateTimeConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (d DateTimeConstraint) Validate() error {
+	if d.Start == "" && d.End == "" {
+		return errors.New("at least one of start and end should be set")
+	}
+
+	if d.start != nil && d.end != nil && !d.start.Before(*d.end) {
+		return errors.New("start should be before end")
+	}
+
+	return validation.ValidateStruct(&d,
+		validation.Field(
+			&d.Timezone,
+			validation.By(func(value interface{}) error {
+				_, err := loadLocation(value.(string))
+
+				return err
+			}),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (d *DateTimeConstraint) Initialize() error {
+	location, err := loadLocation(d.Timezone)
+	if err != nil {
+		return err
+	}
+
+	if d.start, err = parseDateTime(d.Start, location); err != nil {
+		return err
+	}
+
+	if d.end, err = parseDateTime(d.End, location); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (d DateTimeConstraint) Evaluate(_ model.Entity) bool {
+	now := d.Clock.Now()
+
+	if d.start != nil && now.Before(*d.start) {
+		return false
+	}
+
+	if d.end != nil && now.After(*d.end) {
+		return false
+	}
+
+	return true
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		timezone = defaultTimezone
+	}
+
+	return time.LoadLocation(timezone)
+}
+
+func parseDateTime(value string, location *time.Location) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.ParseInLocation(localDateTimeFormat, value, location)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
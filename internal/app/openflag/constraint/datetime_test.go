@@ -0,0 +1,140 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type DateTimeConstraintSuite struct {
+	ConstraintSuite
+}
+
+func (suite *DateTimeConstraintSuite) TestDateTimeConstraint() {
+	tehran, err := time.LoadLocation("Asia/Tehran")
+	suite.NoError(err)
+
+	cases := []struct {
+		name        string
+		parameters  string
+		evaluations []struct {
+			now            time.Time
+			resultExpected bool
+		}
+	}{
+		{
+			name:       "successfully evaluate window with timezone",
+			parameters: `{"start": "2020-11-01T09:00:00", "end": "2020-11-03T23:59:00", "timezone": "Asia/Tehran"}`,
+			evaluations: []struct {
+				now            time.Time
+				resultExpected bool
+			}{
+				{now: time.Date(2020, 11, 1, 8, 59, 0, 0, tehran), resultExpected: false},
+				{now: time.Date(2020, 11, 1, 9, 0, 0, 0, tehran), resultExpected: true},
+				{now: time.Date(2020, 11, 1, 5, 30, 0, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2020, 11, 3, 23, 59, 0, 0, tehran), resultExpected: true},
+				{now: time.Date(2020, 11, 3, 23, 59, 1, 0, tehran), resultExpected: false},
+			},
+		},
+		{
+			name:       "successfully evaluate window with RFC3339 timestamps",
+			parameters: `{"start": "2020-11-01T09:00:00+03:30", "end": "2020-11-03T23:59:00Z"}`,
+			evaluations: []struct {
+				now            time.Time
+				resultExpected bool
+			}{
+				{now: time.Date(2020, 11, 1, 5, 29, 0, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2020, 11, 3, 23, 59, 0, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2020, 11, 4, 0, 0, 0, 0, time.UTC), resultExpected: false},
+			},
+		},
+		{
+			name:       "successfully evaluate window with only start",
+			parameters: `{"start": "2020-11-01T09:00:00Z"}`,
+			evaluations: []struct {
+				now            time.Time
+				resultExpected bool
+			}{
+				{now: time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2030, 11, 1, 0, 0, 0, 0, time.UTC), resultExpected: true},
+			},
+		},
+		{
+			name:       "successfully evaluate window with only end",
+			parameters: `{"end": "2020-11-01T09:00:00Z"}`,
+			evaluations: []struct {
+				now            time.Time
+				resultExpected bool
+			}{
+				{now: time.Date(2010, 10, 1, 0, 0, 0, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2020, 11, 1, 9, 0, 1, 0, time.UTC), resultExpected: false},
+			},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+
+		suite.Run(tc.name, func() {
+			c, err := constraint.New(constraint.DateTimeConstraintName, json.RawMessage(tc.parameters))
+			suite.NoError(err)
+
+			dc, ok := c.(*constraint.DateTimeConstraint)
+			suite.True(ok)
+
+			for _, ev := range tc.evaluations {
+				now := ev.now
+				dc.Clock = func() time.Time { return now }
+
+				suite.Equal(ev.resultExpected, dc.Evaluate(model.Entity{EntityID: 1}), now.String())
+			}
+		})
+	}
+}
+
+func (suite *DateTimeConstraintSuite) TestDateTimeConstraintValidation() {
+	cases := []ConstraintTestCase{
+		{
+			Name: "failed to create constraint without start and end",
+			Constraint: model.Constraint{
+				Name:       constraint.DateTimeConstraintName,
+				Parameters: json.RawMessage(`{"timezone": "Asia/Tehran"}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with start after end",
+			Constraint: model.Constraint{
+				Name:       constraint.DateTimeConstraintName,
+				Parameters: json.RawMessage(`{"start": "2020-11-03T00:00:00Z", "end": "2020-11-01T00:00:00Z"}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with invalid timezone",
+			Constraint: model.Constraint{
+				Name:       constraint.DateTimeConstraintName,
+				Parameters: json.RawMessage(`{"start": "2020-11-01T00:00:00", "timezone": "Mars/Olympus"}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with invalid timestamp",
+			Constraint: model.Constraint{
+				Name:       constraint.DateTimeConstraintName,
+				Parameters: json.RawMessage(`{"start": "yesterday"}`),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func TestDateTimeConstraintSuite(t *testing.T) {
+	suite.Run(t, new(DateTimeConstraintSuite))
+}
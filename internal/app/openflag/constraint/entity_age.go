@@ -0,0 +1,141 @@
+package constraint
+
+import (
+	"errors"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// EntitySeenChecker represents an interface for loading the first seen and the last seen timestamps of entities.
+// The evaluation engine puts an EntitySeenChecker into the entity scope for entity age constraints.
+type EntitySeenChecker interface {
+	// Seen returns the first seen and the last seen timestamps of the given entity if they are recorded.
+	Seen(e model.Entity) (firstSeen *time.Time, lastSeen *time.Time)
+}
+
+// Represents the timestamps of an entity that the entity age constraint reads.
+const (
+	FirstSeenProperty = "first_seen"
+	LastSeenProperty  = "last_seen"
+)
+
+// EntityAgeConstraint represents Openflag entity age constraint.
+// It compares the time that is passed since the first seen (by default) or the last seen timestamp of
+// the entity with the given durations, e.g. {"max_age": "168h"} matches the entities that are first seen
+// in the last 7 days. It matches when the age is at least the min age and less than the max age.
+// The timestamps are recorded when the entity contexts are saved. They come with the stored contexts or are
+// loaded from the EntitySeenChecker of the entity scope, so entities without the timestamp do not match.
+type EntityAgeConstraint struct {
+	minAge    *time.Duration
+	maxAge    *time.Duration
+	Timestamp string `json:"timestamp,omitempty"`
+	MinAge    string `json:"min_age,omitempty"`
+	MaxAge    string `json:"max_age,omitempty"`
+	Clock     Clock  `json:"-"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (a EntityAgeConstraint) Name() string {
+	return EntityAgeConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (a EntityAgeConstraint) Validate() error {
+	if a.minAge == nil && a.maxAge == nil {
+		return errors.New("at least one of min age and max age should be set")
+	}
+
+	if a.minAge != nil && a.maxAge != nil && *a.minAge >= *a.maxAge {
+		return errors.New("min age should be less than max age")
+	}
+
+	return validation.ValidateStruct(&a,
+		validation.Field(
+			&a.Timestamp,
+			validation.In(FirstSeenProperty, LastSeenProperty),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (a *EntityAgeConstraint) Initialize() error {
+	var err error
+
+	if a.minAge, err = parseAge(a.MinAge); err != nil {
+		return err
+	}
+
+	if a.maxAge, err = parseAge(a.MaxAge); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (a EntityAgeConstraint) Evaluate(e model.Entity) bool {
+	firstSeen, lastSeen := e.FirstSeen, e.LastSeen
+
+	if checker, ok := e.Scope().(EntitySeenChecker); ok && firstSeen == nil && lastSeen == nil {
+		firstSeen, lastSeen = checker.Seen(e)
+	}
+
+	property, timestamp := FirstSeenProperty, firstSeen
+	if a.Timestamp == LastSeenProperty {
+		property, timestamp = LastSeenProperty, lastSeen
+	}
+
+	if timestamp == nil {
+		recordProperty(e, property, model.Value{}, false)
+		metrics.reportMissingProperty(EntityAgeConstraintName, property)
+
+		return false
+	}
+
+	recordProperty(e, property, model.StringValue(timestamp.UTC().Format(time.RFC3339)), true)
+
+	age := a.Clock.Now().Sub(*timestamp)
+
+	if a.minAge != nil && age < *a.minAge {
+		return false
+	}
+
+	if a.maxAge != nil && age >= *a.maxAge {
+		return false
+	}
+
+	return true
+}
+
+// EntityAgeConstraints returns all entity age constraints of the given constraint tree.
+func EntityAgeConstraints(c Constraint) []EntityAgeConstraint {
+	var ages []EntityAgeConstraint
+
+	Walk(c, func(c Constraint) {
+		if a, ok := c.(*EntityAgeConstraint); ok {
+			ages = append(ages, *a)
+		}
+	})
+
+	return ages
+}
+
+func parseAge(value string) (*time.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if age < 0 {
+		return nil, errors.New("age should not be negative")
+	}
+
+	return &age, nil
+}
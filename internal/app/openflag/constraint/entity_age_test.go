@@ -0,0 +1,133 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type EntityAgeConstraintSuite struct {
+	ConstraintSuite
+}
+
+func (suite *EntityAgeConstraintSuite) TestEntityAgeConstraint() {
+	now := time.Date(2020, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	seen := func(first, last time.Duration) model.Entity {
+		firstSeen, lastSeen := now.Add(-first), now.Add(-last)
+
+		return model.Entity{EntityID: 1, FirstSeen: &firstSeen, LastSeen: &lastSeen}
+	}
+
+	cases := []struct {
+		name        string
+		parameters  string
+		evaluations []struct {
+			entity         model.Entity
+			resultExpected bool
+		}
+	}{
+		{
+			name:       "successfully evaluate entities first seen in the last 7 days",
+			parameters: `{"max_age": "168h"}`,
+			evaluations: []struct {
+				entity         model.Entity
+				resultExpected bool
+			}{
+				{entity: seen(time.Hour, time.Hour), resultExpected: true},
+				{entity: seen(167*time.Hour, time.Hour), resultExpected: true},
+				{entity: seen(168*time.Hour, time.Hour), resultExpected: false},
+				{entity: model.Entity{EntityID: 1}, resultExpected: false},
+			},
+		},
+		{
+			name:       "successfully evaluate entities that are not seen recently",
+			parameters: `{"timestamp": "last_seen", "min_age": "720h"}`,
+			evaluations: []struct {
+				entity         model.Entity
+				resultExpected bool
+			}{
+				{entity: seen(1000*time.Hour, time.Hour), resultExpected: false},
+				{entity: seen(1000*time.Hour, 720*time.Hour), resultExpected: true},
+			},
+		},
+		{
+			name:       "successfully evaluate entities with age between min and max",
+			parameters: `{"min_age": "24h", "max_age": "48h"}`,
+			evaluations: []struct {
+				entity         model.Entity
+				resultExpected bool
+			}{
+				{entity: seen(12*time.Hour, time.Hour), resultExpected: false},
+				{entity: seen(36*time.Hour, time.Hour), resultExpected: true},
+				{entity: seen(72*time.Hour, time.Hour), resultExpected: false},
+			},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+
+		suite.Run(tc.name, func() {
+			c, err := constraint.New(constraint.EntityAgeConstraintName, json.RawMessage(tc.parameters))
+			suite.NoError(err)
+
+			ac, ok := c.(*constraint.EntityAgeConstraint)
+			suite.True(ok)
+
+			ac.Clock = func() time.Time { return now }
+
+			for _, ev := range tc.evaluations {
+				suite.Equal(ev.resultExpected, ac.Evaluate(ev.entity))
+			}
+		})
+	}
+}
+
+func (suite *EntityAgeConstraintSuite) TestEntityAgeExplain() {
+	c, err := constraint.New(constraint.EntityAgeConstraintName, json.RawMessage(`{"max_age": "168h"}`))
+	suite.NoError(err)
+
+	firstSeen := time.Date(2020, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	trace := constraint.Explain(c, model.Entity{EntityID: 1, FirstSeen: &firstSeen})
+	suite.Equal("2020-12-10T12:00:00Z", trace.Properties[constraint.FirstSeenProperty].String())
+
+	trace = constraint.Explain(c, model.Entity{EntityID: 1})
+	suite.Equal([]string{constraint.FirstSeenProperty}, trace.Missing)
+	suite.False(*trace.Result)
+}
+
+func (suite *EntityAgeConstraintSuite) TestEntityAgeConstraintValidation() {
+	cases := []ConstraintTestCase{}
+
+	for _, tc := range []struct {
+		name       string
+		parameters string
+	}{
+		{name: "failed to create constraint without min age and max age", parameters: `{}`},
+		{name: "failed to create constraint with invalid timestamp", parameters: `{"timestamp": "x", "max_age": "1h"}`},
+		{name: "failed to create constraint with invalid age", parameters: `{"max_age": "7 days"}`},
+		{name: "failed to create constraint with negative age", parameters: `{"min_age": "-1h"}`},
+		{name: "failed to create constraint with min age after max age", parameters: `{"min_age": "2h", "max_age": "1h"}`},
+	} {
+		cases = append(cases, ConstraintTestCase{
+			Name: tc.name,
+			Constraint: model.Constraint{
+				Name:       constraint.EntityAgeConstraintName,
+				Parameters: json.RawMessage(tc.parameters),
+			},
+			ErrExpected: true,
+		})
+	}
+
+	suite.RunCases(cases)
+}
+
+func TestEntityAgeConstraintSuite(t *testing.T) {
+	suite.Run(t, new(EntityAgeConstraintSuite))
+}
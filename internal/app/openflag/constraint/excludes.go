@@ -7,6 +7,7 @@ import (
 
 // ExcludesConstraint represents Openflag excludes constraint.
 type ExcludesConstraint struct {
+	path     Path
 	valueMap map[string]struct{}
 	Values   []string `json:"values"`
 	Property string   `json:"property,omitempty"`
@@ -30,6 +31,13 @@ func (ex ExcludesConstraint) Validate() error {
 
 // Initialize is an implementation for the Constraint interface.
 func (ex *ExcludesConstraint) Initialize() error {
+	path, err := CompilePath(ex.Property)
+	if err != nil {
+		return err
+	}
+
+	ex.path = path
+
 	valueMap := make(map[string]struct{})
 
 	for _, value := range ex.Values {
@@ -43,7 +51,7 @@ func (ex *ExcludesConstraint) Initialize() error {
 
 // Evaluate is an implementation for the Constraint interface.
 func (ex ExcludesConstraint) Evaluate(e model.Entity) bool {
-	property, ok := GetProperty(ex.Property, e)
+	property, ok := ex.path.String(e)
 	if !ok {
 		return false
 	}
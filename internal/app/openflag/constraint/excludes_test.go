@@ -90,7 +90,7 @@ func (suite *ExcludesConstraintSuite) TestExcludesConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "8"},
+						EntityContext: model.Context{"test": model.StringValue("8")},
 					},
 					ResultExpected: true,
 				},
@@ -98,7 +98,7 @@ func (suite *ExcludesConstraintSuite) TestExcludesConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "10"},
+						EntityContext: model.Context{"test": model.StringValue("10")},
 					},
 					ResultExpected: false,
 				},
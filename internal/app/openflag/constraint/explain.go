@@ -0,0 +1,176 @@
+package constraint
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+)
+
+// PropertyRecorder represents an evaluation scope that records the entity properties that constraints read.
+type PropertyRecorder interface {
+	// RecordProperty records the value of the given property or that the entity does not have it.
+	RecordProperty(property string, value model.Value, ok bool)
+}
+
+// Trace represents the evaluation of a node of a constraint tree. It keeps the values of the properties that
+// the node reads and the properties that the entity does not have. Result is nil for the nodes that are
+// skipped because the result of their parent is already known, e.g. the constraints after a failed one
+// in an intersection.
+type Trace struct {
+	Name       string                 `json:"name"`
+	Parameters json.RawMessage        `json:"parameters,omitempty"`
+	Result     *bool                  `json:"result,omitempty"`
+	Properties map[string]model.Value `json:"properties,omitempty"`
+	Missing    []string               `json:"missing,omitempty"`
+	Children   []Trace                `json:"children,omitempty"`
+}
+
+// traceRecorder records the property reads of a constraint into its trace. It passes the other calls
+// to the original scope, so lists, prerequisites, frequency caps and entity timestamps work the same way as in
+// Evaluate.
+type traceRecorder struct {
+	scope interface{}
+	trace *Trace
+}
+
+// Explain evaluates the given constraint tree for the entity and traces the result of each node. The nested
+// constraints are evaluated in the same order and short-circuited in the same way as in Evaluate.
+func Explain(c Constraint, e model.Entity) Trace {
+	trace := Trace{Name: c.Name()}
+
+	var result bool
+
+	switch x := c.(type) {
+	case *IntersectionConstraint:
+		result = true
+
+		for _, child := range x.constraints {
+			if !result {
+				trace.Children = append(trace.Children, skip(child))
+				continue
+			}
+
+			childTrace := Explain(child, e)
+			result = *childTrace.Result
+			trace.Children = append(trace.Children, childTrace)
+		}
+	case *UnionConstraint:
+		for _, child := range x.constraints {
+			if result {
+				trace.Children = append(trace.Children, skip(child))
+				continue
+			}
+
+			childTrace := Explain(child, e)
+			result = *childTrace.Result
+			trace.Children = append(trace.Children, childTrace)
+		}
+	case *NotConstraint:
+		childTrace := Explain(x.constraint, e)
+		result = !*childTrace.Result
+		trace.Children = append(trace.Children, childTrace)
+	default:
+		trace.Parameters = parameters(c)
+		result = c.Evaluate(e.WithScope(traceRecorder{scope: e.Scope(), trace: &trace}))
+	}
+
+	trace.Result = &result
+
+	return trace
+}
+
+// recordProperty reports a property read to the PropertyRecorder of the entity scope if any.
+func recordProperty(e model.Entity, property string, value model.Value, ok bool) {
+	if recorder, isRecorder := e.Scope().(PropertyRecorder); isRecorder {
+		recorder.RecordProperty(property, value, ok)
+	}
+}
+
+// skip returns the trace of a constraint tree that is not evaluated.
+func skip(c Constraint) Trace {
+	trace := Trace{Name: c.Name()}
+
+	if pc, ok := c.(ParentConstraint); ok {
+		for _, child := range pc.Children() {
+			trace.Children = append(trace.Children, skip(child))
+		}
+	} else {
+		trace.Parameters = parameters(c)
+	}
+
+	return trace
+}
+
+func parameters(c Constraint) json.RawMessage {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// RecordProperty is an implementation for the PropertyRecorder interface.
+func (t traceRecorder) RecordProperty(property string, value model.Value, ok bool) {
+	if !ok {
+		for _, missing := range t.trace.Missing {
+			if missing == property {
+				return
+			}
+		}
+
+		t.trace.Missing = append(t.trace.Missing, property)
+
+		return
+	}
+
+	if t.trace.Properties == nil {
+		t.trace.Properties = map[string]model.Value{}
+	}
+
+	t.trace.Properties[property] = value
+}
+
+// IsMember is an implementation for the ListChecker interface.
+func (t traceRecorder) IsMember(list string, value string) bool {
+	checker, ok := t.scope.(ListChecker)
+	if !ok {
+		return false
+	}
+
+	return checker.IsMember(list, value)
+}
+
+// EvaluateFlag is an implementation for the FlagEvaluator interface. The prerequisite flag is evaluated
+// with the original scope, so its property reads are not recorded into the trace of the prerequisite.
+func (t traceRecorder) EvaluateFlag(flag string, e model.Entity) (model.Variant, bool) {
+	evaluator, ok := t.scope.(FlagEvaluator)
+	if !ok {
+		return model.Variant{}, false
+	}
+
+	return evaluator.EvaluateFlag(flag, e.WithScope(t.scope))
+}
+
+// Seen is an implementation for the EntitySeenChecker interface.
+func (t traceRecorder) Seen(e model.Entity) (*time.Time, *time.Time) {
+	checker, ok := t.scope.(EntitySeenChecker)
+	if !ok {
+		return nil, nil
+	}
+
+	return checker.Seen(e)
+}
+
+// ExposureCount is an implementation for the FrequencyCapChecker interface. Without a checker in the original
+// scope, the counter is reported as exhausted, so frequency caps do not match in the same way as in Evaluate.
+func (t traceRecorder) ExposureCount(counter string) int64 {
+	checker, ok := t.scope.(FrequencyCapChecker)
+	if !ok {
+		return math.MaxInt64
+	}
+
+	return checker.ExposureCount(counter)
+}
@@ -0,0 +1,115 @@
+package constraint_test
+
+import (
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	c, err := constraint.CompileRule(
+		`(country == "DE" and age >= 18) or (not os == "ios" and entity_id in list "beta.users")`,
+	)
+	assert.NoError(t, err)
+
+	co, err := constraint.New(c.Name, c.Parameters)
+	assert.NoError(t, err)
+
+	e := model.Entity{
+		EntityID: 7,
+		EntityContext: model.Context{
+			"country": model.StringValue("DE"),
+			"os":      model.StringValue("android"),
+		},
+	}
+
+	e = e.WithScope(fakeListChecker{"beta.users": {"7"}})
+
+	trace := constraint.Explain(co, e)
+
+	assert.Equal(t, co.Evaluate(e), *trace.Result)
+	assert.True(t, *trace.Result)
+	assert.Equal(t, constraint.UnionConstraintName, trace.Name)
+	assert.Len(t, trace.Children, 2)
+
+	var leaves []constraint.Trace
+
+	var walk func(t constraint.Trace)
+
+	walk = func(t constraint.Trace) {
+		if len(t.Children) == 0 {
+			leaves = append(leaves, t)
+		}
+
+		for _, child := range t.Children {
+			walk(child)
+		}
+	}
+
+	walk(trace)
+
+	results := map[string]*bool{}
+	properties := map[string]model.Value{}
+
+	var missing []string
+
+	for _, leaf := range leaves {
+		assert.NotEmpty(t, leaf.Parameters)
+
+		results[leaf.Name] = leaf.Result
+		missing = append(missing, leaf.Missing...)
+
+		for property, value := range leaf.Properties {
+			properties[property] = value
+		}
+	}
+
+	assert.Len(t, leaves, 4)
+	assert.Equal(t, []string{"age"}, missing)
+	assert.Equal(t, "DE", properties["country"].String())
+	assert.Equal(t, "android", properties["os"].String())
+	assert.False(t, *results[constraint.BiggerThanEqualConstraintName])
+	assert.True(t, *results[constraint.ListConstraintName])
+}
+
+func TestExplainShortCircuit(t *testing.T) {
+	c, err := constraint.CompileRule(`country == "IR" and (age >= 18 or beta == "true")`)
+	assert.NoError(t, err)
+
+	co, err := constraint.New(c.Name, c.Parameters)
+	assert.NoError(t, err)
+
+	trace := constraint.Explain(co, model.Entity{EntityID: 7, EntityContext: model.Context{
+		"country": model.StringValue("DE"),
+		"age":     model.NumberValue(20),
+	}})
+
+	assert.False(t, *trace.Result)
+
+	var evaluated, skipped int
+
+	for _, child := range trace.Children {
+		if child.Result == nil {
+			skipped++
+
+			assert.Equal(t, constraint.UnionConstraintName, child.Name)
+			assert.Len(t, child.Children, 2)
+
+			for _, grandchild := range child.Children {
+				assert.Nil(t, grandchild.Result)
+				assert.Empty(t, grandchild.Properties)
+			}
+
+			continue
+		}
+
+		evaluated++
+
+		assert.Equal(t, "DE", child.Properties["country"].String())
+	}
+
+	assert.Equal(t, 1, evaluated)
+	assert.Equal(t, 1, skipped)
+}
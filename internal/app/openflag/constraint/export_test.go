@@ -0,0 +1,8 @@
+package constraint
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// MissingPropertyCounter exposes the missing property counter of the given constraint and property to the tests.
+func MissingPropertyCounter(constraint string, property string) prometheus.Counter {
+	return metrics.MissingPropertyCounter.With(prometheus.Labels{labelConstraint: constraint, labelProperty: property})
+}
@@ -0,0 +1,134 @@
+package constraint
+
+import (
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	minFrequencyCapLimit = 1
+)
+
+// FrequencyCapChecker represents an interface for reading the exposure counters of the frequency caps.
+// The evaluation engine puts a FrequencyCapChecker into the entity scope for frequency cap constraints.
+type FrequencyCapChecker interface {
+	// ExposureCount returns the number of the exposures of the given counter in its current window.
+	ExposureCount(counter string) int64
+}
+
+// FrequencyCapConstraint represents Openflag frequency cap constraint.
+// It matches while the entity is exposed less than the limit in the current window (e.g. "24h"), which
+// starts with the first exposure. The counters are kept per key (the flag key by default) and the given
+// property (the entity type and id by default), so flags with the same key share their counters. The evaluation
+// engine counts an exposure only when the segment of the constraint wins and does it atomically, so
+// concurrent evaluations can not exceed the limit. In the check only mode, the constraint only checks
+// the counter and never counts an exposure, e.g. for a flag that is shown next to the capped one.
+type FrequencyCapConstraint struct {
+	path      Path
+	flag      string
+	window    time.Duration
+	Limit     int64  `json:"limit"`
+	Window    string `json:"window"`
+	Key       string `json:"key,omitempty"`
+	Property  string `json:"property,omitempty"`
+	CheckOnly bool   `json:"check_only,omitempty"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (f FrequencyCapConstraint) Name() string {
+	return FrequencyCapConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (f FrequencyCapConstraint) Validate() error {
+	return validation.ValidateStruct(&f,
+		validation.Field(
+			&f.Limit,
+			validation.Required,
+			validation.Min(int64(minFrequencyCapLimit)),
+		),
+		validation.Field(
+			&f.Window,
+			validation.Required,
+		),
+		validation.Field(
+			&f.window,
+			validation.Min(time.Minute),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (f *FrequencyCapConstraint) Initialize() error {
+	path, err := CompilePath(f.Property)
+	if err != nil {
+		return err
+	}
+
+	f.path = path
+
+	if f.Window != "" {
+		if f.window, err = time.ParseDuration(f.Window); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetFlag is an implementation for the FlagConstraint interface.
+func (f *FrequencyCapConstraint) SetFlag(flag string) {
+	f.flag = flag
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (f FrequencyCapConstraint) Evaluate(e model.Entity) bool {
+	checker, ok := e.Scope().(FrequencyCapChecker)
+	if !ok {
+		return false
+	}
+
+	c, ok := f.Cap(e)
+	if !ok {
+		metrics.reportMissingProperty(FrequencyCapConstraintName, f.Property)
+		return false
+	}
+
+	return checker.ExposureCount(c.Counter) < f.Limit
+}
+
+// Cap returns the exposure counter of the constraint for the given entity. The counters of the entity ids
+// are kept per entity type, so the entities of different types with the same id do not share a counter.
+func (f FrequencyCapConstraint) Cap(e model.Entity) (model.FrequencyCap, bool) {
+	property, ok := f.path.String(e)
+	if !ok {
+		return model.FrequencyCap{}, false
+	}
+
+	if f.Property == "" {
+		property = e.EntityType + ":" + property
+	}
+
+	key := f.Key
+	if key == "" {
+		key = f.flag
+	}
+
+	return model.FrequencyCap{Counter: key + ":" + property, Limit: f.Limit, Window: f.window}, true
+}
+
+// FrequencyCapConstraints returns all frequency cap constraints of the given constraint tree.
+func FrequencyCapConstraints(c Constraint) []FrequencyCapConstraint {
+	var caps []FrequencyCapConstraint
+
+	Walk(c, func(c Constraint) {
+		if f, ok := c.(*FrequencyCapConstraint); ok {
+			caps = append(caps, *f)
+		}
+	})
+
+	return caps
+}
@@ -0,0 +1,128 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeFrequencyCapChecker map[string]int64
+
+func (f fakeFrequencyCapChecker) ExposureCount(counter string) int64 {
+	return f[counter]
+}
+
+type FrequencyCapConstraintSuite struct {
+	ConstraintSuite
+}
+
+func (suite *FrequencyCapConstraintSuite) TestFrequencyCapConstraint() {
+	checker := fakeFrequencyCapChecker{"promo:user:1": 2, "promo:user:2": 3, "promo:device:1": 3, "promo:IR": 3}
+
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create constraint and evaluate 1",
+			Constraint: model.Constraint{
+				Name:       constraint.FrequencyCapConstraintName,
+				Parameters: json.RawMessage(`{"limit": 3, "window": "24h", "key": "promo"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: model.Entity{EntityID: 1, EntityType: "user"}.WithScope(checker), ResultExpected: true},
+				{Entity: model.Entity{EntityID: 2, EntityType: "user"}.WithScope(checker), ResultExpected: false},
+				{Entity: model.Entity{EntityID: 3, EntityType: "user"}.WithScope(checker), ResultExpected: true},
+				{Entity: model.Entity{EntityID: 1, EntityType: "device"}.WithScope(checker), ResultExpected: false},
+				{Entity: model.Entity{EntityID: 2, EntityType: "device"}.WithScope(checker), ResultExpected: true},
+				{Entity: model.Entity{EntityID: 1, EntityType: "user"}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate 2",
+			Constraint: model.Constraint{
+				Name: constraint.FrequencyCapConstraintName,
+				Parameters: json.RawMessage(
+					`{"limit": 3, "window": "1h", "key": "promo", "property": "country", "check_only": true}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity:         model.Entity{EntityContext: model.Context{"country": model.StringValue("IR")}}.WithScope(checker),
+					ResultExpected: false,
+				},
+				{
+					Entity:         model.Entity{EntityContext: model.Context{"country": model.StringValue("DE")}}.WithScope(checker),
+					ResultExpected: true,
+				},
+				{Entity: model.Entity{EntityID: 1}.WithScope(checker), ResultExpected: false},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		parameters string
+	}{
+		{name: "failed to create constraint without limit", parameters: `{"window": "24h"}`},
+		{name: "failed to create constraint with negative limit", parameters: `{"limit": -1, "window": "24h"}`},
+		{name: "failed to create constraint without window", parameters: `{"limit": 1}`},
+		{name: "failed to create constraint with invalid window", parameters: `{"limit": 1, "window": "1 day"}`},
+		{name: "failed to create constraint with short window", parameters: `{"limit": 1, "window": "1s"}`},
+	} {
+		cases = append(cases, ConstraintTestCase{
+			Name: tc.name,
+			Constraint: model.Constraint{
+				Name:       constraint.FrequencyCapConstraintName,
+				Parameters: json.RawMessage(tc.parameters),
+			},
+			ErrExpected: true,
+		})
+	}
+
+	suite.RunCases(cases)
+}
+
+func (suite *FrequencyCapConstraintSuite) TestFrequencyCapConstraints() {
+	c, err := constraint.New(constraint.UnionConstraintName, json.RawMessage(fmt.Sprintf(
+		`{"constraints": [
+			{"name": "%s", "parameters": {"limit": 3, "window": "24h"}},
+			{"name": "%s", "parameters": {"limit": 1, "window": "1h", "key": "promo", "check_only": true}}
+		]}`,
+		constraint.FrequencyCapConstraintName, constraint.FrequencyCapConstraintName,
+	)))
+	suite.NoError(err)
+
+	constraint.SetFlag(c, "banner")
+
+	caps := constraint.FrequencyCapConstraints(c)
+	suite.Len(caps, 2)
+	suite.False(caps[0].CheckOnly)
+	suite.True(caps[1].CheckOnly)
+
+	fc, ok := caps[0].Cap(model.Entity{EntityID: 7, EntityType: "user"})
+	suite.True(ok)
+	suite.Equal(model.FrequencyCap{Counter: "banner:user:7", Limit: 3, Window: 24 * time.Hour}, fc)
+
+	fc, ok = caps[1].Cap(model.Entity{EntityID: 7, EntityType: "user"})
+	suite.True(ok)
+	suite.Equal(model.FrequencyCap{Counter: "promo:user:7", Limit: 1, Window: time.Hour}, fc)
+
+	fc, ok = caps[1].Cap(model.Entity{EntityID: 7, EntityType: "device"})
+	suite.True(ok)
+	suite.Equal("promo:device:7", fc.Counter, "entities of different types should not share a counter")
+}
+
+func TestFrequencyCapConstraintSuite(t *testing.T) {
+	suite.Run(t, new(FrequencyCapConstraintSuite))
+}
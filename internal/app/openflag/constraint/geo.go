@@ -0,0 +1,268 @@
+package constraint
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	defaultLatProperty = "lat"
+	defaultLngProperty = "lng"
+
+	geoJSONPolygon      = "Polygon"
+	geoJSONMultiPolygon = "MultiPolygon"
+
+	earthRadiusKm  = 6371.0
+	minLat         = -90
+	maxLat         = 90
+	minLng         = -180
+	maxLng         = 180
+	minRingLen     = 4
+	positionLen    = 2
+	halfCircleDegs = 180
+)
+
+type (
+	// GeoPoint represents a point on the earth using its latitude and longitude in degrees.
+	GeoPoint struct {
+		Lat float64 `json:"lat"`
+		Lng float64 `json:"lng"`
+	}
+
+	// GeoJSONPolygon represents a GeoJSON Polygon or MultiPolygon geometry.
+	// Positions are [longitude, latitude] pairs and the first ring of each polygon is its exterior ring,
+	// the other rings are holes.
+	GeoJSONPolygon struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+
+	// ring represents a closed ring of [longitude, latitude] positions.
+	ring [][]float64
+
+	// polygon represents a polygon with its exterior ring and its holes.
+	polygon []ring
+)
+
+// GeoConstraint represents Openflag geo constraint.
+// It reads latitude and longitude of an entity from its context and checks whether the point is within
+// the given radius (in km) of the center, or inside the given GeoJSON polygon.
+type GeoConstraint struct {
+	polygons    []polygon
+	latPath     Path
+	lngPath     Path
+	LatProperty string          `json:"lat_property,omitempty"`
+	LngProperty string          `json:"lng_property,omitempty"`
+	Center      *GeoPoint       `json:"center,omitempty"`
+	RadiusKm    float64         `json:"radius_km,omitempty"`
+	Polygon     *GeoJSONPolygon `json:"polygon,omitempty"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (g GeoConstraint) Name() string {
+	return GeoConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (g GeoConstraint) Validate() error {
+	if (g.Center == nil) == (g.Polygon == nil) {
+		return errors.New("exactly one of center and polygon should be set")
+	}
+
+	if g.Center != nil {
+		if err := g.Center.Validate(); err != nil {
+			return err
+		}
+
+		return validation.ValidateStruct(&g,
+			validation.Field(
+				&g.RadiusKm,
+				validation.Required,
+				validation.Min(float64(0)).Exclusive(),
+			),
+		)
+	}
+
+	for _, p := range g.polygons {
+		for _, r := range p {
+			if err := r.validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate validates GeoPoint struct.
+func (p GeoPoint) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(
+			&p.Lat,
+			validation.Min(float64(minLat)),
+			validation.Max(float64(maxLat)),
+		),
+		validation.Field(
+			&p.Lng,
+			validation.Min(float64(minLng)),
+			validation.Max(float64(maxLng)),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (g *GeoConstraint) Initialize() error {
+	if g.LatProperty == "" {
+		g.LatProperty = defaultLatProperty
+	}
+
+	if g.LngProperty == "" {
+		g.LngProperty = defaultLngProperty
+	}
+
+	var err error
+
+	if g.latPath, err = CompilePath(g.LatProperty); err != nil {
+		return err
+	}
+
+	if g.lngPath, err = CompilePath(g.LngProperty); err != nil {
+		return err
+	}
+
+	if g.Polygon == nil {
+		return nil
+	}
+
+	switch g.Polygon.Type {
+	case geoJSONPolygon:
+		var p polygon
+
+		if err := json.Unmarshal(g.Polygon.Coordinates, &p); err != nil {
+			return err
+		}
+
+		g.polygons = []polygon{p}
+	case geoJSONMultiPolygon:
+		if err := json.Unmarshal(g.Polygon.Coordinates, &g.polygons); err != nil {
+			return err
+		}
+	default:
+		return errors.New("invalid GeoJSON polygon type")
+	}
+
+	for _, p := range g.polygons {
+		if len(p) == 0 {
+			return errors.New("GeoJSON polygon should have an exterior ring")
+		}
+	}
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (g GeoConstraint) Evaluate(e model.Entity) bool {
+	point, ok := g.point(e)
+	if !ok {
+		return false
+	}
+
+	if g.Center != nil {
+		return distanceKm(*g.Center, point) <= g.RadiusKm
+	}
+
+	for _, p := range g.polygons {
+		if p.contains(point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g GeoConstraint) point(e model.Entity) (GeoPoint, bool) {
+	lat, ok := g.latPath.Number(e)
+	if !ok {
+		return GeoPoint{}, false
+	}
+
+	lng, ok := g.lngPath.Number(e)
+	if !ok {
+		return GeoPoint{}, false
+	}
+
+	point := GeoPoint{Lat: lat, Lng: lng}
+
+	return point, point.Validate() == nil
+}
+
+// distanceKm returns the great-circle distance of two points using the haversine formula.
+func distanceKm(a, b GeoPoint) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / halfCircleDegs }
+
+	dLat := toRadians(b.Lat - a.Lat)
+	dLng := toRadians(b.Lng - a.Lng)
+
+	h := math.Pow(math.Sin(dLat/2), 2) + // nolint:gomnd
+		math.Cos(toRadians(a.Lat))*math.Cos(toRadians(b.Lat))*math.Pow(math.Sin(dLng/2), 2) // nolint:gomnd
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h)) // nolint:gomnd
+}
+
+func (r ring) validate() error {
+	if len(r) < minRingLen {
+		return errors.New("GeoJSON polygon rings should have at least 4 positions")
+	}
+
+	for _, position := range r {
+		if len(position) < positionLen {
+			return errors.New("invalid GeoJSON position")
+		}
+
+		if err := (GeoPoint{Lat: position[1], Lng: position[0]}).Validate(); err != nil {
+			return err
+		}
+	}
+
+	first, last := r[0], r[len(r)-1]
+	if first[0] != last[0] || first[1] != last[1] {
+		return errors.New("GeoJSON polygon rings should be closed")
+	}
+
+	return nil
+}
+
+// contains checks whether the point is inside the ring using the ray casting algorithm.
+func (r ring) contains(p GeoPoint) bool {
+	inside := false
+
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		xi, yi := r[i][0], r[i][1]
+		xj, yj := r[j][0], r[j][1]
+
+		if (yi > p.Lat) != (yj > p.Lat) && p.Lng < (xj-xi)*(p.Lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+func (p polygon) contains(point GeoPoint) bool {
+	if !p[0].contains(point) {
+		return false
+	}
+
+	for _, hole := range p[1:] {
+		if hole.contains(point) {
+			return false
+		}
+	}
+
+	return true
+}
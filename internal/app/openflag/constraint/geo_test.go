@@ -0,0 +1,175 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type GeoConstraintSuite struct {
+	ConstraintSuite
+}
+
+func locationEntity(lat, lng string) model.Entity {
+	return model.Entity{
+		EntityID:      1,
+		EntityContext: model.Context{"lat": model.StringValue(lat), "lng": model.StringValue(lng)},
+	}
+}
+
+func (suite *GeoConstraintSuite) TestGeoConstraint() {
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create constraint and evaluate center and radius",
+			Constraint: model.Constraint{
+				Name:       constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"center": {"lat": 35.6892, "lng": 51.3890}, "radius_km": 30}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: locationEntity("35.6892", "51.3890"), ResultExpected: true},
+				{Entity: locationEntity("35.8", "51.45"), ResultExpected: true},
+				{Entity: locationEntity("36.2648", "59.6168"), ResultExpected: false},
+				{Entity: locationEntity("invalid", "51.3890"), ResultExpected: false},
+				{Entity: locationEntity("95", "51.3890"), ResultExpected: false},
+				{Entity: model.Entity{EntityID: 1}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate custom properties",
+			Constraint: model.Constraint{
+				Name: constraint.GeoConstraintName,
+				Parameters: json.RawMessage(
+					`{"center": {"lat": 0, "lng": 0}, "radius_km": 200, "lat_property": "y", "lng_property": "x"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity: model.Entity{EntityID: 1, EntityContext: model.Context{
+						"y": model.StringValue("1"),
+						"x": model.StringValue("1"),
+					}},
+					ResultExpected: true,
+				},
+				{
+					Entity: model.Entity{EntityID: 1, EntityContext: model.Context{
+						"y": model.StringValue("2"),
+						"x": model.StringValue("1"),
+					}},
+					ResultExpected: false,
+				},
+				{Entity: locationEntity("0", "0"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate polygon with hole",
+			Constraint: model.Constraint{
+				Name: constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"polygon": {"type": "Polygon", "coordinates": [
+					[[50, 30], [60, 30], [60, 40], [50, 40], [50, 30]],
+					[[54, 34], [56, 34], [56, 36], [54, 36], [54, 34]]
+				]}}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: locationEntity("32", "52"), ResultExpected: true},
+				{Entity: locationEntity("35", "55"), ResultExpected: false},
+				{Entity: locationEntity("45", "55"), ResultExpected: false},
+				{Entity: locationEntity("35", "61"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate multi polygon",
+			Constraint: model.Constraint{
+				Name: constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"polygon": {"type": "MultiPolygon", "coordinates": [
+					[[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]],
+					[[[10, 10], [11, 10], [10.5, 11], [10, 10]]]
+				]}}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: locationEntity("0.5", "0.5"), ResultExpected: true},
+				{Entity: locationEntity("10.2", "10.5"), ResultExpected: true},
+				{Entity: locationEntity("10.9", "10.1"), ResultExpected: false},
+				{Entity: locationEntity("5", "5"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "failed to create constraint without any shape",
+			Constraint: model.Constraint{
+				Name:       constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"radius_km": 30}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with both center and polygon",
+			Constraint: model.Constraint{
+				Name: constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"center": {"lat": 0, "lng": 0}, "radius_km": 30, "polygon": {
+					"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]
+				}}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint without radius",
+			Constraint: model.Constraint{
+				Name:       constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"center": {"lat": 0, "lng": 0}}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with invalid center",
+			Constraint: model.Constraint{
+				Name:       constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"center": {"lat": 100, "lng": 0}, "radius_km": 30}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with open ring",
+			Constraint: model.Constraint{
+				Name: constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"polygon": {
+					"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]
+				}}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with invalid geometry type",
+			Constraint: model.Constraint{
+				Name: constraint.GeoConstraintName,
+				Parameters: json.RawMessage(`{"polygon": {
+					"type": "Point", "coordinates": [0, 0]
+				}}`),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func TestGeoConstraintSuite(t *testing.T) {
+	suite.Run(t, new(GeoConstraintSuite))
+}
@@ -0,0 +1,36 @@
+package constraint
+
+import (
+	"crypto/sha1" // nolint:gosec
+	"encoding/binary"
+	"strings"
+)
+
+const (
+	// BucketSize represents the number of buckets that hash based constraints use.
+	// It lets us define percentages with basis point (0.01%) precision.
+	BucketSize = 10000
+
+	hashKeySeparator = ":"
+)
+
+// Bucket returns a stable bucket in [0, BucketSize) for the given key parts.
+func Bucket(parts ...string) int64 {
+	sum := sha1.Sum([]byte(strings.Join(parts, hashKeySeparator))) // nolint:gosec
+
+	return int64(binary.BigEndian.Uint64(sum[:8]) % BucketSize)
+}
+
+// FlagConstraint represents a constraint that depends on the flag it belongs to.
+// For example, hash based constraints use the flag key to make their buckets independent across flags.
+type FlagConstraint interface {
+	// SetFlag sets the flag key that the constraint belongs to.
+	SetFlag(flag string)
+}
+
+// SetFlag sets the given flag key on the constraint and all of its nested constraints.
+func SetFlag(c Constraint, flag string) {
+	if fc, ok := c.(FlagConstraint); ok {
+		fc.SetFlag(flag)
+	}
+}
@@ -45,6 +45,18 @@ func (i *IntersectionConstraint) Initialize() error {
 	return nil
 }
 
+// Children is an implementation for the ParentConstraint interface.
+func (i *IntersectionConstraint) Children() []Constraint {
+	return i.constraints
+}
+
+// SetFlag is an implementation for the FlagConstraint interface.
+func (i *IntersectionConstraint) SetFlag(flag string) {
+	for _, c := range i.constraints {
+		SetFlag(c, flag)
+	}
+}
+
 // Evaluate is an implementation for the Constraint interface.
 func (i IntersectionConstraint) Evaluate(e model.Entity) bool {
 	for _, c := range i.constraints {
@@ -1,15 +1,12 @@
 package constraint
 
 import (
-	"strconv"
-
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
-
-	"github.com/sirupsen/logrus"
 )
 
 // LessThanConstraint represents Openflag less than constraint.
 type LessThanConstraint struct {
+	path     Path
 	Value    float64 `json:"value"`
 	Property string  `json:"property,omitempty"`
 }
@@ -26,25 +23,22 @@ func (l LessThanConstraint) Validate() error {
 
 // Initialize is an implementation for the Constraint interface.
 func (l *LessThanConstraint) Initialize() error {
+	path, err := CompilePath(l.Property)
+	if err != nil {
+		return err
+	}
+
+	l.path = path
+
 	return nil
 }
 
 // Evaluate is an implementation for the Constraint interface.
 func (l LessThanConstraint) Evaluate(e model.Entity) bool {
-	property, ok := GetProperty(l.Property, e)
+	value, ok := l.path.Number(e)
 	if !ok {
 		return false
 	}
 
-	value, err := strconv.ParseFloat(property, 64)
-	if err != nil {
-		logrus.Errorf(
-			"invalid property for less than constraint => property: %s, value: %s, err: %s",
-			l.Property, property, err.Error(),
-		)
-
-		return false
-	}
-
 	return value < l.Value
 }
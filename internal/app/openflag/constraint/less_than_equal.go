@@ -1,15 +1,12 @@
 package constraint
 
 import (
-	"strconv"
-
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
-
-	"github.com/sirupsen/logrus"
 )
 
 // LessThanEqualConstraint represents Openflag less than equal constraint.
 type LessThanEqualConstraint struct {
+	path     Path
 	Value    float64 `json:"value"`
 	Property string  `json:"property,omitempty"`
 }
@@ -26,25 +23,22 @@ func (l LessThanEqualConstraint) Validate() error {
 
 // Initialize is an implementation for the Constraint interface.
 func (l *LessThanEqualConstraint) Initialize() error {
+	path, err := CompilePath(l.Property)
+	if err != nil {
+		return err
+	}
+
+	l.path = path
+
 	return nil
 }
 
 // Evaluate is an implementation for the Constraint interface.
 func (l LessThanEqualConstraint) Evaluate(e model.Entity) bool {
-	property, ok := GetProperty(l.Property, e)
+	value, ok := l.path.Number(e)
 	if !ok {
 		return false
 	}
 
-	value, err := strconv.ParseFloat(property, 64)
-	if err != nil {
-		logrus.Errorf(
-			"invalid property for less than equal constraint => property: %s, value: %s, err: %s",
-			l.Property, property, err.Error(),
-		)
-
-		return false
-	}
-
 	return value <= l.Value
 }
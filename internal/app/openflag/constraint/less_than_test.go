@@ -104,7 +104,7 @@ func (suite *LessThanConstraintSuite) TestLessThanConstraint() {
 					Entity: model.Entity{
 						EntityID:      11,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "9"},
+						EntityContext: model.Context{"test": model.StringValue("9")},
 					},
 					ResultExpected: true,
 				},
@@ -112,7 +112,7 @@ func (suite *LessThanConstraintSuite) TestLessThanConstraint() {
 					Entity: model.Entity{
 						EntityID:      11,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "10"},
+						EntityContext: model.Context{"test": model.StringValue("10")},
 					},
 					ResultExpected: false,
 				},
@@ -120,7 +120,7 @@ func (suite *LessThanConstraintSuite) TestLessThanConstraint() {
 					Entity: model.Entity{
 						EntityID:      11,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "11"},
+						EntityContext: model.Context{"test": model.StringValue("11")},
 					},
 					ResultExpected: false,
 				},
@@ -143,7 +143,7 @@ func (suite *LessThanConstraintSuite) TestLessThanConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "-1"},
+						EntityContext: model.Context{"test": model.StringValue("-1")},
 					},
 					ResultExpected: true,
 				},
@@ -166,7 +166,7 @@ func (suite *LessThanConstraintSuite) TestLessThanConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "t"},
+						EntityContext: model.Context{"test": model.StringValue("t")},
 					},
 					ResultExpected: false,
 				},
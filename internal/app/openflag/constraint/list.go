@@ -0,0 +1,104 @@
+package constraint
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// ListChecker represents an interface for checking the membership of values in uploaded lists.
+// The evaluation engine puts a ListChecker into the entity scope for list constraints.
+type ListChecker interface {
+	// IsMember returns true if the given value is a member of the list.
+	IsMember(list string, value string) bool
+}
+
+// ListConstraint represents Openflag list constraint.
+// It matches when the property of the entity is a member of an uploaded list. Lists are kept outside of
+// the flag, so they can contain a large number of IDs.
+type ListConstraint struct {
+	path     Path
+	List     string `json:"list"`
+	Property string `json:"property,omitempty"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (l ListConstraint) Name() string {
+	return ListConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (l ListConstraint) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(
+			&l.List,
+			validation.Required,
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (l *ListConstraint) Initialize() error {
+	path, err := CompilePath(l.Property)
+	if err != nil {
+		return err
+	}
+
+	l.path = path
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (l ListConstraint) Evaluate(e model.Entity) bool {
+	checker, ok := e.Scope().(ListChecker)
+	if !ok {
+		return false
+	}
+
+	property, ok := l.path.String(e)
+	if !ok {
+		return false
+	}
+
+	return checker.IsMember(l.List, property)
+}
+
+// Lookup returns the membership lookup of the constraint for the given entity.
+func (l ListConstraint) Lookup(e model.Entity) (model.ListLookup, bool) {
+	property, ok := l.path.String(e)
+	if !ok {
+		return model.ListLookup{}, false
+	}
+
+	return model.ListLookup{List: l.List, Value: property}, true
+}
+
+// ListConstraints returns all list constraints of the given constraint tree.
+func ListConstraints(c Constraint) []ListConstraint {
+	var lists []ListConstraint
+
+	Walk(c, func(c Constraint) {
+		if l, ok := c.(*ListConstraint); ok {
+			lists = append(lists, *l)
+		}
+	})
+
+	return lists
+}
+
+// ReferencedLists returns the names of the lists that the given constraint tree uses.
+func ReferencedLists(c model.Constraint) []string {
+	constraint, err := New(c.Name, c.Parameters)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, l := range ListConstraints(constraint) {
+		names = append(names, l.List)
+	}
+
+	return names
+}
@@ -0,0 +1,120 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeListChecker map[string][]string
+
+func (f fakeListChecker) IsMember(list string, value string) bool {
+	for _, item := range f[list] {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ListConstraintSuite struct {
+	ConstraintSuite
+}
+
+func (suite *ListConstraintSuite) TestListConstraint() {
+	checker := fakeListChecker{"beta.users": {"1", "2"}, "countries": {"IR"}}
+
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create constraint and evaluate 1",
+			Constraint: model.Constraint{
+				Name:       constraint.ListConstraintName,
+				Parameters: json.RawMessage(`{"list": "beta.users"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: model.Entity{EntityID: 1}.WithScope(checker), ResultExpected: true},
+				{Entity: model.Entity{EntityID: 3}.WithScope(checker), ResultExpected: false},
+				{Entity: model.Entity{EntityID: 1}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate 2",
+			Constraint: model.Constraint{
+				Name:       constraint.ListConstraintName,
+				Parameters: json.RawMessage(`{"list": "countries", "property": "country"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity:         model.Entity{EntityContext: model.Context{"country": model.StringValue("IR")}}.WithScope(checker),
+					ResultExpected: true,
+				},
+				{
+					Entity:         model.Entity{EntityContext: model.Context{"country": model.StringValue("DE")}}.WithScope(checker),
+					ResultExpected: false,
+				},
+				{Entity: model.Entity{EntityID: 1}.WithScope(checker), ResultExpected: false},
+			},
+		},
+		{
+			Name: "failed to create constraint without list",
+			Constraint: model.Constraint{
+				Name:       constraint.ListConstraintName,
+				Parameters: json.RawMessage(`{"property": "country"}`),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func (suite *ListConstraintSuite) TestListConstraints() {
+	c, err := constraint.New(constraint.IntersectionConstraintName, json.RawMessage(fmt.Sprintf(
+		`{"constraints": [
+			{"name": "%s", "parameters": {"list": "beta.users"}},
+			{"name": "%s", "parameters": {"constraint": {"name": "%s", "parameters": {"list": "blocked"}}}}
+		]}`,
+		constraint.ListConstraintName, constraint.NotConstraintName, constraint.ListConstraintName,
+	)))
+	suite.NoError(err)
+
+	lists := constraint.ListConstraints(c)
+	suite.Len(lists, 2)
+	suite.Equal("beta.users", lists[0].List)
+	suite.Equal("blocked", lists[1].List)
+
+	lookup, ok := lists[0].Lookup(model.Entity{EntityID: 7})
+	suite.True(ok)
+	suite.Equal(model.ListLookup{List: "beta.users", Value: "7"}, lookup)
+
+	names := constraint.ReferencedLists(model.Constraint{
+		Name:       constraint.ListConstraintName,
+		Parameters: json.RawMessage(`{"list": "beta.users"}`),
+	})
+	suite.Equal([]string{"beta.users"}, names)
+
+	names = constraint.ReferencedLists(model.Constraint{
+		Name: constraint.NotConstraintName,
+		Parameters: json.RawMessage(
+			`{"constraint": {"name": "list", "parameters": {"list": "beta.users", "property": "user_id"}}}`,
+		),
+	})
+	suite.Equal([]string{"beta.users"}, names)
+}
+
+func TestListConstraintSuite(t *testing.T) {
+	suite.Run(t, new(ListConstraintSuite))
+}
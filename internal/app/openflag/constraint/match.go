@@ -10,6 +10,7 @@ import (
 
 // MatchConstraint represents Openflag match constraint.
 type MatchConstraint struct {
+	path      Path
 	regex     *regexp.Regexp
 	Expresion string `json:"expresion"`
 	Property  string `json:"property,omitempty"`
@@ -32,6 +33,13 @@ func (m MatchConstraint) Validate() error {
 
 // Initialize is an implementation for the Constraint interface.
 func (m *MatchConstraint) Initialize() error {
+	path, err := CompilePath(m.Property)
+	if err != nil {
+		return err
+	}
+
+	m.path = path
+
 	regex, err := regexp.Compile(m.Expresion)
 	if err != nil {
 		return err
@@ -44,7 +52,7 @@ func (m *MatchConstraint) Initialize() error {
 
 // Evaluate is an implementation for the Constraint interface.
 func (m MatchConstraint) Evaluate(e model.Entity) bool {
-	property, ok := GetProperty(m.Property, e)
+	property, ok := m.path.String(e)
 	if !ok {
 		return false
 	}
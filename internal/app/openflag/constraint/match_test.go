@@ -87,7 +87,7 @@ func (suite *MatchConstraintSuite) TestMatchConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "hello.how.are.you"},
+						EntityContext: model.Context{"test": model.StringValue("hello.how.are.you")},
 					},
 					ResultExpected: true,
 				},
@@ -95,7 +95,7 @@ func (suite *MatchConstraintSuite) TestMatchConstraint() {
 					Entity: model.Entity{
 						EntityID:      8,
 						EntityType:    "t",
-						EntityContext: map[string]string{"test": "hello.how are.you"},
+						EntityContext: model.Context{"test": model.StringValue("hello.how are.you")},
 					},
 					ResultExpected: false,
 				},
@@ -0,0 +1,39 @@
+package constraint
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/metric"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	labelConstraint  = "constraint"
+	labelProperty    = "property"
+	missingIncrement = 1
+)
+
+// Metrics keeps global Prometheus metrics.
+type Metrics struct {
+	MissingPropertyCounter *prometheus.CounterVec
+}
+
+// nolint:gochecknoglobals
+var (
+	metrics = Metrics{
+		MissingPropertyCounter: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metric.Namespace,
+				Name:      "constraint_missing_property_total",
+				Help:      "Constraint evaluations of entities without a property that the constraint needs.",
+			}, []string{labelConstraint, labelProperty},
+		),
+	}
+)
+
+// reportMissingProperty reports an evaluation of a constraint for an entity that does not have a property that
+// the constraint needs, e.g. the property that a bucketing constraint hashes on.
+func (m Metrics) reportMissingProperty(constraint string, property string) {
+	m.MissingPropertyCounter.With(prometheus.Labels{labelConstraint: constraint, labelProperty: property}).
+		Add(missingIncrement)
+}
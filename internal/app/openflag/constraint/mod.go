@@ -1,12 +1,9 @@
 package constraint
 
 import (
-	"strconv"
-
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
 
 	validation "github.com/go-ozzo/ozzo-validation"
-	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -15,6 +12,7 @@ const (
 
 // ModConstraint represents Openflag mod constraint.
 type ModConstraint struct {
+	path     Path
 	Value    int64  `json:"value"`
 	Property string `json:"property,omitempty"`
 }
@@ -37,23 +35,25 @@ func (m ModConstraint) Validate() error {
 
 // Initialize is an implementation for the Constraint interface.
 func (m *ModConstraint) Initialize() error {
+	path, err := CompilePath(m.Property)
+	if err != nil {
+		return err
+	}
+
+	m.path = path
+
 	return nil
 }
 
 // Evaluate is an implementation for the Constraint interface.
 func (m ModConstraint) Evaluate(e model.Entity) bool {
-	property, ok := GetProperty(m.Property, e)
+	property, ok := m.path.Value(e)
 	if !ok {
 		return false
 	}
 
-	value, err := strconv.ParseInt(property, 0, 64)
-	if err != nil {
-		logrus.Errorf(
-			"invalid property for mod constraint => property: %s, value: %s, err: %s",
-			m.Property, property, err.Error(),
-		)
-
+	value, ok := property.Int()
+	if !ok {
 		return false
 	}
 
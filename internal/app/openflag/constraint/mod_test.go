@@ -93,7 +93,7 @@ func (suite *ModConstraintSuite) TestModConstraint() {
 					Entity: model.Entity{
 						EntityID:      9,
 						EntityType:    "9",
-						EntityContext: map[string]string{"test": "8"},
+						EntityContext: model.Context{"test": model.StringValue("8")},
 					},
 					ResultExpected: true,
 				},
@@ -101,7 +101,7 @@ func (suite *ModConstraintSuite) TestModConstraint() {
 					Entity: model.Entity{
 						EntityID:      9,
 						EntityType:    "9",
-						EntityContext: map[string]string{"test": "11"},
+						EntityContext: model.Context{"test": model.StringValue("11")},
 					},
 					ResultExpected: false,
 				},
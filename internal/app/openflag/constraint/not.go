@@ -38,6 +38,16 @@ func (n *NotConstraint) Initialize() error {
 	return nil
 }
 
+// Children is an implementation for the ParentConstraint interface.
+func (n *NotConstraint) Children() []Constraint {
+	return []Constraint{n.constraint}
+}
+
+// SetFlag is an implementation for the FlagConstraint interface.
+func (n *NotConstraint) SetFlag(flag string) {
+	SetFlag(n.constraint, flag)
+}
+
 // Evaluate is an implementation for the Constraint interface.
 func (n NotConstraint) Evaluate(e model.Entity) bool {
 	return !n.constraint.Evaluate(e)
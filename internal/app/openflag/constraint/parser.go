@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"unicode"
 
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
 	"github.com/golang-collections/collections/stack"
@@ -15,10 +16,49 @@ var (
 )
 
 // Parser represents a golang parser for parsing the main constraint based on the given expression.
+// Operands are the identifiers of the constraints, e.g. A or beta_users. The operators can be written
+// using their symbols (∩, ∪ and !) or their ASCII forms (&& or and, || or or, and not).
 type Parser struct{}
 
+// token represents a single token of an expression. Value is the operator symbol for the operators and
+// text is the token as it is written in the expression. Column is the 1-based position of the token.
+type token struct {
+	value  string
+	text   string
+	column int
+}
+
+// nolint:gochecknoglobals
+var (
+	operatorAliases = map[string]string{
+		"&&":  IntersectionConstraintName,
+		"and": IntersectionConstraintName,
+		"||":  UnionConstraintName,
+		"or":  UnionConstraintName,
+		"not": NotConstraintName,
+	}
+)
+
 func (p Parser) isOperand(c string) bool {
-	return "A" <= c && c <= "Z"
+	if c == "" || p.isOperator(c) {
+		return false
+	}
+
+	for i, r := range c {
+		if !p.isIdentifierRune(r, i == 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p Parser) isIdentifierRune(r rune, first bool) bool {
+	if r == '_' || unicode.IsLetter(r) {
+		return true
+	}
+
+	return !first && unicode.IsDigit(r)
 }
 
 func (p Parser) isOperator(c string) bool {
@@ -27,12 +67,177 @@ func (p Parser) isOperator(c string) bool {
 		c == UnionConstraintName
 }
 
-func (p Parser) isParentheses(c string) bool {
-	return c == "(" || c == ")"
+// tokenize splits the given expression into tokens. The operator aliases are replaced with
+// the operator symbols.
+func (p Parser) tokenize(expression string, constraints map[string]model.Constraint) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		char := string(runes[i])
+		column := i + 1
+
+		switch {
+		case unicode.IsSpace(runes[i]):
+			i++
+		case char == "(" || char == ")" || p.isOperator(char):
+			if char == NotConstraintName && len(tokens) > 0 && tokens[len(tokens)-1].text == "not" {
+				return nil, p.unexpected(token{value: char, text: char, column: column})
+			}
+
+			tokens = append(tokens, token{value: char, text: char, column: column})
+			i++
+		case (char == "&" || char == "|") && i+1 < len(runes) && runes[i+1] == runes[i]:
+			tokens = append(tokens, token{value: operatorAliases[char+char], text: char + char, column: column})
+			i += 2
+		case p.isIdentifierRune(runes[i], true):
+			j := i + 1
+			for j < len(runes) && p.isIdentifierRune(runes[j], false) {
+				j++
+			}
+
+			identifier := string(runes[i:j])
+
+			if operator, ok := operatorAliases[identifier]; ok {
+				// Stored expressions with consecutive ! operators keep their previous meaning,
+				// so the not keyword can not be combined with another negation.
+				if operator == NotConstraintName && len(tokens) > 0 && tokens[len(tokens)-1].value == NotConstraintName {
+					return nil, p.unexpected(token{value: operator, text: identifier, column: column})
+				}
+
+				tokens = append(tokens, token{value: operator, text: identifier, column: column})
+			} else if p.findConstraint(identifier, constraints) != nil {
+				tokens = append(tokens, token{value: identifier, text: identifier, column: column})
+			} else if operands := p.split(runes[i:j], column, constraints); operands != nil {
+				tokens = append(tokens, operands...)
+			} else {
+				return nil, fmt.Errorf("undefined operand %s at column %d in expression", identifier, column)
+			}
+
+			i = j
+		default:
+			return nil, fmt.Errorf("invalid character %s at column %d in expression", char, column)
+		}
+	}
+
+	return tokens, nil
+}
+
+// split splits an undefined identifier into single character operands. Stored expressions used to have
+// only single character operands, which could be written next to each other, e.g. AC∩. It returns nil
+// if any of the characters is not a defined operand.
+func (p Parser) split(identifier []rune, column int, constraints map[string]model.Constraint) []token {
+	if len(identifier) < 2 {
+		return nil
+	}
+
+	tokens := make([]token, 0, len(identifier))
+
+	for k, r := range identifier {
+		if p.findConstraint(string(r), constraints) == nil {
+			return nil
+		}
+
+		tokens = append(tokens, token{value: string(r), text: string(r), column: column + k})
+	}
+
+	return tokens
 }
 
-func (p Parser) isValidCharacter(c string) bool {
-	return p.isOperand(c) || p.isOperator(c) || p.isParentheses(c)
+func (p Parser) unexpected(t token) error {
+	if t.text == "" {
+		return fmt.Errorf("%w: unexpected end of expression at column %d", ErrFailedToParseExpression, t.column)
+	}
+
+	return fmt.Errorf("%w: unexpected token %s at column %d", ErrFailedToParseExpression, t.text, t.column)
+}
+
+// syntaxError finds the first unexpected token of an expression that can not be parsed. It checks the tokens
+// against the grammar of the expressions, so it can report the actual position of the problem. If the tokens
+// match the grammar, the given fallback token is reported.
+func (p Parser) syntaxError(tokens []token, end int, fallback token) error {
+	c := syntaxChecker{parser: p, tokens: tokens, end: token{column: end}}
+
+	if t, ok := c.expression(); !ok {
+		return p.unexpected(t)
+	}
+
+	if c.position < len(tokens) {
+		return p.unexpected(tokens[c.position])
+	}
+
+	return p.unexpected(fallback)
+}
+
+// syntaxChecker checks the tokens of an expression against the following grammar:
+// expression = term { ∪ term }, term = factor { ∩ factor }, factor = ! factor | operand | ( expression ).
+type syntaxChecker struct {
+	parser   Parser
+	tokens   []token
+	end      token
+	position int
+}
+
+func (s *syntaxChecker) next() token {
+	if s.position < len(s.tokens) {
+		return s.tokens[s.position]
+	}
+
+	return s.end
+}
+
+func (s *syntaxChecker) expression() (token, bool) {
+	return s.binary(UnionConstraintName, s.term)
+}
+
+func (s *syntaxChecker) term() (token, bool) {
+	return s.binary(IntersectionConstraintName, s.factor)
+}
+
+func (s *syntaxChecker) binary(operator string, operand func() (token, bool)) (token, bool) {
+	if t, ok := operand(); !ok {
+		return t, false
+	}
+
+	for s.next().value == operator {
+		s.position++
+
+		if t, ok := operand(); !ok {
+			return t, false
+		}
+	}
+
+	return token{}, true
+}
+
+func (s *syntaxChecker) factor() (token, bool) {
+	t := s.next()
+
+	switch {
+	case t.value == NotConstraintName:
+		s.position++
+		return s.factor()
+	case s.parser.isOperand(t.value):
+		s.position++
+		return token{}, true
+	case t.value == "(":
+		s.position++
+
+		if t, ok := s.expression(); !ok {
+			return t, false
+		}
+
+		if s.next().value != ")" {
+			return s.next(), false
+		}
+
+		s.position++
+
+		return token{}, true
+	}
+
+	return t, false
 }
 
 func (p Parser) findConstraint(identifier string, constraints map[string]model.Constraint) *model.Constraint {
@@ -107,116 +312,107 @@ func (p Parser) generateOperator(operator string, args []model.Constraint) (*mod
 // Parse parses and returns the main constraint based on the given expression.
 // nolint:funlen
 func (p Parser) Parse(expression string, constraints map[string]model.Constraint) (*model.Constraint, error) {
-	exp := ""
-
-	for i := 0; i < len([]rune(expression)); i++ {
-		char := string([]rune(expression)[i])
-
-		if char == " " || char == "\n" || char == "\r" || char == "\t" {
-			continue
-		}
-
-		if !p.isValidCharacter(char) {
-			return nil, fmt.Errorf("invalid character %s in expression", char)
-		}
-
-		if p.isOperand(char) && p.findConstraint(char, constraints) == nil {
-			return nil, fmt.Errorf("undefined operand %s in expression", char)
-		}
-
-		exp += char
+	tokens, err := p.tokenize(expression, constraints)
+	if err != nil {
+		return nil, err
 	}
 
-	if exp == "" {
+	if len(tokens) == 0 {
 		return nil, errors.New("at least one operand should be in expression")
 	}
 
-	postfix := ""
-	exp = "(" + exp + ")"
+	var postfix []token
+
+	end := len([]rune(expression)) + 1
+	all := append(append([]token{{value: "("}}, tokens...), token{value: ")", column: end})
 
 	parserStack := stack.New()
 	i := 0
 
-	for i < len([]rune(exp)) {
-		char := string([]rune(exp)[i])
+	for i < len(all) {
+		t := all[i]
 
-		if p.isOperand(char) {
-			postfix += char
+		if p.isOperand(t.value) {
+			postfix = append(postfix, t)
 			i++
-		} else if char == "(" {
-			parserStack.Push(char)
+		} else if t.value == "(" {
+			parserStack.Push(t)
 			i++
-		} else if p.isOperator(char) {
-			if parserStack.Peek() != nil && p.precedence(char) > p.precedence(parserStack.Peek().(string)) {
-				parserStack.Push(char)
+		} else if p.isOperator(t.value) {
+			if parserStack.Peek() != nil && p.precedence(t.value) > p.precedence(parserStack.Peek().(token).value) {
+				parserStack.Push(t)
 				i++
+			} else if parserStack.Peek() != nil {
+				postfix = append(postfix, parserStack.Pop().(token))
 			} else {
-				postfix += parserStack.Peek().(string)
-				parserStack.Pop()
+				return nil, p.syntaxError(tokens, end, t)
 			}
-		} else if char == ")" {
-			for parserStack.Peek() != nil && parserStack.Peek().(string) != "(" {
-				postfix += parserStack.Peek().(string)
-				parserStack.Pop()
+		} else if t.value == ")" {
+			for parserStack.Peek() != nil && parserStack.Peek().(token).value != "(" {
+				postfix = append(postfix, parserStack.Pop().(token))
 			}
 
 			parserStack.Pop()
 
 			i++
 		} else {
-			return nil, ErrFailedToParseExpression
+			return nil, p.syntaxError(tokens, end, t)
 		}
 	}
 
-	parserStack = stack.New()
-
-	for i := 0; i < len([]rune(postfix)); i++ {
-		char := string([]rune(postfix)[i])
+	// operand represents a parsed sub-expression and the first token of it.
+	type operand struct {
+		constraint *model.Constraint
+		first      token
+	}
 
-		if p.isOperand(char) {
-			parserStack.Push(p.findConstraint(char, constraints))
-		} else if char == IntersectionConstraintName || char == UnionConstraintName {
-			args := []model.Constraint{}
+	parserStack = stack.New()
 
+	for _, t := range postfix {
+		if p.isOperand(t.value) {
+			parserStack.Push(operand{constraint: p.findConstraint(t.value, constraints), first: t})
+		} else if t.value == IntersectionConstraintName || t.value == UnionConstraintName {
 			arg0 := parserStack.Pop()
 			arg1 := parserStack.Pop()
 			if arg0 == nil || arg1 == nil {
-				return nil, ErrFailedToParseExpression
+				return nil, p.syntaxError(tokens, end, t)
 			}
 
-			args = append(args, *arg0.(*model.Constraint))
-			args = append(args, *arg1.(*model.Constraint))
+			args := []model.Constraint{*arg0.(operand).constraint, *arg1.(operand).constraint}
 
-			c, err := p.generateOperator(char, args)
+			c, err := p.generateOperator(t.value, args)
 			if err != nil {
 				return nil, err
 			}
 
-			parserStack.Push(c)
-		} else if char == NotConstraintName {
-			args := []model.Constraint{}
-
+			parserStack.Push(operand{constraint: c, first: arg1.(operand).first})
+		} else if t.value == NotConstraintName {
 			arg := parserStack.Pop()
 			if arg == nil {
-				return nil, ErrFailedToParseExpression
+				return nil, p.syntaxError(tokens, end, t)
 			}
 
-			args = append(args, *arg.(*model.Constraint))
-
-			c, err := p.generateOperator(char, args)
+			c, err := p.generateOperator(t.value, []model.Constraint{*arg.(operand).constraint})
 			if err != nil {
 				return nil, err
 			}
 
-			parserStack.Push(c)
+			parserStack.Push(operand{constraint: c, first: t})
 		} else {
-			return nil, ErrFailedToParseExpression
+			return nil, p.syntaxError(tokens, end, t)
 		}
 	}
 
-	if parserStack.Len() == 1 {
-		return parserStack.Pop().(*model.Constraint), nil
+	if parserStack.Len() != 1 {
+		// The bottom of the stack is the first complete sub-expression, so the next one is unexpected.
+		var extra operand
+
+		for parserStack.Len() > 1 {
+			extra = parserStack.Pop().(operand)
+		}
+
+		return nil, p.syntaxError(tokens, end, extra.first)
 	}
 
-	return nil, ErrFailedToParseExpression
+	return parserStack.Pop().(operand).constraint, nil
 }
@@ -468,6 +468,111 @@ func (suite *ParserSuite) TestParserSuite() {
 				},
 			},
 		},
+		{
+			name: "successfully parse expression 10",
+			cExp: "is_ios and (beta_users || not old_version)",
+			cMap: map[string]model.Constraint{
+				"is_ios": {
+					Name:       constraint.ContainsConstraintName,
+					Parameters: json.RawMessage(`{"values": ["ios"], "property": "os"}`),
+				},
+				"beta_users": {
+					Name:       constraint.ContainsConstraintName,
+					Parameters: json.RawMessage(`{"values": ["1", "2"]}`),
+				},
+				"old_version": {
+					Name:       constraint.LessThanConstraintName,
+					Parameters: json.RawMessage(`{"value": 100}`),
+				},
+			},
+			errExpected: false,
+			evaluations: []struct {
+				entity         model.Entity
+				resultExpected bool
+			}{
+				{
+					entity: model.Entity{
+						EntityID:      1,
+						EntityContext: model.Context{"os": model.StringValue("ios")},
+					},
+					resultExpected: true,
+				},
+				{
+					entity: model.Entity{
+						EntityID:      110,
+						EntityContext: model.Context{"os": model.StringValue("ios")},
+					},
+					resultExpected: true,
+				},
+				{
+					entity: model.Entity{
+						EntityID:      10,
+						EntityContext: model.Context{"os": model.StringValue("ios")},
+					},
+					resultExpected: false,
+				},
+				{
+					entity: model.Entity{
+						EntityID:      1,
+						EntityContext: model.Context{"os": model.StringValue("android")},
+					},
+					resultExpected: false,
+				},
+			},
+		},
+		{
+			name: "successfully parse expression 11",
+			cExp: "A && B or !C",
+			cMap: map[string]model.Constraint{
+				"A": {
+					Name:       constraint.BiggerThanConstraintName,
+					Parameters: json.RawMessage(`{"value": 100}`),
+				},
+				"B": {
+					Name:       constraint.LessThanConstraintName,
+					Parameters: json.RawMessage(`{"value": 200}`),
+				},
+				"C": {
+					Name:       constraint.BiggerThanConstraintName,
+					Parameters: json.RawMessage(`{"value": 10}`),
+				},
+			},
+			errExpected: false,
+			evaluations: []struct {
+				entity         model.Entity
+				resultExpected bool
+			}{
+				{
+					entity: model.Entity{
+						EntityID: 150,
+					},
+					resultExpected: true,
+				},
+				{
+					entity: model.Entity{
+						EntityID: 5,
+					},
+					resultExpected: true,
+				},
+				{
+					entity: model.Entity{
+						EntityID: 50,
+					},
+					resultExpected: false,
+				},
+			},
+		},
+		{
+			name: "failed to parse expression with undefined operand",
+			cExp: "A and beta",
+			cMap: map[string]model.Constraint{
+				"A": {
+					Name:       constraint.BiggerThanConstraintName,
+					Parameters: json.RawMessage(`{"value": 100}`),
+				},
+			},
+			errExpected: true,
+		},
 	}
 
 	parser := constraint.Parser{}
@@ -498,6 +603,81 @@ func (suite *ParserSuite) TestParserSuite() {
 	}
 }
 
+func (suite *ParserSuite) TestParserLegacyExpressions() {
+	constraints := map[string]model.Constraint{}
+
+	for _, identifier := range []string{"A", "B", "C", "D"} {
+		constraints[identifier] = model.Constraint{
+			Name:       constraint.LessThanConstraintName,
+			Parameters: json.RawMessage(fmt.Sprintf(`{"value": %d}`, identifier[0])),
+		}
+	}
+
+	parser := constraint.Parser{}
+
+	cases := []struct {
+		expression string
+		equivalent string
+	}{
+		{expression: "A ∩ B ∪ C", equivalent: "A and B or C"},
+		{expression: "!A ∩ (B ∪ !C)", equivalent: "not A && (B || not C)"},
+		{expression: "A∩B∪C∩D", equivalent: "A and B or C and D"},
+		{expression: "!(A ∪ B) ∩ D", equivalent: "not (A or B) and D"},
+		{expression: "AC∩", equivalent: "A and C"},
+		{expression: "A B ∪", equivalent: "A or B"},
+		{expression: "AB∪C∩", equivalent: "A or (B and C)"},
+		{expression: "AB!∩", equivalent: "A and not B"},
+	}
+
+	for _, tc := range cases {
+		expected, err := parser.Parse(tc.expression, constraints)
+		suite.NoError(err)
+
+		result, err := parser.Parse(tc.equivalent, constraints)
+		suite.NoError(err)
+
+		suite.JSONEq(string(expected.Parameters), string(result.Parameters), tc.expression)
+	}
+
+	// The arguments of the binary operators are kept in the reverse order, as they have always been.
+	c, err := parser.Parse("A ∩ B", constraints)
+	suite.NoError(err)
+
+	suite.JSONEq(
+		`{"constraints": [{"name": "<", "parameters": {"value": 66}}, {"name": "<", "parameters": {"value": 65}}]}`,
+		string(c.Parameters),
+	)
+}
+
+func (suite *ParserSuite) TestParserErrors() {
+	constraints := map[string]model.Constraint{
+		"is_ios":     {Name: constraint.AlwaysConstraintName},
+		"beta_users": {Name: constraint.AlwaysConstraintName},
+	}
+
+	cases := []struct {
+		expression string
+		err        string
+	}{
+		{expression: "", err: "at least one operand should be in expression"},
+		{expression: "is_ios and beta_users is_ios", err: "unexpected token is_ios at column 23"},
+		{expression: "is_ios or or beta_users", err: "unexpected token or at column 11"},
+		{expression: "is_ios and", err: "unexpected end of expression at column 11"},
+		{expression: "is_ios and (beta_users", err: "unexpected end of expression at column 23"},
+		{expression: "is_ios ∩ not not beta_users", err: "unexpected token not at column 14"},
+		{expression: "is_ios & beta_users", err: "invalid character & at column 8 in expression"},
+		{expression: "is_ios and android", err: "undefined operand android at column 12 in expression"},
+	}
+
+	parser := constraint.Parser{}
+
+	for _, tc := range cases {
+		_, err := parser.Parse(tc.expression, constraints)
+		suite.Error(err, tc.expression)
+		suite.Contains(err.Error(), tc.err, tc.expression)
+	}
+}
+
 func TestParserSuite(t *testing.T) {
 	suite.Run(t, new(ParserSuite))
 }
@@ -0,0 +1,173 @@
+package constraint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+)
+
+const (
+	pathSeparator    = "."
+	pointerSeparator = "/"
+)
+
+// Path represents a compiled property path. A property is either a key of the entity context, a dotted path
+// such as device.os.version or a JSON pointer such as /device/os/version that reads the nested objects
+// of the context. A key that exists in the context as it is takes precedence over the nested objects, so
+// the flat keys that contain dots keep working. Constraints compile their paths in Initialize.
+type Path struct {
+	property string
+	keys     []string
+}
+
+// CompilePath compiles the given property into a path.
+func CompilePath(property string) (Path, error) {
+	path := Path{property: property}
+
+	switch {
+	case property == "" || property == EntityTypeProperty:
+		return path, nil
+	case strings.HasPrefix(property, pointerSeparator):
+		for _, token := range strings.Split(property[len(pointerSeparator):], pointerSeparator) {
+			key, err := unescapePointerToken(token)
+			if err != nil {
+				return Path{}, fmt.Errorf("invalid property %s: %w", property, err)
+			}
+
+			path.keys = append(path.keys, key)
+		}
+	default:
+		path.keys = strings.Split(property, pathSeparator)
+
+		for _, key := range path.keys {
+			if key == "" {
+				return Path{}, fmt.Errorf("invalid property %s: empty path segment", property)
+			}
+		}
+	}
+
+	return path, nil
+}
+
+// unescapePointerToken unescapes a JSON pointer reference token, i.e. ~1 to / and ~0 to ~.
+func unescapePointerToken(token string) (string, error) {
+	if !strings.Contains(token, "~") {
+		return token, nil
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(token); i++ {
+		if token[i] != '~' {
+			b.WriteByte(token[i])
+			continue
+		}
+
+		if i+1 == len(token) || (token[i+1] != '0' && token[i+1] != '1') {
+			return "", fmt.Errorf("invalid escape in %s", token)
+		}
+
+		if token[i+1] == '0' {
+			b.WriteByte('~')
+		} else {
+			b.WriteByte('/')
+		}
+
+		i++
+	}
+
+	return b.String(), nil
+}
+
+// Property returns the property of the path.
+func (p Path) Property() string {
+	return p.property
+}
+
+// Value returns the typed value of the path in the given entity. An index of a list can be used as
+// a path segment too, e.g. roles.0. The read is reported to the PropertyRecorder of the entity scope if any.
+func (p Path) Value(e model.Entity) (model.Value, bool) {
+	value, ok := p.value(e)
+
+	if p.property != "" {
+		recordProperty(e, p.property, value, ok)
+	}
+
+	return value, ok
+}
+
+func (p Path) value(e model.Entity) (model.Value, bool) {
+	switch p.property {
+	case "":
+		return model.StringValue(fmt.Sprintf("%d", e.EntityID)), true
+	case EntityTypeProperty:
+		return model.StringValue(e.EntityType), true
+	}
+
+	if value, ok := e.EntityContext[p.property]; ok {
+		return value, true
+	}
+
+	value, ok := e.EntityContext[p.keys[0]]
+	if !ok {
+		return model.Value{}, false
+	}
+
+	for _, key := range p.keys[1:] {
+		if value.Kind() == model.ListKind {
+			list := value.List()
+
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(list) {
+				return model.Value{}, false
+			}
+
+			value = model.StringValue(list[i])
+
+			continue
+		}
+
+		object, ok := value.Object()
+		if !ok {
+			return model.Value{}, false
+		}
+
+		if value, ok = object[key]; !ok {
+			return model.Value{}, false
+		}
+	}
+
+	return value, true
+}
+
+// String returns the value of the path in the given entity in the string form.
+func (p Path) String(e model.Entity) (string, bool) {
+	value, ok := p.Value(e)
+	if !ok {
+		return "", false
+	}
+
+	return value.String(), true
+}
+
+// Number returns the value of the path in the given entity as a number.
+func (p Path) Number(e model.Entity) (float64, bool) {
+	value, ok := p.Value(e)
+	if !ok {
+		return 0, false
+	}
+
+	return value.Number()
+}
+
+// List returns the value of the path in the given entity as a list of strings.
+func (p Path) List(e model.Entity) ([]string, bool) {
+	value, ok := p.Value(e)
+	if !ok {
+		return nil, false
+	}
+
+	return value.List(), true
+}
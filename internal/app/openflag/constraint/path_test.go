@@ -0,0 +1,101 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type PathSuite struct {
+	suite.Suite
+	entity model.Entity
+}
+
+func (suite *PathSuite) SetupSuite() {
+	context := model.Context{}
+
+	suite.NoError(json.Unmarshal([]byte(`{
+		"device": {"os": {"name": "ios", "version": "14.2.1"}, "screen": {"width": 1170}},
+		"account": {"roles": ["admin", "editor"], "a/b": {"m~n": "escaped"}},
+		"device.os": "flat"
+	}`), &context))
+
+	suite.entity = model.Entity{EntityID: 7, EntityType: "user", EntityContext: context}
+}
+
+func (suite *PathSuite) TestPathValue() {
+	cases := []struct {
+		property string
+		value    string
+		ok       bool
+	}{
+		{property: "", value: "7", ok: true},
+		{property: constraint.EntityTypeProperty, value: "user", ok: true},
+		{property: "device.os.name", value: "ios", ok: true},
+		{property: "/device/os/version", value: "14.2.1", ok: true},
+		{property: "device.screen.width", value: "1170", ok: true},
+		{property: "account.roles.1", value: "editor", ok: true},
+		{property: "/account/a~1b/m~0n", value: "escaped", ok: true},
+		{property: "device.os", value: "flat", ok: true},
+		{property: "device.os.build", ok: false},
+		{property: "device.os.name.first", ok: false},
+		{property: "account.roles.2", ok: false},
+		{property: "location.city", ok: false},
+	}
+
+	for _, tc := range cases {
+		path, err := constraint.CompilePath(tc.property)
+		suite.NoError(err, tc.property)
+
+		value, ok := path.String(suite.entity)
+		suite.Equal(tc.ok, ok, tc.property)
+		suite.Equal(tc.value, value, tc.property)
+
+		value, ok = constraint.GetProperty(tc.property, suite.entity)
+		suite.Equal(tc.ok, ok, tc.property)
+		suite.Equal(tc.value, value, tc.property)
+	}
+}
+
+func (suite *PathSuite) TestInvalidPaths() {
+	for _, property := range []string{"device..os", ".device", "device.", "/device/~2", "/device/os~"} {
+		_, err := constraint.CompilePath(property)
+		suite.Error(err, property)
+
+		_, err = constraint.New(
+			constraint.ContainsConstraintName,
+			json.RawMessage(`{"values": ["ios"], "property": "`+property+`"}`),
+		)
+		suite.Error(err, property)
+	}
+}
+
+func (suite *PathSuite) TestConstraintsWithPaths() {
+	cases := []struct {
+		name       string
+		parameters string
+	}{
+		{name: constraint.ContainsConstraintName, parameters: `{"values": ["ios"], "property": "device.os.name"}`},
+		{
+			name:       constraint.SemverRangeConstraintName,
+			parameters: `{"expression": ">=14.0.0 <15.0.0", "property": "/device/os/version"}`,
+		},
+		{name: constraint.BiggerThanConstraintName, parameters: `{"value": 1000, "property": "device.screen.width"}`},
+		{name: constraint.ContainsAllConstraintName, parameters: `{"values": ["admin"], "property": "account.roles"}`},
+		{name: constraint.StartsWithConstraintName, parameters: `{"values": ["ed"], "property": "account.roles.1"}`},
+	}
+
+	for _, tc := range cases {
+		c, err := constraint.New(tc.name, json.RawMessage(tc.parameters))
+		suite.NoError(err, tc.name)
+		suite.True(c.Evaluate(suite.entity), tc.name)
+		suite.False(c.Evaluate(model.Entity{EntityID: 7}), tc.name)
+	}
+}
+
+func TestPathSuite(t *testing.T) {
+	suite.Run(t, new(PathSuite))
+}
@@ -0,0 +1,180 @@
+package constraint
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// FlagEvaluator represents an interface for evaluating other flags for an entity.
+// The evaluation engine puts a FlagEvaluator into the entity scope for prerequisite constraints.
+type FlagEvaluator interface {
+	// EvaluateFlag returns the variant of the given flag for the entity and whether the entity gets any variant.
+	EvaluateFlag(flag string, e model.Entity) (model.Variant, bool)
+}
+
+// PrerequisiteConstraint represents Openflag prerequisite constraint.
+// It matches when the prerequisite flag is evaluated to one of the variants for the same entity.
+type PrerequisiteConstraint struct {
+	variantMap map[string]struct{}
+	Flag       string   `json:"flag"`
+	Variants   []string `json:"variants"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (p PrerequisiteConstraint) Name() string {
+	return PrerequisiteConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (p PrerequisiteConstraint) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(
+			&p.Flag,
+			validation.Required,
+		),
+		validation.Field(
+			&p.Variants,
+			validation.Required,
+			validation.Length(minValueLen, 0),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (p *PrerequisiteConstraint) Initialize() error {
+	variantMap := make(map[string]struct{})
+
+	for _, variant := range p.Variants {
+		variantMap[variant] = struct{}{}
+	}
+
+	p.variantMap = variantMap
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (p PrerequisiteConstraint) Evaluate(e model.Entity) bool {
+	evaluator, ok := e.Scope().(FlagEvaluator)
+	if !ok {
+		return false
+	}
+
+	variant, ok := evaluator.EvaluateFlag(p.Flag, e)
+	if !ok {
+		return false
+	}
+
+	_, ok = p.variantMap[variant.VariantKey]
+
+	return ok
+}
+
+// PrerequisiteFlags returns the prerequisite flags of the given constraints.
+func PrerequisiteFlags(constraints map[string]model.Constraint) []string {
+	var flags []string
+
+	for _, c := range constraints {
+		if c.Name != PrerequisiteConstraintName {
+			continue
+		}
+
+		p := PrerequisiteConstraint{}
+
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			continue
+		}
+
+		flags = append(flags, p.Flag)
+	}
+
+	return flags
+}
+
+// FindPrerequisiteCycles finds the dependency cycles of the given flags. The dependencies map contains the
+// prerequisite flags of each flag. Each cycle is returned as a sorted list of the flags that are part of it.
+func FindPrerequisiteCycles(dependencies map[string][]string) [][]string {
+	t := tarjan{
+		dependencies: dependencies,
+		index:        map[string]int{},
+		lowLink:      map[string]int{},
+		onStack:      map[string]bool{},
+	}
+
+	flags := make([]string, 0, len(dependencies))
+	for flag := range dependencies {
+		flags = append(flags, flag)
+	}
+
+	sort.Strings(flags)
+
+	for _, flag := range flags {
+		if _, ok := t.index[flag]; !ok {
+			t.visit(flag)
+		}
+	}
+
+	return t.cycles
+}
+
+// tarjan finds the strongly connected components of the dependency graph using Tarjan's algorithm.
+type tarjan struct {
+	dependencies map[string][]string
+	index        map[string]int
+	lowLink      map[string]int
+	onStack      map[string]bool
+	stack        []string
+	cycles       [][]string
+}
+
+func (t *tarjan) visit(flag string) {
+	t.index[flag] = len(t.index)
+	t.lowLink[flag] = t.index[flag]
+	t.stack = append(t.stack, flag)
+	t.onStack[flag] = true
+
+	selfLoop := false
+
+	for _, dependency := range t.dependencies[flag] {
+		if dependency == flag {
+			selfLoop = true
+		}
+
+		if _, ok := t.index[dependency]; !ok {
+			t.visit(dependency)
+
+			if t.lowLink[dependency] < t.lowLink[flag] {
+				t.lowLink[flag] = t.lowLink[dependency]
+			}
+		} else if t.onStack[dependency] && t.index[dependency] < t.lowLink[flag] {
+			t.lowLink[flag] = t.index[dependency]
+		}
+	}
+
+	if t.lowLink[flag] != t.index[flag] {
+		return
+	}
+
+	var component []string
+
+	for {
+		last := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+		t.onStack[last] = false
+
+		component = append(component, last)
+
+		if last == flag {
+			break
+		}
+	}
+
+	if len(component) > 1 || selfLoop {
+		sort.Strings(component)
+		t.cycles = append(t.cycles, component)
+	}
+}
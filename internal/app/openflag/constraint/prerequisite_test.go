@@ -0,0 +1,115 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeFlagEvaluator map[string]string
+
+func (f fakeFlagEvaluator) EvaluateFlag(flag string, e model.Entity) (model.Variant, bool) {
+	variant, ok := f[flag]
+
+	return model.Variant{VariantKey: variant}, ok
+}
+
+type PrerequisiteConstraintSuite struct {
+	ConstraintSuite
+}
+
+func (suite *PrerequisiteConstraintSuite) TestPrerequisiteConstraint() {
+	evaluator := fakeFlagEvaluator{"payment.v2": "on", "checkout": "off"}
+
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create constraint and evaluate 1",
+			Constraint: model.Constraint{
+				Name:       constraint.PrerequisiteConstraintName,
+				Parameters: json.RawMessage(`{"flag": "payment.v2", "variants": ["on", "beta"]}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: model.Entity{EntityID: 1}.WithScope(evaluator), ResultExpected: true},
+				{Entity: model.Entity{EntityID: 1}.WithScope(fakeFlagEvaluator{}), ResultExpected: false},
+				{Entity: model.Entity{EntityID: 1}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate 2",
+			Constraint: model.Constraint{
+				Name:       constraint.PrerequisiteConstraintName,
+				Parameters: json.RawMessage(`{"flag": "checkout", "variants": ["on"]}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: model.Entity{EntityID: 1}.WithScope(evaluator), ResultExpected: false},
+			},
+		},
+		{
+			Name: "failed to create constraint without flag",
+			Constraint: model.Constraint{
+				Name:       constraint.PrerequisiteConstraintName,
+				Parameters: json.RawMessage(`{"variants": ["on"]}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint without variants",
+			Constraint: model.Constraint{
+				Name:       constraint.PrerequisiteConstraintName,
+				Parameters: json.RawMessage(`{"flag": "checkout", "variants": []}`),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func (suite *PrerequisiteConstraintSuite) TestFindPrerequisiteCycles() {
+	cases := []struct {
+		name         string
+		dependencies map[string][]string
+		cycles       [][]string
+	}{
+		{
+			name:         "without cycles",
+			dependencies: map[string][]string{"a": {"b", "c"}, "b": {"c"}, "c": nil, "d": {"e"}},
+			cycles:       nil,
+		},
+		{
+			name:         "with self cycle",
+			dependencies: map[string][]string{"a": {"a"}, "b": {"a"}},
+			cycles:       [][]string{{"a"}},
+		},
+		{
+			name: "with multiple cycles",
+			dependencies: map[string][]string{
+				"a": {"b"}, "b": {"c"}, "c": {"a", "d"}, "d": nil, "e": {"f"}, "f": {"e"}, "g": {"a"},
+			},
+			cycles: [][]string{{"a", "b", "c"}, {"e", "f"}},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+
+		suite.Run(tc.name, func() {
+			suite.Equal(tc.cycles, constraint.FindPrerequisiteCycles(tc.dependencies))
+		})
+	}
+}
+
+func TestPrerequisiteConstraintSuite(t *testing.T) {
+	suite.Run(t, new(PrerequisiteConstraintSuite))
+}
@@ -0,0 +1,164 @@
+package constraint
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// Represents the easing modes of the ramp constraint.
+const (
+	LinearEasing = "linear"
+	StepEasing   = "step"
+)
+
+const (
+	defaultRampSteps = 10
+)
+
+// RampConstraint represents Openflag ramp constraint.
+// It is a rollout whose percentage grows automatically from the start percentage at the start time to
+// the end percentage at the end time. With the linear easing, the percentage grows continuously and with
+// the step easing, it grows in the given number of equal steps. Nothing is admitted before the start time
+// and the end percentage is admitted after the end time. Like the rollout constraint, it hashes the flag key,
+// the salt and the given property (the entity id by default) into buckets, so the entities that are admitted
+// stay admitted as the percentage grows. Entities without the property do not match.
+type RampConstraint struct {
+	path            Path
+	flag            string
+	start           *time.Time
+	end             *time.Time
+	StartTime       string  `json:"start_time"`
+	EndTime         string  `json:"end_time"`
+	StartPercentage float64 `json:"start_percentage"`
+	EndPercentage   float64 `json:"end_percentage"`
+	Easing          string  `json:"easing,omitempty"`
+	Steps           int     `json:"steps,omitempty"`
+	Timezone        string  `json:"timezone,omitempty"`
+	Salt            string  `json:"salt,omitempty"`
+	Property        string  `json:"property,omitempty"`
+	Clock           Clock   `json:"-"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (r RampConstraint) Name() string {
+	return RampConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (r RampConstraint) Validate() error {
+	if r.start != nil && r.end != nil && !r.start.Before(*r.end) {
+		return errors.New("start time should be before end time")
+	}
+
+	if r.StartPercentage > r.EndPercentage {
+		return errors.New("start percentage should not be more than end percentage")
+	}
+
+	if r.Steps != 0 && r.Easing != StepEasing {
+		return errors.New("steps can only be used with the step easing")
+	}
+
+	return validation.ValidateStruct(&r,
+		validation.Field(
+			&r.StartTime,
+			validation.Required,
+		),
+		validation.Field(
+			&r.EndTime,
+			validation.Required,
+		),
+		validation.Field(
+			&r.StartPercentage,
+			validation.Min(float64(0)),
+			validation.Max(float64(maxPercentage)),
+		),
+		validation.Field(
+			&r.EndPercentage,
+			validation.Min(float64(0)),
+			validation.Max(float64(maxPercentage)),
+		),
+		validation.Field(
+			&r.Easing,
+			validation.In(LinearEasing, StepEasing),
+		),
+		validation.Field(
+			&r.Steps,
+			validation.Min(0),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (r *RampConstraint) Initialize() error {
+	path, err := CompilePath(r.Property)
+	if err != nil {
+		return err
+	}
+
+	r.path = path
+
+	location, err := loadLocation(r.Timezone)
+	if err != nil {
+		return err
+	}
+
+	if r.start, err = parseDateTime(r.StartTime, location); err != nil {
+		return err
+	}
+
+	if r.end, err = parseDateTime(r.EndTime, location); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetFlag is an implementation for the FlagConstraint interface.
+func (r *RampConstraint) SetFlag(flag string) {
+	r.flag = flag
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (r RampConstraint) Evaluate(e model.Entity) bool {
+	buckets := int64(math.Round(r.Percentage(r.Clock.Now()) * BucketSize / maxPercentage))
+	if buckets == 0 {
+		return false
+	}
+
+	property, ok := r.path.String(e)
+	if !ok {
+		metrics.reportMissingProperty(RampConstraintName, r.Property)
+		return false
+	}
+
+	return Bucket(r.flag, r.Salt, property) < buckets
+}
+
+// Percentage returns the percentage of the entities that the ramp admits at the given time.
+func (r RampConstraint) Percentage(now time.Time) float64 {
+	if r.start == nil || r.end == nil || now.Before(*r.start) {
+		return 0
+	}
+
+	if !now.Before(*r.end) {
+		return r.EndPercentage
+	}
+
+	progress := float64(now.Sub(*r.start)) / float64(r.end.Sub(*r.start))
+
+	if r.Easing == StepEasing {
+		steps := r.Steps
+		if steps == 0 {
+			steps = defaultRampSteps
+		}
+
+		progress = math.Floor(progress*float64(steps)) / float64(steps)
+	}
+
+	return r.StartPercentage + (r.EndPercentage-r.StartPercentage)*progress
+}
@@ -0,0 +1,124 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type RampConstraintSuite struct {
+	ConstraintSuite
+}
+
+func (suite *RampConstraintSuite) newRamp(parameters string) *constraint.RampConstraint {
+	c, err := constraint.New(constraint.RampConstraintName, json.RawMessage(parameters))
+	suite.NoError(err)
+
+	constraint.SetFlag(c, "flag1")
+
+	ramp, ok := c.(*constraint.RampConstraint)
+	suite.True(ok)
+
+	return ramp
+}
+
+func (suite *RampConstraintSuite) TestRampPercentage() {
+	start := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	linear := suite.newRamp(`{
+		"start_time": "2020-11-01T00:00:00Z", "end_time": "2020-11-11T00:00:00Z",
+		"start_percentage": 10, "end_percentage": 60
+	}`)
+
+	step := suite.newRamp(`{
+		"start_time": "2020-11-01T00:00:00Z", "end_time": "2020-11-11T00:00:00Z",
+		"start_percentage": 0, "end_percentage": 100, "easing": "step", "steps": 4
+	}`)
+
+	cases := []struct {
+		now    time.Time
+		linear float64
+		step   float64
+	}{
+		{now: start.Add(-time.Hour), linear: 0, step: 0},
+		{now: start, linear: 10, step: 0},
+		{now: start.Add(24 * time.Hour), linear: 15, step: 0},
+		{now: start.Add(60 * time.Hour), linear: 22.5, step: 25},
+		{now: start.Add(5 * 24 * time.Hour), linear: 35, step: 50},
+		{now: start.Add(10*24*time.Hour - time.Second), linear: 60, step: 75},
+		{now: start.Add(10 * 24 * time.Hour), linear: 60, step: 100},
+		{now: start.Add(30 * 24 * time.Hour), linear: 60, step: 100},
+	}
+
+	for _, tc := range cases {
+		suite.InDelta(tc.linear, linear.Percentage(tc.now), 0.001, tc.now)
+		suite.InDelta(tc.step, step.Percentage(tc.now), 0.001, tc.now)
+	}
+}
+
+func (suite *RampConstraintSuite) TestRampAdmission() {
+	const entities = 10000
+
+	start := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+
+	ramp := suite.newRamp(`{
+		"start_time": "2020-11-01T00:00:00Z", "end_time": "2020-11-08T00:00:00Z",
+		"start_percentage": 1, "end_percentage": 100
+	}`)
+	ramp.Clock = func() time.Time { return now }
+
+	admitted := map[int64]bool{}
+
+	for day := 0; day <= 7; day++ {
+		now = start.Add(time.Duration(day) * 24 * time.Hour)
+
+		matched := 0
+
+		for i := int64(1); i <= entities; i++ {
+			result := ramp.Evaluate(model.Entity{EntityID: i})
+			if admitted[i] {
+				suite.True(result, "entity %d is not admitted on day %d anymore", i, day)
+			}
+
+			if result {
+				admitted[i] = true
+				matched++
+			}
+		}
+
+		suite.InDelta(entities*ramp.Percentage(now)/100, matched, entities*0.03)
+	}
+
+	suite.Len(admitted, entities)
+}
+
+func (suite *RampConstraintSuite) TestRampValidation() {
+	for _, parameters := range []string{
+		`{"end_time": "2020-11-08T00:00:00Z", "end_percentage": 100}`,
+		`{"start_time": "2020-11-08T00:00:00Z", "end_time": "2020-11-01T00:00:00Z", "end_percentage": 100}`,
+		`{"start_time": "2020-11-01T00:00:00Z", "end_time": "2020-11-08T00:00:00Z", "start_percentage": 50,
+			"end_percentage": 10}`,
+		`{"start_time": "2020-11-01T00:00:00Z", "end_time": "2020-11-08T00:00:00Z", "end_percentage": 110}`,
+		`{"start_time": "2020-11-01T00:00:00Z", "end_time": "2020-11-08T00:00:00Z", "end_percentage": 10,
+			"easing": "cubic"}`,
+		`{"start_time": "2020-11-01T00:00:00Z", "end_time": "2020-11-08T00:00:00Z", "end_percentage": 10,
+			"steps": 5}`,
+		`{"start_time": "2020-11-01", "end_time": "2020-11-08T00:00:00Z", "end_percentage": 10}`,
+	} {
+		suite.Error(constraint.Validate(constraint.RampConstraintName, json.RawMessage(parameters)), parameters)
+	}
+
+	suite.NoError(constraint.Validate(constraint.RampConstraintName, json.RawMessage(
+		`{"start_time": "2020-11-01T09:00:00", "end_time": "2020-11-08T09:00:00", "end_percentage": 10,
+			"timezone": "Asia/Tehran"}`,
+	)))
+}
+
+func TestRampConstraintSuite(t *testing.T) {
+	suite.Run(t, new(RampConstraintSuite))
+}
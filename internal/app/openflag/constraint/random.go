@@ -1,12 +1,64 @@
 package constraint
 
 import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
-	"github.com/Pallinder/go-randomdata"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	defaultProbability = 0.5
+	maxProbability     = 1
+
+	randomHashSalt = "random"
+)
+
+// nolint:gochecknoglobals
+var (
+	randomSource = newLockedSource(time.Now().UnixNano())
 )
 
 // RandomConstraint represents Openflag random constraint.
-type RandomConstraint struct{}
+// It matches with the given probability (0.5 by default) and works in three modes. By default, the outcome
+// is re-rolled on each evaluation. In the sticky mode, it is derived from a hash of the flag key and
+// the entity id, so it is random across the entities but stable for each of them. In the sticky mode, the
+// given property can be hashed instead of the entity id and entities without it do not match. The sticky
+// random constraints of a flag match the same entities unless they have different salts.
+// In the seeded mode, the outcomes come from a random source with the given seed, so they are reproducible.
+type RandomConstraint struct {
+	path        Path
+	flag        string
+	buckets     int64
+	source      *lockedSource
+	Probability *float64 `json:"probability,omitempty"`
+	Sticky      bool     `json:"sticky,omitempty"`
+	Salt        string   `json:"salt,omitempty"`
+	Seed        *int64   `json:"seed,omitempty"`
+	Property    string   `json:"property,omitempty"`
+}
+
+// lockedSource is a random source that is safe for concurrent use.
+type lockedSource struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newLockedSource(seed int64) *lockedSource {
+	return &lockedSource{rand: rand.New(rand.NewSource(seed))} // nolint:gosec
+}
+
+func (l *lockedSource) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rand.Float64()
+}
 
 // Name is an implementation for the Constraint interface.
 func (r RandomConstraint) Name() string {
@@ -15,15 +67,81 @@ func (r RandomConstraint) Name() string {
 
 // Validate is an implementation for the Constraint interface.
 func (r RandomConstraint) Validate() error {
-	return nil
+	if r.Sticky && r.Seed != nil {
+		return errors.New("random seed can not be used in the sticky mode")
+	}
+
+	if !r.Sticky && r.Property != "" {
+		return errors.New("random property can only be used in the sticky mode")
+	}
+
+	if !r.Sticky && r.Salt != "" {
+		return errors.New("random salt can only be used in the sticky mode")
+	}
+
+	return validation.ValidateStruct(&r,
+		validation.Field(
+			&r.Probability,
+			validation.Min(float64(0)),
+			validation.Max(float64(maxProbability)),
+		),
+	)
 }
 
 // Initialize is an implementation for the Constraint interface.
 func (r *RandomConstraint) Initialize() error {
+	path, err := CompilePath(r.Property)
+	if err != nil {
+		return err
+	}
+
+	r.path = path
+
+	r.buckets = int64(math.Round(r.probability() * BucketSize))
+
+	if r.Seed != nil {
+		r.source = newLockedSource(*r.Seed)
+	} else {
+		r.source = randomSource
+	}
+
 	return nil
 }
 
+// SetFlag is an implementation for the FlagConstraint interface.
+func (r *RandomConstraint) SetFlag(flag string) {
+	r.flag = flag
+}
+
 // Evaluate is an implementation for the Constraint interface.
 func (r RandomConstraint) Evaluate(e model.Entity) bool {
-	return randomdata.Boolean()
+	if r.Sticky {
+		property, ok := r.path.String(e)
+		if !ok {
+			metrics.reportMissingProperty(RandomConstraintName, r.Property)
+			return false
+		}
+
+		return r.bucket(property) < r.buckets
+	}
+
+	return r.source.Float64() < r.probability()
+}
+
+// bucket returns the bucket of the given property in the sticky mode. The constraints without a salt keep
+// the buckets that they had before salts were supported.
+func (r RandomConstraint) bucket(property string) int64 {
+	if r.Salt == "" {
+		return Bucket(r.flag, randomHashSalt, property)
+	}
+
+	return Bucket(r.flag, randomHashSalt, r.Salt, property)
+}
+
+func (r RandomConstraint) probability() float64 {
+	if r.Probability == nil {
+		return defaultProbability
+	}
+
+	return *r.Probability
 }
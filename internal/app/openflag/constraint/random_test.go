@@ -36,3 +36,154 @@ func TestRandomConstraint(t *testing.T) {
 	assert.NotEqual(t, tr, 0)
 	assert.NotEqual(t, tr, 0)
 }
+
+func TestRandomConstraintProbability(t *testing.T) {
+	const entities = 10000
+
+	cases := []struct {
+		name        string
+		parameters  string
+		probability float64
+	}{
+		{name: "random", parameters: `{"probability": 0.2}`, probability: 0.2},
+		{name: "sticky", parameters: `{"probability": 0.7, "sticky": true}`, probability: 0.7},
+		{name: "seeded", parameters: `{"probability": 0.4, "seed": 42}`, probability: 0.4},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := constraint.New(constraint.RandomConstraintName, json.RawMessage(tc.parameters))
+			assert.NoError(t, err)
+
+			constraint.SetFlag(c, "flag1")
+
+			matched := 0
+
+			for i := int64(1); i <= entities; i++ {
+				if c.Evaluate(model.Entity{EntityID: i}) {
+					matched++
+				}
+			}
+
+			assert.InDelta(t, entities*tc.probability, matched, entities*0.03)
+		})
+	}
+
+	for _, probability := range []string{`{"probability": 0}`, `{"probability": 0, "sticky": true}`} {
+		c, err := constraint.New(constraint.RandomConstraintName, json.RawMessage(probability))
+		assert.NoError(t, err)
+
+		for i := int64(1); i <= 100; i++ {
+			assert.False(t, c.Evaluate(model.Entity{EntityID: i}))
+		}
+	}
+}
+
+func TestRandomConstraintStickiness(t *testing.T) {
+	c, err := constraint.New(constraint.RandomConstraintName, json.RawMessage(`{"sticky": true}`))
+	assert.NoError(t, err)
+
+	constraint.SetFlag(c, "flag1")
+
+	other, err := constraint.New(constraint.RandomConstraintName, json.RawMessage(`{"sticky": true}`))
+	assert.NoError(t, err)
+
+	constraint.SetFlag(other, "flag2")
+
+	differs := false
+
+	for i := int64(1); i <= 100; i++ {
+		e := model.Entity{EntityID: i}
+
+		result := c.Evaluate(e)
+
+		for j := 0; j < 5; j++ {
+			assert.Equal(t, result, c.Evaluate(e))
+		}
+
+		if result != other.Evaluate(e) {
+			differs = true
+		}
+	}
+
+	assert.True(t, differs)
+}
+
+func TestRandomConstraintSalt(t *testing.T) {
+	newConstraint := func(parameters string) constraint.Constraint {
+		c, err := constraint.New(constraint.RandomConstraintName, json.RawMessage(parameters))
+		assert.NoError(t, err)
+
+		constraint.SetFlag(c, "flag1")
+
+		return c
+	}
+
+	c := newConstraint(`{"sticky": true, "salt": "segment1"}`)
+	same := newConstraint(`{"sticky": true, "salt": "segment1"}`)
+	other := newConstraint(`{"sticky": true, "salt": "segment2"}`)
+
+	differs := false
+
+	for i := int64(1); i <= 100; i++ {
+		e := model.Entity{EntityID: i}
+
+		assert.Equal(t, c.Evaluate(e), same.Evaluate(e))
+
+		if c.Evaluate(e) != other.Evaluate(e) {
+			differs = true
+		}
+	}
+
+	assert.True(t, differs)
+}
+
+func TestRandomConstraintProperty(t *testing.T) {
+	c, err := constraint.New(
+		constraint.RandomConstraintName, json.RawMessage(`{"sticky": true, "property": "device.id"}`),
+	)
+	assert.NoError(t, err)
+
+	constraint.SetFlag(c, "flag1")
+
+	for device := 0; device < 100; device++ {
+		context := model.Context{"device": model.ObjectValue(model.Context{"id": model.NumberValue(float64(device))})}
+		result := c.Evaluate(model.Entity{EntityID: 1, EntityContext: context})
+
+		for i := int64(2); i <= 5; i++ {
+			assert.Equal(t, result, c.Evaluate(model.Entity{EntityID: i, EntityContext: context}))
+		}
+	}
+
+	assert.False(t, c.Evaluate(model.Entity{EntityID: 1}))
+}
+
+func TestRandomConstraintSeed(t *testing.T) {
+	evaluate := func() []bool {
+		c, err := constraint.New(constraint.RandomConstraintName, json.RawMessage(`{"seed": 7}`))
+		assert.NoError(t, err)
+
+		var results []bool
+
+		for i := int64(1); i <= 100; i++ {
+			results = append(results, c.Evaluate(model.Entity{EntityID: i}))
+		}
+
+		return results
+	}
+
+	assert.Equal(t, evaluate(), evaluate())
+}
+
+func TestRandomConstraintValidation(t *testing.T) {
+	for _, parameters := range []string{
+		`{"probability": -0.1}`,
+		`{"probability": 1.1}`,
+		`{"sticky": true, "seed": 7}`,
+		`{"property": "device_id"}`,
+		`{"salt": "segment1"}`,
+	} {
+		assert.Error(t, constraint.Validate(constraint.RandomConstraintName, json.RawMessage(parameters)), parameters)
+	}
+}
@@ -0,0 +1,198 @@
+package constraint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Represents the JSON types of constraint parameters.
+const (
+	StringParameter  ParameterType = "string"
+	NumberParameter  ParameterType = "number"
+	BooleanParameter ParameterType = "boolean"
+	ArrayParameter   ParameterType = "array"
+	ObjectParameter  ParameterType = "object"
+)
+
+var (
+	// ErrDuplicateConstraint represents an error for returning when we want to register a constraint
+	// with a name that is already in use.
+	ErrDuplicateConstraint = errors.New("constraint is already registered")
+	// ErrInvalidRegistration represents an error for returning when we want to register a constraint
+	// without a name or a valid factory.
+	ErrInvalidRegistration = errors.New("invalid constraint registration")
+)
+
+// nolint:gochecknoglobals
+var (
+	registry = struct {
+		sync.RWMutex
+		registrations map[string]Registration
+	}{registrations: map[string]Registration{}}
+)
+
+type (
+	// ParameterType represents the JSON type of a constraint parameter.
+	ParameterType string
+
+	// Parameter represents a parameter in the schema of a registered constraint.
+	Parameter struct {
+		Type     ParameterType `json:"type"`
+		Required bool          `json:"required,omitempty"`
+	}
+
+	// Schema represents the parameters of a registered constraint by their names.
+	Schema map[string]Parameter
+
+	// Factory creates a new instance of a registered constraint. The constraint parameters are unmarshalled
+	// into the returned value, so it should be a pointer.
+	Factory func() Constraint
+
+	// Registration represents a custom constraint type that is registered using Register.
+	Registration struct {
+		Name    string
+		Factory Factory
+		Schema  Schema
+	}
+)
+
+// Register registers a custom constraint type, so that segments can use it like the basic constraints.
+// The applications that embed OpenFlag register their constraints using the public pkg/constraint package.
+// Registering a name that is already in use fails with ErrDuplicateConstraint.
+func Register(name string, factory Factory, schema Schema) error {
+	if name == "" || factory == nil {
+		return ErrInvalidRegistration
+	}
+
+	c := factory()
+	if c == nil || c.Name() != name {
+		return fmt.Errorf("%w: factory of %s should create a constraint with the same name", ErrInvalidRegistration, name)
+	}
+
+	for parameter, p := range schema {
+		switch p.Type {
+		case StringParameter, NumberParameter, BooleanParameter, ArrayParameter, ObjectParameter:
+		default:
+			return fmt.Errorf("%w: invalid type of parameter %s", ErrInvalidRegistration, parameter)
+		}
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.registrations[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateConstraint, name)
+	}
+
+	if _, err := findBasic(name); err == nil {
+		return fmt.Errorf("%w: %s", ErrDuplicateConstraint, name)
+	}
+
+	registry.registrations[name] = Registration{Name: name, Factory: factory, Schema: schema}
+
+	return nil
+}
+
+// Registered returns the registered custom constraint types sorted by their names.
+func Registered() []Registration {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	registrations := make([]Registration, 0, len(registry.registrations))
+
+	for _, r := range registry.registrations {
+		registrations = append(registrations, r)
+	}
+
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Name < registrations[j].Name
+	})
+
+	return registrations
+}
+
+func findRegistration(name string) (Registration, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	r, ok := registry.registrations[name]
+
+	return r, ok
+}
+
+// validateParameters validates the given parameters against the schema of the constraint if it is
+// a registered one.
+func validateParameters(name string, parameters json.RawMessage) error {
+	r, ok := findRegistration(name)
+	if !ok {
+		return nil
+	}
+
+	return r.Schema.Validate(parameters)
+}
+
+// Validate validates the given parameters against the schema. Unknown parameters are rejected.
+func (s Schema) Validate(parameters json.RawMessage) error {
+	values := map[string]json.RawMessage{}
+
+	if len(bytes.TrimSpace(parameters)) != 0 {
+		if err := json.Unmarshal(parameters, &values); err != nil {
+			return err
+		}
+	}
+
+	for name, value := range values {
+		p, ok := s[name]
+		if !ok {
+			return fmt.Errorf("unknown parameter %s", name)
+		}
+
+		t, ok := parameterType(value)
+		if !ok {
+			if p.Required {
+				return fmt.Errorf("parameter %s is required", name)
+			}
+
+			continue
+		}
+
+		if t != p.Type {
+			return fmt.Errorf("parameter %s should be %s", name, p.Type)
+		}
+	}
+
+	for name, p := range s {
+		if _, ok := values[name]; p.Required && !ok {
+			return fmt.Errorf("parameter %s is required", name)
+		}
+	}
+
+	return nil
+}
+
+// parameterType returns the JSON type of the given value. It returns false for null values.
+func parameterType(value json.RawMessage) (ParameterType, bool) {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 {
+		return "", false
+	}
+
+	switch value[0] {
+	case '"':
+		return StringParameter, true
+	case 't', 'f':
+		return BooleanParameter, true
+	case '[':
+		return ArrayParameter, true
+	case '{':
+		return ObjectParameter, true
+	case 'n':
+		return "", false
+	default:
+		return NumberParameter, true
+	}
+}
@@ -0,0 +1,181 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+const premiumConstraintName = "premium"
+
+type premiumConstraint struct {
+	Plans []string `json:"plans"`
+	Trial bool     `json:"trial,omitempty"`
+}
+
+func (p premiumConstraint) Name() string {
+	return premiumConstraintName
+}
+
+func (p premiumConstraint) Validate() error {
+	if len(p.Plans) == 0 {
+		return errors.New("plans are required")
+	}
+
+	return nil
+}
+
+func (p *premiumConstraint) Initialize() error {
+	return nil
+}
+
+func (p premiumConstraint) Evaluate(e model.Entity) bool {
+	if trial, _ := e.EntityContext["trial"].Bool(); trial && !p.Trial {
+		return false
+	}
+
+	for _, plan := range p.Plans {
+		if e.EntityContext["plan"].String() == plan {
+			return true
+		}
+	}
+
+	return false
+}
+
+type RegistrySuite struct {
+	ConstraintSuite
+}
+
+func (suite *RegistrySuite) SetupSuite() {
+	err := constraint.Register(
+		premiumConstraintName,
+		func() constraint.Constraint { return &premiumConstraint{} },
+		constraint.Schema{
+			"plans": {Type: constraint.ArrayParameter, Required: true},
+			"trial": {Type: constraint.BooleanParameter},
+		},
+	)
+	suite.NoError(err)
+}
+
+func (suite *RegistrySuite) TestRegisteredConstraint() {
+	suite.Contains(constraint.BasicConstraints(), premiumConstraintName)
+
+	c, err := constraint.Find(premiumConstraintName)
+	suite.NoError(err)
+	suite.Equal(premiumConstraintName, c.Name())
+
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create registered constraint and evaluate",
+			Constraint: model.Constraint{
+				Name:       premiumConstraintName,
+				Parameters: json.RawMessage(`{"plans": ["gold", "silver"]}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: model.Entity{EntityContext: model.Context{"plan": model.StringValue("gold")}}, ResultExpected: true},
+				{Entity: model.Entity{EntityContext: model.Context{"plan": model.StringValue("bronze")}}, ResultExpected: false},
+				{
+					Entity: model.Entity{EntityContext: model.Context{
+						"plan":  model.StringValue("gold"),
+						"trial": model.StringValue("true"),
+					}},
+					ResultExpected: false,
+				},
+			},
+		},
+		{
+			Name: "successfully create registered constraint inside a basic constraint and evaluate",
+			Constraint: model.Constraint{
+				Name: constraint.NotConstraintName,
+				Parameters: json.RawMessage(
+					`{"constraint": {"name": "premium", "parameters": {"plans": ["gold"], "trial": true}}}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: model.Entity{EntityContext: model.Context{"plan": model.StringValue("gold")}}, ResultExpected: false},
+				{Entity: model.Entity{EntityContext: model.Context{"plan": model.StringValue("bronze")}}, ResultExpected: true},
+			},
+		},
+		{
+			Name: "failed to create registered constraint without required parameter",
+			Constraint: model.Constraint{
+				Name:       premiumConstraintName,
+				Parameters: json.RawMessage(`{"trial": true}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create registered constraint with invalid parameter type",
+			Constraint: model.Constraint{
+				Name:       premiumConstraintName,
+				Parameters: json.RawMessage(`{"plans": ["gold"], "trial": "yes"}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create registered constraint with unknown parameter",
+			Constraint: model.Constraint{
+				Name:       premiumConstraintName,
+				Parameters: json.RawMessage(`{"plans": ["gold"], "tier": 1}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create registered constraint with invalid parameters",
+			Constraint: model.Constraint{
+				Name:       premiumConstraintName,
+				Parameters: json.RawMessage(`{"plans": []}`),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func (suite *RegistrySuite) TestRegisterFailures() {
+	factory := func() constraint.Constraint { return &premiumConstraint{} }
+
+	err := constraint.Register(premiumConstraintName, factory, nil)
+	suite.True(errors.Is(err, constraint.ErrDuplicateConstraint))
+
+	err = constraint.Register(constraint.ContainsConstraintName, func() constraint.Constraint {
+		return &constraint.ContainsConstraint{}
+	}, nil)
+	suite.True(errors.Is(err, constraint.ErrDuplicateConstraint))
+
+	err = constraint.Register("gold", factory, nil)
+	suite.True(errors.Is(err, constraint.ErrInvalidRegistration))
+
+	err = constraint.Register("", factory, nil)
+	suite.True(errors.Is(err, constraint.ErrInvalidRegistration))
+
+	err = constraint.Register("silver", nil, nil)
+	suite.True(errors.Is(err, constraint.ErrInvalidRegistration))
+
+	names := []string{}
+
+	for _, r := range constraint.Registered() {
+		names = append(names, r.Name)
+	}
+
+	suite.Equal([]string{premiumConstraintName}, names)
+}
+
+func TestRegistrySuite(t *testing.T) {
+	suite.Run(t, new(RegistrySuite))
+}
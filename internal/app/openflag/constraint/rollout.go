@@ -2,6 +2,7 @@ package constraint
 
 import (
 	"errors"
+	"math"
 
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
 
@@ -15,9 +16,21 @@ const (
 )
 
 // RolloutConstraint represents Openflag rollout constraint.
+// It works in two modes. If the percentage is not set, it checks a bucket between 0 and 99 against the lower
+// and upper bounds. Otherwise, it hashes the flag key, the salt and the given property (the entity id by
+// default) into BucketSize buckets and admits the given percentage of them. Setting the property, e.g. to
+// company_id, buckets all entities with the same property value together. In the bounds mode, the bucket
+// is the entity id modulo 100 if the property is not set and comes from the same hash otherwise.
+// Entities without the property do not match.
 type RolloutConstraint struct {
-	LowerBound int `json:"lower_bound"`
-	UpperBound int `json:"upper_bound"`
+	path       Path
+	flag       string
+	buckets    int64
+	LowerBound int      `json:"lower_bound"`
+	UpperBound int      `json:"upper_bound"`
+	Percentage *float64 `json:"percentage,omitempty"`
+	Salt       string   `json:"salt,omitempty"`
+	Property   string   `json:"property,omitempty"`
 }
 
 // Name is an implementation for the Constraint interface.
@@ -27,6 +40,20 @@ func (r RolloutConstraint) Name() string {
 
 // Validate is an implementation for the Constraint interface.
 func (r RolloutConstraint) Validate() error {
+	if r.Percentage != nil {
+		if r.LowerBound != 0 || r.UpperBound != 0 {
+			return errors.New("rollout bounds can not be used with percentage")
+		}
+
+		return validation.ValidateStruct(&r,
+			validation.Field(
+				&r.Percentage,
+				validation.Min(float64(0)),
+				validation.Max(float64(maxPercentage)),
+			),
+		)
+	}
+
 	if r.LowerBound >= r.UpperBound {
 		return errors.New("invalid rollout bound")
 	}
@@ -47,12 +74,46 @@ func (r RolloutConstraint) Validate() error {
 }
 
 // Initialize is an implementation for the Constraint interface.
-func (r RolloutConstraint) Initialize() error {
+func (r *RolloutConstraint) Initialize() error {
+	path, err := CompilePath(r.Property)
+	if err != nil {
+		return err
+	}
+
+	r.path = path
+
+	if r.Percentage != nil {
+		r.buckets = int64(math.Round(*r.Percentage * BucketSize / maxPercentage))
+	}
+
 	return nil
 }
 
+// SetFlag is an implementation for the FlagConstraint interface.
+func (r *RolloutConstraint) SetFlag(flag string) {
+	r.flag = flag
+}
+
 // Evaluate is an implementation for the Constraint interface.
 func (r RolloutConstraint) Evaluate(e model.Entity) bool {
-	return (e.EntityID%maxPercentage) >= int64(r.LowerBound) &&
-		(e.EntityID%maxPercentage) <= int64(r.UpperBound)
+	if r.Percentage == nil && r.Property == "" {
+		return (e.EntityID%maxPercentage) >= int64(r.LowerBound) &&
+			(e.EntityID%maxPercentage) <= int64(r.UpperBound)
+	}
+
+	property, ok := r.path.String(e)
+	if !ok {
+		metrics.reportMissingProperty(RolloutConstraintName, r.Property)
+		return false
+	}
+
+	bucket := Bucket(r.flag, r.Salt, property)
+
+	if r.Percentage != nil {
+		return bucket < r.buckets
+	}
+
+	percentile := bucket * maxPercentage / BucketSize
+
+	return percentile >= int64(r.LowerBound) && percentile <= int64(r.UpperBound)
 }
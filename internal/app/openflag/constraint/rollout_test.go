@@ -7,6 +7,7 @@ import (
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
 
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -49,6 +50,83 @@ func (suite *RolloutConstraintSuite) TestRolloutConstraint() {
 				},
 			},
 		},
+		{
+			Name: "successfully create constraint and evaluate 2",
+			Constraint: model.Constraint{
+				Name: constraint.RolloutConstraintName,
+				Parameters: json.RawMessage(
+					`{"percentage": 100, "salt": "salt1"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity: model.Entity{
+						EntityID: 15,
+					},
+					ResultExpected: true,
+				},
+				{
+					Entity: model.Entity{
+						EntityID: 1005,
+					},
+					ResultExpected: true,
+				},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate 3",
+			Constraint: model.Constraint{
+				Name: constraint.RolloutConstraintName,
+				Parameters: json.RawMessage(
+					`{"percentage": 0, "property": "city"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity: model.Entity{
+						EntityID:      15,
+						EntityContext: model.Context{"city": model.StringValue("AMS")},
+					},
+					ResultExpected: false,
+				},
+			},
+		},
+		{
+			Name: "successfully create constraint and evaluate 4",
+			Constraint: model.Constraint{
+				Name: constraint.RolloutConstraintName,
+				Parameters: json.RawMessage(
+					`{"percentage": 100, "property": "city"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity: model.Entity{
+						EntityID:      15,
+						EntityContext: model.Context{"city": model.StringValue("AMS")},
+					},
+					ResultExpected: true,
+				},
+				{
+					Entity: model.Entity{
+						EntityID: 15,
+					},
+					ResultExpected: false,
+				},
+			},
+		},
 		{
 			Name: "failed to create constraint with invalid parameters",
 			Constraint: model.Constraint{
@@ -59,11 +137,117 @@ func (suite *RolloutConstraintSuite) TestRolloutConstraint() {
 			},
 			ErrExpected: true,
 		},
+		{
+			Name: "failed to create constraint with invalid percentage",
+			Constraint: model.Constraint{
+				Name: constraint.RolloutConstraintName,
+				Parameters: json.RawMessage(
+					`{"percentage": 100.5}`,
+				),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with both percentage and bounds",
+			Constraint: model.Constraint{
+				Name: constraint.RolloutConstraintName,
+				Parameters: json.RawMessage(
+					`{"percentage": 10, "lower_bound": 0, "upper_bound": 10}`,
+				),
+			},
+			ErrExpected: true,
+		},
 	}
 
 	suite.RunCases(cases)
 }
 
+func (suite *RolloutConstraintSuite) TestRolloutConstraintHash() {
+	const entities = 20000
+
+	newRollout := func(flag, parameters string) constraint.Constraint {
+		c, err := constraint.New(constraint.RolloutConstraintName, json.RawMessage(parameters))
+		suite.NoError(err)
+
+		constraint.SetFlag(c, flag)
+
+		return c
+	}
+
+	canary := newRollout("flag1", `{"percentage": 0.5}`)
+	flag1 := newRollout("flag1", `{"percentage": 10}`)
+	flag2 := newRollout("flag2", `{"percentage": 10}`)
+	resalted := newRollout("flag1", `{"percentage": 10, "salt": "salt1"}`)
+
+	canaryCount, flag1Count, bothCount, resaltedBothCount := 0, 0, 0, 0
+
+	for i := int64(1); i <= entities; i++ {
+		e := model.Entity{EntityID: i}
+
+		if canary.Evaluate(e) {
+			canaryCount++
+
+			suite.True(flag1.Evaluate(e), "smaller rollouts should be a subset of bigger ones")
+		}
+
+		if flag1.Evaluate(e) {
+			flag1Count++
+
+			if flag2.Evaluate(e) {
+				bothCount++
+			}
+
+			if resalted.Evaluate(e) {
+				resaltedBothCount++
+			}
+		}
+	}
+
+	suite.InDelta(entities*0.005, canaryCount, entities*0.002)
+	suite.InDelta(entities*0.1, flag1Count, entities*0.01)
+	suite.InDelta(entities*0.01, bothCount, entities*0.005)
+	suite.InDelta(entities*0.01, resaltedBothCount, entities*0.005)
+}
+
+func (suite *RolloutConstraintSuite) TestRolloutConstraintProperty() {
+	for _, parameters := range []string{
+		`{"percentage": 50, "property": "company_id"}`,
+		`{"lower_bound": 0, "upper_bound": 49, "property": "company_id"}`,
+	} {
+		c, err := constraint.New(constraint.RolloutConstraintName, json.RawMessage(parameters))
+		suite.NoError(err)
+
+		constraint.SetFlag(c, "flag1")
+
+		admitted := 0
+
+		for company := 0; company < 1000; company++ {
+			companyID := model.NumberValue(float64(company))
+			result := c.Evaluate(model.Entity{EntityID: 1, EntityContext: model.Context{"company_id": companyID}})
+
+			if result {
+				admitted++
+			}
+
+			for i := int64(2); i <= 5; i++ {
+				e := model.Entity{EntityID: i, EntityContext: model.Context{"company_id": companyID}}
+				suite.Equal(result, c.Evaluate(e), "entities of a company should be bucketed together")
+			}
+		}
+
+		suite.InDelta(500, admitted, 60, parameters)
+
+		before := testutil.ToFloat64(
+			constraint.MissingPropertyCounter(constraint.RolloutConstraintName, "company_id"),
+		)
+
+		suite.False(c.Evaluate(model.Entity{EntityID: 1}))
+		suite.Equal(before+1, testutil.ToFloat64(
+			constraint.MissingPropertyCounter(constraint.RolloutConstraintName, "company_id"),
+		))
+	}
+}
+
 func TestRolloutConstraintSuite(t *testing.T) {
 	suite.Run(t, new(RolloutConstraintSuite))
 }
@@ -0,0 +1,613 @@
+package constraint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+)
+
+const (
+	// EntityIDProperty represents the name that rules use for the entity id property.
+	EntityIDProperty = "entity_id"
+
+	ruleIgnoreCase = "ignore_case"
+)
+
+var (
+	// ErrFailedToParseRule represents an error that we return when we can not parse the given rule.
+	ErrFailedToParseRule = errors.New("failed to parse rule")
+)
+
+type (
+	ruleTokenKind int
+
+	// ruleToken represents a single token of a rule. Column is the 1-based position of the token in the rule.
+	ruleToken struct {
+		kind   ruleTokenKind
+		text   string
+		value  string
+		column int
+	}
+
+	// ruleCompiler compiles the tokens of a rule into a constraint tree using the following grammar:
+	// rule = term { or term }, term = factor { and factor },
+	// factor = not factor | ( rule ) | always | property operator value.
+	ruleCompiler struct {
+		tokens   []ruleToken
+		position int
+	}
+)
+
+const (
+	ruleEnd ruleTokenKind = iota
+	ruleWord
+	ruleString
+	ruleNumber
+	ruleSymbol
+)
+
+// CompileRule compiles the given rule into a constraint tree. A rule combines comparisons of the entity
+// properties using and (&&), or (||) and not (!), e.g. `country in ["IR", "DE"] and age >= 18`.
+// The supported comparisons are ==, !=, <, <=, >, >=, in [values], not in [values], in list "name",
+// matches "regex", starts_with, ends_with, contains, contains_any [values], contains_all [values] and
+// semver ">=2.1.0 <3.0.0". Also, always matches all entities. The in and string comparisons accept
+// ignore_case at the end. The entity id is available as the entity_id property.
+func CompileRule(rule string) (*model.Constraint, error) {
+	tokens, err := tokenizeRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	c := ruleCompiler{tokens: tokens}
+
+	result, err := c.rule()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := c.next(); t.kind != ruleEnd {
+		return nil, c.unexpected(t)
+	}
+
+	return result, nil
+}
+
+// nolint:funlen
+func tokenizeRule(rule string) ([]ruleToken, error) {
+	var tokens []ruleToken
+
+	runes := []rune(rule)
+
+	isWordRune := func(r rune, first bool) bool {
+		return r == '_' || unicode.IsLetter(r) || (!first && (unicode.IsDigit(r) || r == '.' || r == '-'))
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		column := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case isWordRune(r, true):
+			j := i + 1
+			for j < len(runes) && isWordRune(runes[j], false) {
+				j++
+			}
+
+			word := string(runes[i:j])
+			tokens = append(tokens, ruleToken{kind: ruleWord, text: word, value: word, column: column})
+			i = j
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+
+			number := string(runes[i:j])
+			if _, err := strconv.ParseFloat(number, 64); err != nil {
+				return nil, fmt.Errorf("%w: invalid number %s at column %d", ErrFailedToParseRule, number, column)
+			}
+
+			tokens = append(tokens, ruleToken{kind: ruleNumber, text: number, value: number, column: column})
+			i = j
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+
+			if j >= len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string at column %d", ErrFailedToParseRule, column)
+			}
+
+			text := string(runes[i : j+1])
+
+			value, err := strconv.Unquote(text)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid string %s at column %d", ErrFailedToParseRule, text, column)
+			}
+
+			tokens = append(tokens, ruleToken{kind: ruleString, text: text, value: value, column: column})
+			i = j + 1
+		default:
+			symbol := ""
+
+			for _, s := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ","} {
+				if strings.HasPrefix(string(runes[i:]), s) {
+					symbol = s
+					break
+				}
+			}
+
+			if symbol == "" {
+				return nil, fmt.Errorf("%w: invalid character %c at column %d", ErrFailedToParseRule, r, column)
+			}
+
+			tokens = append(tokens, ruleToken{kind: ruleSymbol, text: symbol, value: symbol, column: column})
+			i += len([]rune(symbol))
+		}
+	}
+
+	return append(tokens, ruleToken{kind: ruleEnd, column: len(runes) + 1}), nil
+}
+
+func (c *ruleCompiler) next() ruleToken {
+	return c.tokens[c.position]
+}
+
+func (c *ruleCompiler) advance() ruleToken {
+	t := c.tokens[c.position]
+
+	if t.kind != ruleEnd {
+		c.position++
+	}
+
+	return t
+}
+
+// accept advances if the next token is one of the given words or symbols.
+func (c *ruleCompiler) accept(values ...string) bool {
+	t := c.next()
+	if t.kind != ruleWord && t.kind != ruleSymbol {
+		return false
+	}
+
+	for _, value := range values {
+		if t.value == value {
+			c.position++
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *ruleCompiler) unexpected(t ruleToken) error {
+	if t.kind == ruleEnd {
+		return fmt.Errorf("%w: unexpected end of rule at column %d", ErrFailedToParseRule, t.column)
+	}
+
+	return fmt.Errorf("%w: unexpected token %s at column %d", ErrFailedToParseRule, t.text, t.column)
+}
+
+func (c *ruleCompiler) rule() (*model.Constraint, error) {
+	return c.binary(UnionConstraintName, []string{"or", "||"}, c.term)
+}
+
+func (c *ruleCompiler) term() (*model.Constraint, error) {
+	return c.binary(IntersectionConstraintName, []string{"and", "&&"}, c.factor)
+}
+
+func (c *ruleCompiler) binary(
+	name string, operators []string, operand func() (*model.Constraint, error),
+) (*model.Constraint, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	constraints := []model.Constraint{*first}
+
+	for c.accept(operators...) {
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		constraints = append(constraints, *next)
+	}
+
+	if len(constraints) == 1 {
+		return first, nil
+	}
+
+	if name == IntersectionConstraintName {
+		return newRuleConstraint(name, IntersectionConstraint{Constraints: constraints})
+	}
+
+	return newRuleConstraint(name, UnionConstraint{Constraints: constraints})
+}
+
+func (c *ruleCompiler) factor() (*model.Constraint, error) {
+	if c.accept("not", "!") {
+		operand, err := c.factor()
+		if err != nil {
+			return nil, err
+		}
+
+		return newRuleConstraint(NotConstraintName, NotConstraint{Constraint: *operand})
+	}
+
+	if c.accept("(") {
+		result, err := c.rule()
+		if err != nil {
+			return nil, err
+		}
+
+		if !c.accept(")") {
+			return nil, c.unexpected(c.next())
+		}
+
+		return result, nil
+	}
+
+	if c.accept("always") {
+		return newRuleConstraint(AlwaysConstraintName, struct{}{})
+	}
+
+	t := c.advance()
+	if t.kind != ruleWord {
+		return nil, c.unexpected(t)
+	}
+
+	property := t.value
+	if property == EntityIDProperty {
+		property = ""
+	}
+
+	return c.comparison(property)
+}
+
+// nolint:funlen,gocyclo
+func (c *ruleCompiler) comparison(property string) (*model.Constraint, error) {
+	operator := c.advance()
+
+	switch {
+	case operator.value == "==" || operator.value == "!=":
+		value, err := c.value()
+		if err != nil {
+			return nil, err
+		}
+
+		if operator.value == "==" {
+			return newRuleConstraint(
+				ContainsConstraintName, ContainsConstraint{Values: []string{value}, Property: property},
+			)
+		}
+
+		return newRuleConstraint(
+			ExcludesConstraintName, ExcludesConstraint{Values: []string{value}, Property: property},
+		)
+	case operator.value == "<" || operator.value == "<=" || operator.value == ">" || operator.value == ">=":
+		t := c.advance()
+		if t.kind != ruleNumber {
+			return nil, c.unexpected(t)
+		}
+
+		value, _ := strconv.ParseFloat(t.value, 64)
+
+		return newRuleConstraint(operator.value, LessThanConstraint{Value: value, Property: property})
+	case operator.kind == ruleWord && operator.value == "in":
+		if c.accept("list") {
+			t := c.advance()
+			if t.kind != ruleString {
+				return nil, c.unexpected(t)
+			}
+
+			return newRuleConstraint(ListConstraintName, ListConstraint{List: t.value, Property: property})
+		}
+
+		values, err := c.values()
+		if err != nil {
+			return nil, err
+		}
+
+		if c.accept(ruleIgnoreCase) {
+			return newRuleConstraint(
+				ContainsIgnoreCaseConstraintName, ContainsIgnoreCaseConstraint{Values: values, Property: property},
+			)
+		}
+
+		return newRuleConstraint(ContainsConstraintName, ContainsConstraint{Values: values, Property: property})
+	case operator.kind == ruleWord && operator.value == "not":
+		if !c.accept("in") {
+			return nil, c.unexpected(c.next())
+		}
+
+		values, err := c.values()
+		if err != nil {
+			return nil, err
+		}
+
+		return newRuleConstraint(ExcludesConstraintName, ExcludesConstraint{Values: values, Property: property})
+	case operator.kind == ruleWord && (operator.value == "matches" || operator.value == "semver"):
+		t := c.advance()
+		if t.kind != ruleString {
+			return nil, c.unexpected(t)
+		}
+
+		if operator.value == "matches" {
+			return newRuleConstraint(MatchConstraintName, MatchConstraint{Expresion: t.value, Property: property})
+		}
+
+		return newRuleConstraint(
+			SemverRangeConstraintName, SemverRangeConstraint{Expression: t.value, Property: property},
+		)
+	case operator.kind == ruleWord && ruleListOperators[operator.value] != "":
+		values, err := c.values()
+		if err != nil {
+			return nil, err
+		}
+
+		return newRuleConstraint(ruleListOperators[operator.value], listOperator{Values: values, Property: property})
+	case operator.kind == ruleWord && ruleStringOperators[operator.value] != "":
+		var values []string
+
+		if c.next().value == "[" {
+			v, err := c.values()
+			if err != nil {
+				return nil, err
+			}
+
+			values = v
+		} else {
+			t := c.advance()
+			if t.kind != ruleString {
+				return nil, c.unexpected(t)
+			}
+
+			values = []string{t.value}
+		}
+
+		parameters := stringOperator{Values: values, IgnoreCase: c.accept(ruleIgnoreCase), Property: property}
+
+		return newRuleConstraint(ruleStringOperators[operator.value], parameters)
+	}
+
+	return nil, c.unexpected(operator)
+}
+
+// value reads a string or a number value.
+func (c *ruleCompiler) value() (string, error) {
+	t := c.advance()
+	if t.kind != ruleString && t.kind != ruleNumber {
+		return "", c.unexpected(t)
+	}
+
+	return t.value, nil
+}
+
+// values reads a non-empty list of string or number values, e.g. ["IR", "DE"].
+func (c *ruleCompiler) values() ([]string, error) {
+	if !c.accept("[") {
+		return nil, c.unexpected(c.next())
+	}
+
+	var values []string
+
+	for {
+		value, err := c.value()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		if c.accept("]") {
+			return values, nil
+		}
+
+		if !c.accept(",") {
+			return nil, c.unexpected(c.next())
+		}
+	}
+}
+
+func newRuleConstraint(name string, parameters interface{}) (*model.Constraint, error) {
+	raw, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Constraint{Name: name, Parameters: raw}, nil
+}
+
+// nolint:gochecknoglobals
+var (
+	ruleStringOperators = map[string]string{
+		"starts_with": StartsWithConstraintName,
+		"ends_with":   EndsWithConstraintName,
+		"contains":    SubstringConstraintName,
+	}
+
+	ruleListOperators = map[string]string{
+		"contains_any": ContainsAnyConstraintName,
+		"contains_all": ContainsAllConstraintName,
+	}
+)
+
+const (
+	rulePrecedenceOr = iota + 1
+	rulePrecedenceAnd
+	rulePrecedenceFactor
+)
+
+// RenderRule renders the given constraint tree as a rule. It is the reverse of CompileRule, so it fails
+// for the constraints that rules do not support.
+func RenderRule(c model.Constraint) (string, error) {
+	return renderRule(c, rulePrecedenceOr)
+}
+
+// nolint:funlen,gocyclo
+func renderRule(c model.Constraint, precedence int) (string, error) {
+	switch c.Name {
+	case IntersectionConstraintName, UnionConstraintName:
+		p := IntersectionConstraint{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		operator, own := " and ", rulePrecedenceAnd
+		if c.Name == UnionConstraintName {
+			operator, own = " or ", rulePrecedenceOr
+		}
+
+		parts := make([]string, 0, len(p.Constraints))
+
+		for _, child := range p.Constraints {
+			part, err := renderRule(child, own+1)
+			if err != nil {
+				return "", err
+			}
+
+			parts = append(parts, part)
+		}
+
+		rule := strings.Join(parts, operator)
+		if precedence > own {
+			rule = "(" + rule + ")"
+		}
+
+		return rule, nil
+	case NotConstraintName:
+		p := NotConstraint{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		operand, err := renderRule(p.Constraint, rulePrecedenceFactor)
+		if err != nil {
+			return "", err
+		}
+
+		return "not " + operand, nil
+	case AlwaysConstraintName:
+		return "always", nil
+	case ContainsConstraintName, ExcludesConstraintName, ContainsIgnoreCaseConstraintName:
+		p := ContainsConstraint{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		switch {
+		case c.Name == ContainsIgnoreCaseConstraintName:
+			return renderComparison(p.Property, "in", renderValues(p.Values)+" "+ruleIgnoreCase)
+		case len(p.Values) == 1 && c.Name == ContainsConstraintName:
+			return renderComparison(p.Property, "==", strconv.Quote(p.Values[0]))
+		case len(p.Values) == 1:
+			return renderComparison(p.Property, "!=", strconv.Quote(p.Values[0]))
+		case c.Name == ContainsConstraintName:
+			return renderComparison(p.Property, "in", renderValues(p.Values))
+		default:
+			return renderComparison(p.Property, "not in", renderValues(p.Values))
+		}
+	case LessThanConstraintName, LessThanEqualConstraintName,
+		BiggerThanConstraintName, BiggerThanEqualConstraintName:
+		p := LessThanConstraint{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		return renderComparison(p.Property, c.Name, strconv.FormatFloat(p.Value, 'f', -1, 64))
+	case MatchConstraintName:
+		p := MatchConstraint{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		return renderComparison(p.Property, "matches", strconv.Quote(p.Expresion))
+	case SemverRangeConstraintName:
+		p := SemverRangeConstraint{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		return renderComparison(p.Property, "semver", strconv.Quote(p.Expression))
+	case ListConstraintName:
+		p := ListConstraint{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		return renderComparison(p.Property, "in list", strconv.Quote(p.List))
+	case ContainsAnyConstraintName, ContainsAllConstraintName:
+		p := listOperator{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		for operator, name := range ruleListOperators {
+			if name == c.Name {
+				return renderComparison(p.Property, operator, renderValues(p.Values))
+			}
+		}
+	case StartsWithConstraintName, EndsWithConstraintName, SubstringConstraintName:
+		p := stringOperator{}
+		if err := json.Unmarshal(c.Parameters, &p); err != nil {
+			return "", err
+		}
+
+		var operator string
+
+		for o, name := range ruleStringOperators {
+			if name == c.Name {
+				operator = o
+			}
+		}
+
+		value := renderValues(p.Values)
+		if len(p.Values) == 1 {
+			value = strconv.Quote(p.Values[0])
+		}
+
+		if p.IgnoreCase {
+			value += " " + ruleIgnoreCase
+		}
+
+		return renderComparison(p.Property, operator, value)
+	}
+
+	return "", fmt.Errorf("constraint %s can not be rendered as a rule", c.Name)
+}
+
+func renderComparison(property string, operator string, value string) (string, error) {
+	if property == "" {
+		property = EntityIDProperty
+	}
+
+	tokens, err := tokenizeRule(property)
+	if err != nil || len(tokens) != 2 || tokens[0].kind != ruleWord {
+		return "", fmt.Errorf("property %s can not be rendered as a rule", property)
+	}
+
+	return fmt.Sprintf("%s %s %s", property, operator, value), nil
+}
+
+func renderValues(values []string) string {
+	quoted := make([]string, 0, len(values))
+
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,182 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type RuleSuite struct {
+	suite.Suite
+}
+
+func (suite *RuleSuite) TestCompileRule() {
+	cases := []struct {
+		name        string
+		rule        string
+		evaluations []struct {
+			entity         model.Entity
+			resultExpected bool
+		}
+	}{
+		{
+			name: "successfully compile rule with and and in",
+			rule: `country in ["IR", "DE"] and age >= 18`,
+			evaluations: []struct {
+				entity         model.Entity
+				resultExpected bool
+			}{
+				{
+					entity: model.Entity{EntityContext: model.Context{
+						"country": model.StringValue("IR"),
+						"age":     model.NumberValue(20),
+					}},
+					resultExpected: true,
+				},
+				{
+					entity: model.Entity{EntityContext: model.Context{
+						"country": model.StringValue("IR"),
+						"age":     model.NumberValue(17),
+					}},
+					resultExpected: false,
+				},
+				{
+					entity: model.Entity{EntityContext: model.Context{
+						"country": model.StringValue("US"),
+						"age":     model.NumberValue(20),
+					}},
+					resultExpected: false,
+				},
+			},
+		},
+		{
+			name: "successfully compile rule with precedence and negation",
+			rule: `entity_id < 10 || !(os == "ios") && version semver ">=2.1.0"`,
+			evaluations: []struct {
+				entity         model.Entity
+				resultExpected bool
+			}{
+				{
+					entity:         model.Entity{EntityID: 5, EntityContext: model.Context{"os": model.StringValue("ios")}},
+					resultExpected: true,
+				},
+				{
+					entity: model.Entity{
+						EntityID: 15, EntityContext: model.Context{
+							"os":      model.StringValue("android"),
+							"version": model.StringValue("2.2.0"),
+						},
+					},
+					resultExpected: true,
+				},
+				{
+					entity: model.Entity{
+						EntityID: 15, EntityContext: model.Context{"os": model.StringValue("ios"), "version": model.StringValue("2.2.0")},
+					},
+					resultExpected: false,
+				},
+			},
+		},
+		{
+			name: "successfully compile rule with string operators",
+			rule: `email ends_with "@example.com" ignore_case and name not in ["admin"] and path matches "^/v1"`,
+			evaluations: []struct {
+				entity         model.Entity
+				resultExpected bool
+			}{
+				{
+					entity: model.Entity{
+						EntityContext: model.Context{
+							"email": model.StringValue("A@EXAMPLE.COM"),
+							"name":  model.StringValue("ali"),
+							"path":  model.StringValue("/v1/a"),
+						},
+					},
+					resultExpected: true,
+				},
+				{
+					entity: model.Entity{
+						EntityContext: model.Context{
+							"email": model.StringValue("a@example.com"),
+							"name":  model.StringValue("admin"),
+							"path":  model.StringValue("/v1/a"),
+						},
+					},
+					resultExpected: false,
+				},
+			},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			mc, err := constraint.CompileRule(tc.rule)
+			suite.NoError(err)
+
+			c, err := constraint.New(mc.Name, mc.Parameters)
+			suite.NoError(err)
+
+			for _, e := range tc.evaluations {
+				suite.Equal(e.resultExpected, c.Evaluate(e.entity), e.entity)
+			}
+		})
+	}
+}
+
+func (suite *RuleSuite) TestRenderRule() {
+	rules := []string{
+		`country in ["IR", "DE"] and age >= 18`,
+		`entity_id < 10 or not os == "ios" and version semver ">=2.1.0"`,
+		`(a == "1" or b != "2") and c in list "beta.users"`,
+		`name starts_with ["a", "b"] ignore_case or name contains "x" or always`,
+		`country in ["ir"] ignore_case and path matches "^/v1"`,
+		`roles contains_any ["admin", "editor"] and not roles contains_all ["guest"]`,
+		`device.os.name == "ios" and device.os.version semver ">=14.0.0"`,
+	}
+
+	for _, rule := range rules {
+		mc, err := constraint.CompileRule(rule)
+		suite.NoError(err, rule)
+
+		rendered, err := constraint.RenderRule(*mc)
+		suite.NoError(err, rule)
+		suite.Equal(rule, rendered)
+	}
+
+	_, err := constraint.RenderRule(model.Constraint{
+		Name:       constraint.RolloutConstraintName,
+		Parameters: json.RawMessage(`{"percentage": 10}`),
+	})
+	suite.Error(err)
+}
+
+func (suite *RuleSuite) TestRuleErrors() {
+	cases := []struct {
+		rule string
+		err  string
+	}{
+		{rule: `age >=`, err: "failed to parse rule: unexpected end of rule at column 7"},
+		{rule: `age >= "a"`, err: `failed to parse rule: unexpected token "a" at column 8`},
+		{rule: `country in ["IR" "DE"]`, err: `failed to parse rule: unexpected token "DE" at column 18`},
+		{rule: `(a == 1`, err: "failed to parse rule: unexpected end of rule at column 8"},
+		{rule: `a == 1 b == 2`, err: "failed to parse rule: unexpected token b at column 8"},
+		{rule: `a == "1`, err: "failed to parse rule: unterminated string at column 6"},
+		{rule: `a ~ 1`, err: "failed to parse rule: invalid character ~ at column 3"},
+	}
+
+	for _, tc := range cases {
+		_, err := constraint.CompileRule(tc.rule)
+		suite.Error(err, tc.rule)
+		suite.True(errors.Is(err, constraint.ErrFailedToParseRule), tc.rule)
+		suite.EqualError(err, tc.err, tc.rule)
+	}
+}
+
+func TestRuleSuite(t *testing.T) {
+	suite.Run(t, new(RuleSuite))
+}
@@ -0,0 +1,264 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	semverCoreLen     = 3
+	semverRangeOr     = "||"
+	semverPrefix      = "v"
+	semverPrerelease  = "-"
+	semverBuild       = "+"
+	semverSeparator   = "."
+	semverOperatorEq  = "="
+	semverOperatorLt  = "<"
+	semverOperatorLte = "<="
+	semverOperatorGt  = ">"
+	semverOperatorGte = ">="
+)
+
+// nolint:gochecknoglobals
+var (
+	semverIdentifierRegex = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+
+	// Longer operators should come first so that ">=" is not read as ">".
+	semverOperators = []string{
+		semverOperatorGte, semverOperatorLte, semverOperatorGt, semverOperatorLt, semverOperatorEq,
+	}
+
+	// ErrInvalidSemver represents an error that we return when we can not parse a semantic version.
+	ErrInvalidSemver = errors.New("invalid semantic version")
+)
+
+type (
+	// semver represents a semantic version (https://semver.org). Build metadata is ignored because
+	// it has no effect on the precedence of versions.
+	semver struct {
+		core       [semverCoreLen]int64
+		prerelease []string
+	}
+
+	// semverComparator represents a comparison of a version against a fixed version, e.g. ">=2.3.0".
+	semverComparator struct {
+		operator string
+		version  semver
+	}
+
+	// semverRange represents a set of comparator sets. A version is in the range if it satisfies all
+	// comparators of at least one set, e.g. ">=1.2.0 <2.0.0 || >=3.0.0".
+	semverRange [][]semverComparator
+)
+
+// parseSemver parses a semantic version. A leading "v" and missing minor or patch parts are accepted,
+// so "v2.10" is parsed as "2.10.0".
+func parseSemver(value string) (semver, error) {
+	v := semver{}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), semverPrefix)
+
+	if i := strings.Index(value, semverBuild); i >= 0 {
+		value = value[:i]
+	}
+
+	if i := strings.Index(value, semverPrerelease); i >= 0 {
+		for _, identifier := range strings.Split(value[i+1:], semverSeparator) {
+			if !semverIdentifierRegex.MatchString(identifier) {
+				return v, ErrInvalidSemver
+			}
+
+			v.prerelease = append(v.prerelease, identifier)
+		}
+
+		value = value[:i]
+	}
+
+	parts := strings.Split(value, semverSeparator)
+	if len(parts) > semverCoreLen {
+		return v, ErrInvalidSemver
+	}
+
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return v, ErrInvalidSemver
+		}
+
+		v.core[i] = int64(n)
+	}
+
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 if the version is less than, equal to or greater than the other version.
+func (v semver) compare(o semver) int {
+	for i := range v.core {
+		if v.core[i] != o.core[i] {
+			return compareInt(v.core[i], o.core[i])
+		}
+	}
+
+	// A version without prerelease has a higher precedence than the same version with prerelease.
+	switch {
+	case len(v.prerelease) == 0 && len(o.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(o.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(o.prerelease); i++ {
+		if r := compareIdentifier(v.prerelease[i], o.prerelease[i]); r != 0 {
+			return r
+		}
+	}
+
+	return compareInt(int64(len(v.prerelease)), int64(len(o.prerelease)))
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareIdentifier compares prerelease identifiers. Numeric identifiers are compared numerically and
+// have a lower precedence than alphanumeric ones, which are compared lexically.
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.ParseInt(a, 10, 64)
+	bn, bErr := strconv.ParseInt(b, 10, 64)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInt(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func (c semverComparator) satisfies(v semver) bool {
+	r := v.compare(c.version)
+
+	switch c.operator {
+	case semverOperatorLt:
+		return r < 0
+	case semverOperatorLte:
+		return r <= 0
+	case semverOperatorGt:
+		return r > 0
+	case semverOperatorGte:
+		return r >= 0
+	default:
+		return r == 0
+	}
+}
+
+// parseSemverRange parses a range expression. Comparators separated by spaces must all be satisfied
+// and comparator sets separated by "||" are alternatives, e.g. ">=2.3.0 <3.0.0 || 4.0.0".
+func parseSemverRange(expression string) (semverRange, error) {
+	var result semverRange
+
+	for _, set := range strings.Split(expression, semverRangeOr) {
+		var comparators []semverComparator
+
+		fields := strings.Fields(set)
+
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+
+			operator := semverOperatorEq
+
+			for _, op := range semverOperators {
+				if strings.HasPrefix(field, op) {
+					operator = op
+					field = strings.TrimPrefix(field, op)
+
+					break
+				}
+			}
+
+			// Allow a space between the operator and the version, e.g. ">= 2.3.0".
+			if field == "" && i+1 < len(fields) {
+				i++
+				field = fields[i]
+			}
+
+			v, err := parseSemver(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid semantic version range %s: %w", expression, err)
+			}
+
+			comparators = append(comparators, semverComparator{operator: operator, version: v})
+		}
+
+		if len(comparators) == 0 {
+			return nil, fmt.Errorf("invalid semantic version range %s", expression)
+		}
+
+		result = append(result, comparators)
+	}
+
+	return result, nil
+}
+
+func (r semverRange) contains(v semver) bool {
+	for _, set := range r {
+		satisfied := true
+
+		for _, c := range set {
+			if !c.satisfies(v) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateSemver(value interface{}) error {
+	_, err := parseSemver(value.(string))
+
+	return err
+}
+
+// semverProperty returns the property value of a semantic version constraint as a parsed version.
+func semverProperty(name string, path Path, e model.Entity) (semver, bool) {
+	value, ok := path.String(e)
+	if !ok {
+		return semver{}, false
+	}
+
+	v, err := parseSemver(value)
+	if err != nil {
+		logrus.Errorf(
+			"invalid property for %s constraint => property: %s, value: %s, err: %s",
+			name, path.Property(), value, err.Error(),
+		)
+
+		return semver{}, false
+	}
+
+	return v, true
+}
@@ -0,0 +1,60 @@
+package constraint
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// SemverBiggerThanEqualConstraint represents Openflag semantic version bigger than equal constraint.
+type SemverBiggerThanEqualConstraint struct {
+	path     Path
+	version  semver
+	Value    string `json:"value"`
+	Property string `json:"property,omitempty"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (s SemverBiggerThanEqualConstraint) Name() string {
+	return SemverBiggerThanEqualConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (s SemverBiggerThanEqualConstraint) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(
+			&s.Value,
+			validation.Required,
+			validation.By(validateSemver),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (s *SemverBiggerThanEqualConstraint) Initialize() error {
+	path, err := CompilePath(s.Property)
+	if err != nil {
+		return err
+	}
+
+	s.path = path
+
+	version, err := parseSemver(s.Value)
+	if err != nil {
+		return err
+	}
+
+	s.version = version
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (s SemverBiggerThanEqualConstraint) Evaluate(e model.Entity) bool {
+	version, ok := semverProperty(SemverBiggerThanEqualConstraintName, s.path, e)
+	if !ok {
+		return false
+	}
+
+	return version.compare(s.version) >= 0
+}
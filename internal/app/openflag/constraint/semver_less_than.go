@@ -0,0 +1,60 @@
+package constraint
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// SemverLessThanConstraint represents Openflag semantic version less than constraint.
+type SemverLessThanConstraint struct {
+	path     Path
+	version  semver
+	Value    string `json:"value"`
+	Property string `json:"property,omitempty"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (s SemverLessThanConstraint) Name() string {
+	return SemverLessThanConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (s SemverLessThanConstraint) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(
+			&s.Value,
+			validation.Required,
+			validation.By(validateSemver),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (s *SemverLessThanConstraint) Initialize() error {
+	path, err := CompilePath(s.Property)
+	if err != nil {
+		return err
+	}
+
+	s.path = path
+
+	version, err := parseSemver(s.Value)
+	if err != nil {
+		return err
+	}
+
+	s.version = version
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (s SemverLessThanConstraint) Evaluate(e model.Entity) bool {
+	version, ok := semverProperty(SemverLessThanConstraintName, s.path, e)
+	if !ok {
+		return false
+	}
+
+	return version.compare(s.version) < 0
+}
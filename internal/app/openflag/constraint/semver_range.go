@@ -0,0 +1,65 @@
+package constraint
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// SemverRangeConstraint represents Openflag semantic version range constraint.
+// The expression contains comparators like ">=2.3.0 <3.0.0" and alternatives separated by "||".
+type SemverRangeConstraint struct {
+	path         Path
+	versionRange semverRange
+	Expression   string `json:"expression"`
+	Property     string `json:"property,omitempty"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (s SemverRangeConstraint) Name() string {
+	return SemverRangeConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (s SemverRangeConstraint) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(
+			&s.Expression,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				_, err := parseSemverRange(value.(string))
+
+				return err
+			}),
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (s *SemverRangeConstraint) Initialize() error {
+	path, err := CompilePath(s.Property)
+	if err != nil {
+		return err
+	}
+
+	s.path = path
+
+	versionRange, err := parseSemverRange(s.Expression)
+	if err != nil {
+		return err
+	}
+
+	s.versionRange = versionRange
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (s SemverRangeConstraint) Evaluate(e model.Entity) bool {
+	version, ok := semverProperty(SemverRangeConstraintName, s.path, e)
+	if !ok {
+		return false
+	}
+
+	return s.versionRange.contains(version)
+}
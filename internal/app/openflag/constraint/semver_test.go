@@ -0,0 +1,182 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type SemverConstraintSuite struct {
+	ConstraintSuite
+}
+
+func versionEntity(version string) model.Entity {
+	return model.Entity{
+		EntityID:      1,
+		EntityContext: model.Context{"app_version": model.StringValue(version)},
+	}
+}
+
+func (suite *SemverConstraintSuite) TestSemverConstraint() {
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create equal constraint and evaluate",
+			Constraint: model.Constraint{
+				Name: constraint.SemverEqualConstraintName,
+				Parameters: json.RawMessage(
+					`{"value": "2.10.0", "property": "app_version"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: versionEntity("2.10.0"), ResultExpected: true},
+				{Entity: versionEntity("v2.10"), ResultExpected: true},
+				{Entity: versionEntity("2.10.0+build.5"), ResultExpected: true},
+				{Entity: versionEntity("2.10.0-beta"), ResultExpected: false},
+				{Entity: versionEntity("2.9.3"), ResultExpected: false},
+				{Entity: versionEntity("invalid"), ResultExpected: false},
+				{Entity: model.Entity{EntityID: 1}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create bigger than constraint and evaluate",
+			Constraint: model.Constraint{
+				Name: constraint.SemverBiggerThanConstraintName,
+				Parameters: json.RawMessage(
+					`{"value": "2.9.3", "property": "app_version"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: versionEntity("2.10.0"), ResultExpected: true},
+				{Entity: versionEntity("2.9.3"), ResultExpected: false},
+				{Entity: versionEntity("2.9.3-rc.1"), ResultExpected: false},
+				{Entity: versionEntity("2.9.2"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create bigger than equal constraint and evaluate",
+			Constraint: model.Constraint{
+				Name: constraint.SemverBiggerThanEqualConstraintName,
+				Parameters: json.RawMessage(
+					`{"value": "2.9.3", "property": "app_version"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: versionEntity("2.10.0"), ResultExpected: true},
+				{Entity: versionEntity("2.9.3"), ResultExpected: true},
+				{Entity: versionEntity("2.9.2"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create less than constraint and evaluate",
+			Constraint: model.Constraint{
+				Name: constraint.SemverLessThanConstraintName,
+				Parameters: json.RawMessage(
+					`{"value": "1.0.0", "property": "app_version"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: versionEntity("1.0.0-alpha"), ResultExpected: true},
+				{Entity: versionEntity("0.9.12"), ResultExpected: true},
+				{Entity: versionEntity("1.0.0"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create less than equal constraint and evaluate",
+			Constraint: model.Constraint{
+				Name: constraint.SemverLessThanEqualConstraintName,
+				Parameters: json.RawMessage(
+					`{"value": "1.0.0-beta.2", "property": "app_version"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: versionEntity("1.0.0-alpha"), ResultExpected: true},
+				{Entity: versionEntity("1.0.0-alpha.beta"), ResultExpected: true},
+				{Entity: versionEntity("1.0.0-beta"), ResultExpected: true},
+				{Entity: versionEntity("1.0.0-beta.2"), ResultExpected: true},
+				{Entity: versionEntity("1.0.0-beta.11"), ResultExpected: false},
+				{Entity: versionEntity("1.0.0-rc.1"), ResultExpected: false},
+				{Entity: versionEntity("1.0.0"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create range constraint and evaluate",
+			Constraint: model.Constraint{
+				Name: constraint.SemverRangeConstraintName,
+				Parameters: json.RawMessage(
+					`{"expression": ">=2.3.0 <3.0.0 || >= 4.1", "property": "app_version"}`,
+				),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: versionEntity("2.3.0"), ResultExpected: true},
+				{Entity: versionEntity("2.10.1"), ResultExpected: true},
+				{Entity: versionEntity("3.0.0-rc.1"), ResultExpected: true},
+				{Entity: versionEntity("3.0.0"), ResultExpected: false},
+				{Entity: versionEntity("2.2.9"), ResultExpected: false},
+				{Entity: versionEntity("4.1.0"), ResultExpected: true},
+			},
+		},
+		{
+			Name: "failed to create constraint with invalid version",
+			Constraint: model.Constraint{
+				Name: constraint.SemverEqualConstraintName,
+				Parameters: json.RawMessage(
+					`{"value": "2.x.0"}`,
+				),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with empty version",
+			Constraint: model.Constraint{
+				Name: constraint.SemverBiggerThanConstraintName,
+				Parameters: json.RawMessage(
+					`{"value": ""}`,
+				),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create constraint with invalid range",
+			Constraint: model.Constraint{
+				Name: constraint.SemverRangeConstraintName,
+				Parameters: json.RawMessage(
+					`{"expression": ">=2.3.0 || "}`,
+				),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func TestSemverConstraintSuite(t *testing.T) {
+	suite.Run(t, new(SemverConstraintSuite))
+}
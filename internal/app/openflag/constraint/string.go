@@ -0,0 +1,153 @@
+package constraint
+
+import (
+	"strings"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"golang.org/x/text/cases"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// stringOperator contains the shared parameters and logic of the string operator constraints.
+// The entity property matches when the operator returns true for at least one of the values.
+type stringOperator struct {
+	path       Path
+	values     []string
+	Values     []string `json:"values"`
+	IgnoreCase bool     `json:"ignore_case,omitempty"`
+	Property   string   `json:"property,omitempty"`
+}
+
+func (s stringOperator) validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(
+			&s.Values,
+			validation.Required,
+			validation.Length(minValueLen, 0),
+			validation.Each(validation.Required),
+		),
+	)
+}
+
+func (s *stringOperator) initialize() error {
+	path, err := CompilePath(s.Property)
+	if err != nil {
+		return err
+	}
+
+	s.path = path
+
+	s.values = make([]string, len(s.Values))
+
+	for i, value := range s.Values {
+		s.values[i] = s.normalize(value)
+	}
+
+	return nil
+}
+
+func (s stringOperator) evaluate(e model.Entity, operator func(property, value string) bool) bool {
+	property, ok := s.path.String(e)
+	if !ok {
+		return false
+	}
+
+	property = s.normalize(property)
+
+	for _, value := range s.values {
+		if operator(property, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize folds the case of the given value if the case is ignored. It uses full Unicode case folding
+// in the same way as the contains ignore case constraint, e.g. ß matches SS.
+func (s stringOperator) normalize(value string) string {
+	if s.IgnoreCase {
+		return cases.Fold().String(value)
+	}
+
+	return value
+}
+
+// StartsWithConstraint represents Openflag starts with constraint.
+// It matches when the property starts with one of the values.
+type StartsWithConstraint struct {
+	stringOperator
+}
+
+// Name is an implementation for the Constraint interface.
+func (s StartsWithConstraint) Name() string {
+	return StartsWithConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (s StartsWithConstraint) Validate() error {
+	return s.validate()
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (s *StartsWithConstraint) Initialize() error {
+	return s.initialize()
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (s StartsWithConstraint) Evaluate(e model.Entity) bool {
+	return s.evaluate(e, strings.HasPrefix)
+}
+
+// EndsWithConstraint represents Openflag ends with constraint.
+// It matches when the property ends with one of the values.
+type EndsWithConstraint struct {
+	stringOperator
+}
+
+// Name is an implementation for the Constraint interface.
+func (s EndsWithConstraint) Name() string {
+	return EndsWithConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (s EndsWithConstraint) Validate() error {
+	return s.validate()
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (s *EndsWithConstraint) Initialize() error {
+	return s.initialize()
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (s EndsWithConstraint) Evaluate(e model.Entity) bool {
+	return s.evaluate(e, strings.HasSuffix)
+}
+
+// SubstringConstraint represents Openflag substring constraint.
+// It matches when the property contains one of the values as a substring.
+type SubstringConstraint struct {
+	stringOperator
+}
+
+// Name is an implementation for the Constraint interface.
+func (s SubstringConstraint) Name() string {
+	return SubstringConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (s SubstringConstraint) Validate() error {
+	return s.validate()
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (s *SubstringConstraint) Initialize() error {
+	return s.initialize()
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (s SubstringConstraint) Evaluate(e model.Entity) bool {
+	return s.evaluate(e, strings.Contains)
+}
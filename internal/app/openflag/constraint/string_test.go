@@ -0,0 +1,196 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type StringConstraintSuite struct {
+	ConstraintSuite
+}
+
+func emailEntity(email string) model.Entity {
+	return model.Entity{
+		EntityID:      1,
+		EntityContext: model.Context{"email": model.StringValue(email)},
+	}
+}
+
+func (suite *StringConstraintSuite) TestStringConstraints() {
+	cases := []ConstraintTestCase{
+		{
+			Name: "successfully create starts with constraint and evaluate",
+			Constraint: model.Constraint{
+				Name:       constraint.StartsWithConstraintName,
+				Parameters: json.RawMessage(`{"values": ["admin", "ops"], "property": "email"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: emailEntity("admin@snapp.ir"), ResultExpected: true},
+				{Entity: emailEntity("ops.team@snapp.ir"), ResultExpected: true},
+				{Entity: emailEntity("Admin@snapp.ir"), ResultExpected: false},
+				{Entity: emailEntity("user@snapp.ir"), ResultExpected: false},
+				{Entity: model.Entity{EntityID: 1}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create starts with constraint and evaluate entity id",
+			Constraint: model.Constraint{
+				Name:       constraint.StartsWithConstraintName,
+				Parameters: json.RawMessage(`{"values": ["12"]}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: model.Entity{EntityID: 1234}, ResultExpected: true},
+				{Entity: model.Entity{EntityID: 2123}, ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create ends with constraint and evaluate",
+			Constraint: model.Constraint{
+				Name:       constraint.EndsWithConstraintName,
+				Parameters: json.RawMessage(`{"values": ["@snapp.ir"], "ignore_case": true, "property": "email"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: emailEntity("admin@snapp.ir"), ResultExpected: true},
+				{Entity: emailEntity("admin@SNAPP.IR"), ResultExpected: true},
+				{Entity: emailEntity("admin@snapp.com"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create ignore case string constraints and evaluate with case folding",
+			Constraint: model.Constraint{
+				Name:       constraint.EndsWithConstraintName,
+				Parameters: json.RawMessage(`{"values": ["STRASSE", "ΟΣ"], "ignore_case": true, "property": "name"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"name": model.StringValue("hauptstraße")}},
+					ResultExpected: true,
+				},
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"name": model.StringValue("λόγος")}},
+					ResultExpected: true,
+				},
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"name": model.StringValue("strase")}},
+					ResultExpected: false,
+				},
+			},
+		},
+		{
+			Name: "successfully create substring constraint and evaluate",
+			Constraint: model.Constraint{
+				Name:       constraint.SubstringConstraintName,
+				Parameters: json.RawMessage(`{"values": ["beta"], "property": "email"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{Entity: emailEntity("user+beta@snapp.ir"), ResultExpected: true},
+				{Entity: emailEntity("user+BETA@snapp.ir"), ResultExpected: false},
+				{Entity: emailEntity("user@snapp.ir"), ResultExpected: false},
+			},
+		},
+		{
+			Name: "successfully create contains ignore case constraint and evaluate",
+			Constraint: model.Constraint{
+				Name:       constraint.ContainsIgnoreCaseConstraintName,
+				Parameters: json.RawMessage(`{"values": ["IR", "de"], "property": "country"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"country": model.StringValue("ir")}},
+					ResultExpected: true,
+				},
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"country": model.StringValue("DE")}},
+					ResultExpected: true,
+				},
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"country": model.StringValue("iran")}},
+					ResultExpected: false,
+				},
+			},
+		},
+		{
+			Name: "successfully create contains ignore case constraint and evaluate with case folding",
+			Constraint: model.Constraint{
+				Name:       constraint.ContainsIgnoreCaseConstraintName,
+				Parameters: json.RawMessage(`{"values": ["straße", "ǅemal"], "property": "name"}`),
+			},
+			ErrExpected: false,
+			Evaluations: []struct {
+				Entity         model.Entity
+				ResultExpected bool
+			}{
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"name": model.StringValue("STRASSE")}},
+					ResultExpected: true,
+				},
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"name": model.StringValue("ǄEMAL")}},
+					ResultExpected: true,
+				},
+				{
+					Entity:         model.Entity{EntityID: 1, EntityContext: model.Context{"name": model.StringValue("strase")}},
+					ResultExpected: false,
+				},
+			},
+		},
+		{
+			Name: "failed to create starts with constraint without values",
+			Constraint: model.Constraint{
+				Name:       constraint.StartsWithConstraintName,
+				Parameters: json.RawMessage(`{"values": [], "property": "email"}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create substring constraint with empty value",
+			Constraint: model.Constraint{
+				Name:       constraint.SubstringConstraintName,
+				Parameters: json.RawMessage(`{"values": [""], "property": "email"}`),
+			},
+			ErrExpected: true,
+		},
+		{
+			Name: "failed to create contains ignore case constraint without values",
+			Constraint: model.Constraint{
+				Name:       constraint.ContainsIgnoreCaseConstraintName,
+				Parameters: json.RawMessage(`{"property": "country"}`),
+			},
+			ErrExpected: true,
+		},
+	}
+
+	suite.RunCases(cases)
+}
+
+func TestStringConstraintSuite(t *testing.T) {
+	suite.Run(t, new(StringConstraintSuite))
+}
@@ -41,6 +41,18 @@ func (u *UnionConstraint) Initialize() error {
 	return nil
 }
 
+// Children is an implementation for the ParentConstraint interface.
+func (u *UnionConstraint) Children() []Constraint {
+	return u.constraints
+}
+
+// SetFlag is an implementation for the FlagConstraint interface.
+func (u *UnionConstraint) SetFlag(flag string) {
+	for _, c := range u.constraints {
+		SetFlag(c, flag)
+	}
+}
+
 // Evaluate is an implementation for the Constraint interface.
 func (u UnionConstraint) Evaluate(e model.Entity) bool {
 	for _, c := range u.constraints {
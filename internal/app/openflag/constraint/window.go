@@ -0,0 +1,213 @@
+package constraint
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// maxWindowLookback is how far back the window constraint looks for the last start of a window. It covers
+	// the schedules that rarely start, e.g. on the 29th of February.
+	maxWindowLookback = 8 * 366 * 24 * time.Hour
+)
+
+// nolint:gochecknoglobals
+var (
+	locations sync.Map
+)
+
+// TimeWindowConstraint represents Openflag time window constraint.
+// It matches during the recurring windows that start at the times of the start cron expression and last for
+// the given duration (e.g. "8h") or until the next time of the end cron expression. The expressions are
+// evaluated in the given IANA timezone, which defaults to UTC. If the timezone property is set, the timezone
+// is read from the entity, so each entity is targeted in its local time. Entities without a valid timezone
+// in the property fall back to the timezone of the constraint.
+type TimeWindowConstraint struct {
+	path             Path
+	location         *time.Location
+	start            cron.Schedule
+	end              cron.Schedule
+	duration         time.Duration
+	starts           *sync.Map
+	Start            string `json:"start"`
+	End              string `json:"end,omitempty"`
+	Duration         string `json:"duration,omitempty"`
+	Timezone         string `json:"timezone,omitempty"`
+	TimezoneProperty string `json:"timezone_property,omitempty"`
+	Clock            Clock  `json:"-"`
+}
+
+// Name is an implementation for the Constraint interface.
+func (t TimeWindowConstraint) Name() string {
+	return TimeWindowConstraintName
+}
+
+// Validate is an implementation for the Constraint interface.
+func (t TimeWindowConstraint) Validate() error {
+	if (t.End == "") == (t.Duration == "") {
+		return errors.New("exactly one of end and duration should be set")
+	}
+
+	if t.Duration != "" && t.duration <= 0 {
+		return errors.New("duration should be positive")
+	}
+
+	return validation.ValidateStruct(&t,
+		validation.Field(
+			&t.Start,
+			validation.Required,
+		),
+	)
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (t *TimeWindowConstraint) Initialize() error {
+	path, err := CompilePath(t.TimezoneProperty)
+	if err != nil {
+		return err
+	}
+
+	t.path = path
+	t.starts = &sync.Map{}
+
+	if t.location, err = loadLocation(t.Timezone); err != nil {
+		return err
+	}
+
+	parser := cron.NewParser(parserFormat)
+
+	if t.Start != "" {
+		if t.start, err = parser.Parse(t.Start); err != nil {
+			return err
+		}
+	}
+
+	if t.End != "" {
+		if t.end, err = parser.Parse(t.End); err != nil {
+			return err
+		}
+	}
+
+	if t.Duration != "" {
+		if t.duration, err = time.ParseDuration(t.Duration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (t TimeWindowConstraint) Evaluate(e model.Entity) bool {
+	now := t.Clock.Now().In(t.entityLocation(e))
+
+	start, ok := t.previousStart(now)
+	if !ok {
+		return false
+	}
+
+	if t.end != nil {
+		return t.end.Next(start).After(now)
+	}
+
+	return now.Before(start.Add(t.duration))
+}
+
+// entityLocation returns the timezone of the entity or the timezone of the constraint if the entity does not
+// have a valid one.
+func (t TimeWindowConstraint) entityLocation(e model.Entity) *time.Location {
+	if t.TimezoneProperty == "" {
+		return t.location
+	}
+
+	timezone, ok := t.path.String(e)
+	if !ok {
+		metrics.reportMissingProperty(TimeWindowConstraintName, t.TimezoneProperty)
+		return t.location
+	}
+
+	location, ok := cachedLocation(timezone)
+	if !ok {
+		return t.location
+	}
+
+	return location
+}
+
+// cachedLocation loads the given IANA timezone once, because loading a timezone reads the timezone database.
+// The invalid timezones are cached as well.
+func cachedLocation(timezone string) (*time.Location, bool) {
+	if value, ok := locations.Load(timezone); ok {
+		location, ok := value.(*time.Location)
+		return location, ok
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		locations.Store(timezone, false)
+		return nil, false
+	}
+
+	locations.Store(timezone, location)
+
+	return location, true
+}
+
+// previousStart returns the last start of the window that is not after the given time. The last start is
+// cached for each timezone, so it is only looked up again when the next window starts.
+func (t TimeWindowConstraint) previousStart(now time.Time) (time.Time, bool) {
+	if t.starts != nil {
+		if value, ok := t.starts.Load(now.Location()); ok {
+			start := value.(time.Time)
+			if !start.After(now) && t.start.Next(start).After(now) {
+				return start, true
+			}
+		}
+	}
+
+	start, ok := previous(t.start, now)
+	if ok && t.starts != nil {
+		t.starts.Store(now.Location(), start)
+	}
+
+	return start, ok
+}
+
+// previous returns the last time of the schedule that is not after the given time. It looks back in a window
+// that doubles each time, so it finds the schedules that rarely happen without going through every minute.
+// Then it narrows the window down to a minute, which is the granularity of the schedules.
+func previous(schedule cron.Schedule, t time.Time) (time.Time, bool) {
+	for lookback := time.Minute; lookback <= maxWindowLookback; lookback *= 2 {
+		from := t.Add(-lookback)
+
+		last := schedule.Next(from)
+		if last.IsZero() {
+			return time.Time{}, false
+		}
+
+		if last.After(t) {
+			continue
+		}
+
+		// The next time of the schedule is not after t from any time before the last time of the schedule and
+		// it is after t from any time after that.
+		to := t
+		for to.Sub(from) > time.Minute {
+			middle := from.Add(to.Sub(from) / 2)
+			if schedule.Next(middle).After(t) {
+				to = middle
+			} else {
+				from = middle
+			}
+		}
+
+		return schedule.Next(from), true
+	}
+
+	return time.Time{}, false
+}
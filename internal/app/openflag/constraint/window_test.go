@@ -0,0 +1,158 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type TimeWindowConstraintSuite struct {
+	ConstraintSuite
+}
+
+type timeWindowEvaluation struct {
+	now            time.Time
+	entity         model.Entity
+	resultExpected bool
+}
+
+func (suite *TimeWindowConstraintSuite) TestTimeWindowConstraint() {
+	tehran := model.Entity{EntityID: 1, EntityContext: model.Context{"tz": model.StringValue("Asia/Tehran")}}
+	invalid := model.Entity{EntityID: 2, EntityContext: model.Context{"tz": model.StringValue("Mars/Olympus")}}
+	unknown := model.Entity{EntityID: 3}
+
+	cases := []struct {
+		name        string
+		parameters  string
+		evaluations []timeWindowEvaluation
+	}{
+		{
+			name:       "successfully evaluate weekday working hours with duration and timezone",
+			parameters: `{"start": "0 9 * * 1-5", "duration": "8h", "timezone": "Asia/Tehran"}`,
+			evaluations: []timeWindowEvaluation{
+				{now: time.Date(2020, 11, 2, 5, 29, 0, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2020, 11, 2, 5, 30, 0, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2020, 11, 2, 13, 29, 59, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2020, 11, 2, 13, 30, 0, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2020, 11, 7, 6, 0, 0, 0, time.UTC), resultExpected: false},
+			},
+		},
+		{
+			name:       "successfully evaluate overnight window with end expression",
+			parameters: `{"start": "0 22 * * *", "end": "0 6 * * *"}`,
+			evaluations: []timeWindowEvaluation{
+				{now: time.Date(2020, 11, 2, 21, 59, 0, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2020, 11, 2, 23, 0, 0, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2020, 11, 3, 5, 59, 0, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2020, 11, 3, 6, 0, 0, 0, time.UTC), resultExpected: false},
+			},
+		},
+		{
+			name:       "successfully evaluate window in the timezone of the entity",
+			parameters: `{"start": "0 9 * * *", "duration": "1h", "timezone_property": "tz"}`,
+			evaluations: []timeWindowEvaluation{
+				{now: time.Date(2020, 11, 2, 5, 45, 0, 0, time.UTC), entity: tehran, resultExpected: true},
+				{now: time.Date(2020, 11, 2, 5, 45, 0, 0, time.UTC), entity: unknown, resultExpected: false},
+				{now: time.Date(2020, 11, 2, 9, 30, 0, 0, time.UTC), entity: tehran, resultExpected: false},
+				{now: time.Date(2020, 11, 2, 9, 30, 0, 0, time.UTC), entity: unknown, resultExpected: true},
+				{now: time.Date(2020, 11, 2, 9, 30, 0, 0, time.UTC), entity: invalid, resultExpected: true},
+			},
+		},
+		{
+			name:       "successfully evaluate window that rarely starts",
+			parameters: `{"start": "0 0 29 2 *", "duration": "24h"}`,
+			evaluations: []timeWindowEvaluation{
+				{now: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2027, 2, 28, 12, 0, 0, 0, time.UTC), resultExpected: false},
+			},
+		},
+		{
+			name:       "successfully evaluate window that starts every minute of a day",
+			parameters: `{"start": "* * 1 1 *", "duration": "30s"}`,
+			evaluations: []timeWindowEvaluation{
+				{now: time.Date(2020, 12, 15, 12, 0, 0, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2021, 1, 1, 0, 0, 10, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2021, 1, 1, 12, 34, 20, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2021, 1, 1, 12, 34, 40, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2021, 1, 1, 23, 59, 20, 0, time.UTC), resultExpected: true},
+				{now: time.Date(2021, 1, 2, 0, 0, 20, 0, time.UTC), resultExpected: false},
+				{now: time.Date(2021, 1, 1, 12, 35, 20, 0, time.UTC), resultExpected: true},
+			},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+
+		suite.Run(tc.name, func() {
+			c, err := constraint.New(constraint.TimeWindowConstraintName, json.RawMessage(tc.parameters))
+			suite.NoError(err)
+
+			tw, ok := c.(*constraint.TimeWindowConstraint)
+			suite.True(ok)
+
+			for _, ev := range tc.evaluations {
+				now := ev.now
+				tw.Clock = func() time.Time { return now }
+
+				suite.Equal(ev.resultExpected, tw.Evaluate(ev.entity), now.String())
+			}
+		})
+	}
+}
+
+func (suite *TimeWindowConstraintSuite) TestTimeWindowConstraintValidation() {
+	cases := []ConstraintTestCase{}
+
+	for _, tc := range []struct {
+		name       string
+		parameters string
+	}{
+		{name: "failed to create constraint without start", parameters: `{"duration": "1h"}`},
+		{name: "failed to create constraint without end and duration", parameters: `{"start": "0 9 * * *"}`},
+		{
+			name:       "failed to create constraint with end and duration",
+			parameters: `{"start": "0 9 * * *", "end": "0 17 * * *", "duration": "8h"}`,
+		},
+		{
+			name:       "failed to create constraint with negative duration",
+			parameters: `{"start": "0 9 * * *", "duration": "-1h"}`,
+		},
+		{
+			name:       "failed to create constraint with invalid duration",
+			parameters: `{"start": "0 9 * * *", "duration": "1 day"}`,
+		},
+		{
+			name:       "failed to create constraint with invalid start",
+			parameters: `{"start": "0 9 * *", "duration": "1h"}`,
+		},
+		{
+			name:       "failed to create constraint with invalid end",
+			parameters: `{"start": "0 9 * * *", "end": "0 25 * * *"}`,
+		},
+		{
+			name:       "failed to create constraint with invalid timezone",
+			parameters: `{"start": "0 9 * * *", "duration": "1h", "timezone": "Mars/Olympus"}`,
+		},
+	} {
+		cases = append(cases, ConstraintTestCase{
+			Name: tc.name,
+			Constraint: model.Constraint{
+				Name:       constraint.TimeWindowConstraintName,
+				Parameters: json.RawMessage(tc.parameters),
+			},
+			ErrExpected: true,
+		})
+	}
+
+	suite.RunCases(cases)
+}
+
+func TestTimeWindowConstraintSuite(t *testing.T) {
+	suite.Run(t, new(TimeWindowConstraintSuite))
+}
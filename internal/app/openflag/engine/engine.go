@@ -3,6 +3,8 @@ package engine
 import (
 	"encoding/json"
 	"errors"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
@@ -14,6 +16,12 @@ import (
 
 const (
 	cacheKey = "flags"
+
+	variantsHashSalt = "variants"
+	totalWeight      = 100
+
+	layerHashSalt = "layer"
+	layerSlices   = 100
 )
 
 type (
@@ -23,41 +31,118 @@ type (
 		Variant model.Variant `json:"variant"`
 	}
 
+	// Explanation represents how a flag is evaluated for an entity. It keeps the trace of each segment that is
+	// tried in order and the index of the segment that won. Excluded is true when the entity is held out or
+	// is outside the layer slice of the flag, so no segment is tried. Assigned is true when the variant comes
+	// from an earlier sticky assignment of the entity, which may differ from the variant of the segment that won.
+	Explanation struct {
+		Flag     string               `json:"flag"`
+		Excluded bool                 `json:"excluded,omitempty"`
+		Segments []SegmentExplanation `json:"segments"`
+		Segment  *int                 `json:"segment,omitempty"`
+		Variant  *model.Variant       `json:"variant,omitempty"`
+		Assigned bool                 `json:"assigned,omitempty"`
+	}
+
+	// SegmentExplanation represents the evaluation of a segment with the trace of its constraint tree.
+	SegmentExplanation struct {
+		Description string           `json:"description"`
+		Constraint  constraint.Trace `json:"constraint"`
+	}
+
 	// Result represents evaluation result for an entity.
+	// Explanations are only set when the evaluation is explained.
 	Result struct {
-		Entity      model.Entity `json:"entity"`
-		Evaluations []Evaluation `json:"evaluations"`
-		Timestamp   time.Time    `json:"timestamp"`
+		Entity       model.Entity  `json:"entity"`
+		Evaluations  []Evaluation  `json:"evaluations"`
+		Explanations []Explanation `json:"explanations,omitempty"`
+		Timestamp    time.Time     `json:"timestamp"`
 	}
 
 	flagSegment struct {
-		variant    model.Variant
-		constraint constraint.Constraint
+		description string
+		variant     model.Variant
+		variants    []model.WeightedVariant
+		thresholds  []int64
+		constraint  constraint.Constraint
+		caps        []constraint.FrequencyCapConstraint
 	}
 
 	flagItem struct {
 		segments []flagSegment
+		lists    []constraint.ListConstraint
+		caps     []constraint.FrequencyCapConstraint
+		layer    *layerSlice
+		holdouts []layerSlice
+		sticky   bool
+		variants map[string]model.Variant
+		aged     bool
+	}
+
+	// layerSlice represents the slices of a layer between the lower and upper bounds.
+	layerSlice struct {
+		layer string
+		path  constraint.Path
+		lower int64
+		upper int64
+	}
+
+	// evaluation keeps the state of a single evaluation call, so that each flag is evaluated only once
+	// for the entity even if it is a prerequisite of other flags. The explanations are only kept when
+	// the evaluation is explained.
+	evaluation struct {
+		flagMap          map[string]flagItem
+		results          map[string]*model.Variant
+		inProgress       map[string]bool
+		listRepo         model.ListRepo
+		members          map[model.ListLookup]bool
+		frequencyCapRepo model.FrequencyCapRepo
+		counts           map[string]int64
+		assignmentRepo   model.AssignmentRepo
+		assignments      map[model.AssignmentLookup]string
+		entityRepo       model.EntityRepo
+		seenLoaded       bool
+		firstSeen        *time.Time
+		lastSeen         *time.Time
+		explanations     map[string]*Explanation
 	}
 )
 
 // Engine represents an engine interface for the evaluation of an entity.
 type Engine interface {
 	Evaluate(flags []string, entity model.Entity) (*Result, error)
+	Explain(flags []string, entity model.Entity) (*Result, error)
 }
 
 // EvaluationEngine represents an engine for evaluation of an entity.
 type EvaluationEngine struct {
-	Logger   Logger
-	FlagRepo model.FlagRepo
-	cache    *cache.Cache
+	Logger           Logger
+	FlagRepo         model.FlagRepo
+	AudienceRepo     model.AudienceRepo
+	LayerRepo        model.LayerRepo
+	ListRepo         model.ListRepo
+	FrequencyCapRepo model.FrequencyCapRepo
+	AssignmentRepo   model.AssignmentRepo
+	EntityRepo       model.EntityRepo
+	cache            *cache.Cache
 }
 
 // New creates a new evaluation engine.
-func New(logger Logger, flagRepo model.FlagRepo) *EvaluationEngine {
+func New(
+	logger Logger, flagRepo model.FlagRepo, audienceRepo model.AudienceRepo, layerRepo model.LayerRepo,
+	listRepo model.ListRepo, frequencyCapRepo model.FrequencyCapRepo, assignmentRepo model.AssignmentRepo,
+	entityRepo model.EntityRepo,
+) *EvaluationEngine {
 	return &EvaluationEngine{
-		Logger:   logger,
-		FlagRepo: flagRepo,
-		cache:    cache.New(cache.NoExpiration, cache.NoExpiration),
+		Logger:           logger,
+		FlagRepo:         flagRepo,
+		AudienceRepo:     audienceRepo,
+		LayerRepo:        layerRepo,
+		ListRepo:         listRepo,
+		FrequencyCapRepo: frequencyCapRepo,
+		AssignmentRepo:   assignmentRepo,
+		EntityRepo:       entityRepo,
+		cache:            cache.New(cache.NoExpiration, cache.NoExpiration),
 	}
 }
 
@@ -72,12 +157,50 @@ func (e *EvaluationEngine) Fetch() (finalErr error) {
 		return err
 	}
 
+	audiences, err := e.fetchAudiences()
+	if err != nil {
+		return err
+	}
+
+	layers, holdouts, err := e.fetchLayers()
+	if err != nil {
+		return err
+	}
+
 	parse := constraint.Parser{}
 
+	cyclicFlags := findCyclicFlags(dbFlags)
+
 	flagMap := map[string]flagItem{}
 
 	for _, dbFlag := range dbFlags {
-		flagItem := flagItem{}
+		if cycle, ok := cyclicFlags[dbFlag.Flag]; ok {
+			logrus.Errorf(
+				"failed to prepare flag %s with id %d because of prerequisite cycle between %v",
+				dbFlag.Flag, dbFlag.ID, cycle,
+			)
+
+			continue
+		}
+
+		flagItem := flagItem{sticky: dbFlag.Sticky, holdouts: holdouts}
+
+		if dbFlag.Layer != nil {
+			layer, ok := layers[*dbFlag.Layer]
+			if !ok {
+				logrus.Errorf(
+					"failed to prepare flag %s with id %d because its layer %s is not found or is a holdout",
+					dbFlag.Flag, dbFlag.ID, *dbFlag.Layer,
+				)
+
+				continue
+			}
+
+			layer.lower = int64(dbFlag.LayerLowerBound)
+			layer.upper = int64(dbFlag.LayerUpperBound)
+
+			flagItem.layer = &layer
+		}
 
 		var segments []model.Segment
 
@@ -91,7 +214,17 @@ func (e *EvaluationEngine) Fetch() (finalErr error) {
 		}
 
 		for _, segment := range segments {
-			pco, err := parse.Parse(segment.Expression, segment.Constraints)
+			constraints, err := constraint.ResolveAudiences(segment.Constraints, audiences)
+			if err != nil {
+				logrus.Errorf(
+					"failed to resolve flag segment audiences for flag %s with id %d: %s",
+					dbFlag.Flag, dbFlag.ID, err.Error(),
+				)
+
+				continue
+			}
+
+			pco, err := parse.Parse(segment.Expression, constraints)
 			if err != nil {
 				logrus.Errorf(
 					"failed to parse flag segment expression for flag %s with id %d: %s",
@@ -111,10 +244,16 @@ func (e *EvaluationEngine) Fetch() (finalErr error) {
 				continue
 			}
 
-			flagItem.segments = append(flagItem.segments, flagSegment{
-				variant:    segment.Variant,
-				constraint: co,
-			})
+			constraint.SetFlag(co, dbFlag.Flag)
+
+			flagItem.segments = append(flagItem.segments, newFlagSegment(segment, co))
+			flagItem.lists = append(flagItem.lists, constraint.ListConstraints(co)...)
+			flagItem.caps = append(flagItem.caps, constraint.FrequencyCapConstraints(co)...)
+			flagItem.aged = flagItem.aged || len(constraint.EntityAgeConstraints(co)) > 0
+
+			if flagItem.sticky {
+				flagItem.addVariants(segment)
+			}
 		}
 
 		flagMap[dbFlag.Flag] = flagItem
@@ -125,6 +264,199 @@ func (e *EvaluationEngine) Fetch() (finalErr error) {
 	return nil
 }
 
+// findCyclicFlags finds the flags that are part of a prerequisite cycle. It maps each of them to its cycle.
+func findCyclicFlags(dbFlags []model.Flag) map[string][]string {
+	dependencies := map[string][]string{}
+
+	for _, dbFlag := range dbFlags {
+		var segments []model.Segment
+
+		if err := json.Unmarshal([]byte(dbFlag.Segments), &segments); err != nil {
+			continue
+		}
+
+		for _, segment := range segments {
+			dependencies[dbFlag.Flag] = append(
+				dependencies[dbFlag.Flag], constraint.PrerequisiteFlags(segment.Constraints)...,
+			)
+		}
+	}
+
+	cyclicFlags := map[string][]string{}
+
+	for _, cycle := range constraint.FindPrerequisiteCycles(dependencies) {
+		for _, flag := range cycle {
+			cyclicFlags[flag] = cycle
+		}
+	}
+
+	return cyclicFlags
+}
+
+// fetchAudiences fetches all audiences from the database and parses them into constraint trees,
+// so that segments can use them without any extra lookup in evaluation.
+func (e *EvaluationEngine) fetchAudiences() (map[string]model.Constraint, error) {
+	dbAudiences, err := e.AudienceRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	parse := constraint.Parser{}
+
+	audiences := map[string]model.Constraint{}
+
+	for _, dbAudience := range dbAudiences {
+		var constraints map[string]model.Constraint
+
+		if err := json.Unmarshal([]byte(dbAudience.Constraints), &constraints); err != nil {
+			logrus.Errorf(
+				"failed to unmarshal db audience %s with id %d into go struct: %s",
+				dbAudience.Name, dbAudience.ID, err.Error(),
+			)
+
+			continue
+		}
+
+		pco, err := parse.Parse(dbAudience.Expression, constraints)
+		if err != nil {
+			logrus.Errorf(
+				"failed to parse audience expression for audience %s with id %d: %s",
+				dbAudience.Name, dbAudience.ID, err.Error(),
+			)
+
+			continue
+		}
+
+		audiences[dbAudience.Name] = *pco
+	}
+
+	return audiences, nil
+}
+
+// fetchLayers fetches all layers from the database. It returns the layers that flags can be assigned to
+// by their names and the held out slices of the holdout layers.
+func (e *EvaluationEngine) fetchLayers() (map[string]layerSlice, []layerSlice, error) {
+	dbLayers, err := e.LayerRepo.FindAll()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layers := map[string]layerSlice{}
+
+	var holdouts []layerSlice
+
+	for _, dbLayer := range dbLayers {
+		path, err := constraint.CompilePath(dbLayer.Property)
+		if err != nil {
+			logrus.Errorf(
+				"failed to compile property of layer %s with id %d: %s", dbLayer.Name, dbLayer.ID, err.Error(),
+			)
+
+			continue
+		}
+
+		layer := layerSlice{layer: dbLayer.Name, path: path}
+
+		if dbLayer.Holdout == 0 {
+			layers[dbLayer.Name] = layer
+			continue
+		}
+
+		layer.upper = int64(dbLayer.Holdout) - 1
+
+		holdouts = append(holdouts, layer)
+	}
+
+	return layers, holdouts, nil
+}
+
+// contains checks whether the slice of the given entity in the layer is between the bounds. Entities without
+// the property of the layer are not in any slice.
+func (l layerSlice) contains(entity model.Entity) bool {
+	property, ok := l.path.String(entity)
+	if !ok {
+		return false
+	}
+
+	slice := constraint.Bucket(layerHashSalt, l.layer, property) * layerSlices / constraint.BucketSize
+
+	return slice >= l.lower && slice <= l.upper
+}
+
+// admits checks the layer membership of the given entity. The entities in a holdout are not evaluated for any
+// flag and the entities outside the layer slice of the flag are not evaluated for it.
+func (f flagItem) admits(entity model.Entity) bool {
+	for _, holdout := range f.holdouts {
+		if holdout.contains(entity) {
+			return false
+		}
+	}
+
+	if f.layer == nil {
+		return true
+	}
+
+	return f.layer.contains(entity)
+}
+
+// addVariants keeps the variants of the given segment by their keys, so that the sticky assignments are served
+// with the current attachments of their variants.
+func (f *flagItem) addVariants(segment model.Segment) {
+	if f.variants == nil {
+		f.variants = map[string]model.Variant{}
+	}
+
+	if segment.Variant.VariantKey != "" {
+		f.variants[segment.Variant.VariantKey] = segment.Variant
+	}
+
+	for _, v := range segment.Variants {
+		f.variants[v.VariantKey] = v.Variant
+	}
+}
+
+func newFlagSegment(segment model.Segment, co constraint.Constraint) flagSegment {
+	fs := flagSegment{
+		description: segment.Description,
+		variant:     segment.Variant,
+		variants:    segment.Variants,
+		constraint:  co,
+	}
+
+	for _, c := range constraint.FrequencyCapConstraints(co) {
+		if !c.CheckOnly {
+			fs.caps = append(fs.caps, c)
+		}
+	}
+
+	var sum int64
+
+	for _, v := range segment.Variants {
+		sum += int64(v.Weight)
+		fs.thresholds = append(fs.thresholds, sum*constraint.BucketSize/totalWeight)
+	}
+
+	return fs
+}
+
+// pick returns the variant of the segment for the given entity. If the segment has weighted variants,
+// it assigns one of them deterministically using a hash of the flag key and the entity id.
+func (f flagSegment) pick(flag string, entity model.Entity) model.Variant {
+	if len(f.variants) == 0 {
+		return f.variant
+	}
+
+	bucket := constraint.Bucket(flag, variantsHashSalt, strconv.FormatInt(entity.EntityID, 10))
+
+	for i, threshold := range f.thresholds {
+		if bucket < threshold {
+			return f.variants[i].Variant
+		}
+	}
+
+	return f.variants[len(f.variants)-1].Variant
+}
+
 // Start starts fetching flags from database in periods using given cron pattern.
 func (e *EvaluationEngine) Start(cronPattern string) error {
 	c := cron.New()
@@ -149,6 +481,20 @@ func (e *EvaluationEngine) Evaluate(flags []string, entity model.Entity) (_ *Res
 
 	defer func() { metrics.report("evaluate", startTime, finalErr) }()
 
+	return e.evaluate(flags, entity, false)
+}
+
+// Explain evaluates the given entity and explains the evaluation of each flag, i.e. the segments that are tried,
+// the result of each constraint in their trees, the properties that are read and the segment that won.
+func (e *EvaluationEngine) Explain(flags []string, entity model.Entity) (_ *Result, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report("explain", startTime, finalErr) }()
+
+	return e.evaluate(flags, entity, true)
+}
+
+func (e *EvaluationEngine) evaluate(flags []string, entity model.Entity, explain bool) (*Result, error) {
 	value, ok := e.cache.Get(cacheKey)
 	if !ok {
 		return nil, errors.New("failed to load flags from cache")
@@ -170,23 +516,48 @@ func (e *EvaluationEngine) Evaluate(flags []string, entity model.Entity) (_ *Res
 		}
 	}
 
+	ev := &evaluation{
+		flagMap:          flagMap,
+		results:          map[string]*model.Variant{},
+		inProgress:       map[string]bool{},
+		listRepo:         e.ListRepo,
+		members:          map[model.ListLookup]bool{},
+		frequencyCapRepo: e.FrequencyCapRepo,
+		counts:           map[string]int64{},
+		assignmentRepo:   e.AssignmentRepo,
+		assignments:      map[model.AssignmentLookup]string{},
+		entityRepo:       e.EntityRepo,
+	}
+
+	if explain {
+		ev.explanations = map[string]*Explanation{}
+	}
+
+	entity = ev.fetchSeen(flags, entity)
+	result.Entity = entity
+
+	ev.fetchMembers(flags, entity)
+	ev.fetchCounts(flags, entity)
+	ev.fetchAssignments(flags, entity)
+
+	scopedEntity := entity.WithScope(ev)
+
 	for _, flag := range flags {
-		f, ok := flagMap[flag]
-		if !ok {
+		if _, ok := flagMap[flag]; !ok {
 			logrus.Warnf("failed to find flag %s in our flags for evaluation", flag)
 
 			continue
 		}
 
-		for _, segment := range f.segments {
-			if segment.constraint.Evaluate(entity) {
-				result.Evaluations = append(result.Evaluations, Evaluation{
-					Flag:    flag,
-					Variant: segment.variant,
-				})
+		if variant, ok := ev.EvaluateFlag(flag, scopedEntity); ok {
+			result.Evaluations = append(result.Evaluations, Evaluation{
+				Flag:    flag,
+				Variant: variant,
+			})
+		}
 
-				break
-			}
+		if explanation, ok := ev.explanations[flag]; ok {
+			result.Explanations = append(result.Explanations, *explanation)
 		}
 	}
 
@@ -194,3 +565,374 @@ func (e *EvaluationEngine) Evaluate(flags []string, entity model.Entity) (_ *Res
 
 	return &result, nil
 }
+
+// EvaluateFlag is an implementation for the constraint.FlagEvaluator interface.
+// It returns false for unknown flags and for flags that are already being evaluated, which can only happen
+// with a prerequisite cycle.
+func (ev *evaluation) EvaluateFlag(flag string, entity model.Entity) (model.Variant, bool) {
+	if variant, ok := ev.results[flag]; ok {
+		if variant == nil {
+			return model.Variant{}, false
+		}
+
+		return *variant, true
+	}
+
+	f, ok := ev.flagMap[flag]
+	if !ok || ev.inProgress[flag] {
+		return model.Variant{}, false
+	}
+
+	ev.inProgress[flag] = true
+
+	var result *model.Variant
+
+	if ev.explanations != nil {
+		result = ev.explainFlag(flag, f, entity)
+	} else if f.admits(entity) {
+		result = ev.pickVariant(flag, f, entity)
+	}
+
+	delete(ev.inProgress, flag)
+
+	ev.results[flag] = result
+
+	if result == nil {
+		return model.Variant{}, false
+	}
+
+	return *result, true
+}
+
+// pickVariant returns the variant of the given flag for an entity that is admitted to the flag. The entities with
+// a sticky assignment keep their variant, even if they move to another segment or do not match any segment.
+// The saved variants are served without counting exposures, because sticky flags can not have frequency caps.
+func (ev *evaluation) pickVariant(flag string, f flagItem, entity model.Entity) *model.Variant {
+	if f.sticky {
+		if variant, ok := ev.savedVariant(flag, f, entity); ok {
+			return &variant
+		}
+	}
+
+	for _, segment := range f.segments {
+		if segment.constraint.Evaluate(entity) && ev.expose(segment, entity) {
+			variant := segment.pick(flag, entity)
+
+			if f.sticky {
+				variant = ev.assign(flag, f, entity, variant)
+			}
+
+			return &variant
+		}
+	}
+
+	return nil
+}
+
+// explainFlag evaluates the given flag in the same way as EvaluateFlag and keeps the explanation of it. It does not
+// count the exposures of the frequency caps or assign the entity in a sticky flag, so explaining an evaluation does
+// not change the later evaluations.
+func (ev *evaluation) explainFlag(flag string, f flagItem, entity model.Entity) *model.Variant {
+	explanation := &Explanation{Flag: flag, Segments: []SegmentExplanation{}}
+
+	ev.explanations[flag] = explanation
+
+	if !f.admits(entity) {
+		explanation.Excluded = true
+		return nil
+	}
+
+	for i, segment := range f.segments {
+		trace := constraint.Explain(segment.constraint, entity)
+
+		explanation.Segments = append(explanation.Segments, SegmentExplanation{
+			Description: segment.description,
+			Constraint:  trace,
+		})
+
+		// The frequency caps are checked by the constraints, but explaining does not count an exposure.
+		if *trace.Result {
+			index := i
+			variant := segment.pick(flag, entity)
+
+			explanation.Segment = &index
+			explanation.Variant = &variant
+
+			break
+		}
+	}
+
+	if f.sticky {
+		if variant, ok := ev.savedVariant(flag, f, entity); ok {
+			explanation.Variant = &variant
+			explanation.Assigned = true
+		}
+	}
+
+	return explanation.Variant
+}
+
+// fetchSeen loads the first seen and the last seen timestamps of the entity if any of the given flags has an
+// entity age constraint and the entity is not loaded with its stored context, so that entity age constraints
+// work without the stored contexts.
+func (ev *evaluation) fetchSeen(flags []string, entity model.Entity) model.Entity {
+	if entity.FirstSeen != nil || entity.LastSeen != nil {
+		return entity
+	}
+
+	for _, flag := range flags {
+		if ev.flagMap[flag].aged {
+			entity.FirstSeen, entity.LastSeen = ev.Seen(entity)
+			break
+		}
+	}
+
+	return entity
+}
+
+// Seen is an implementation for the constraint.EntitySeenChecker interface.
+// The timestamps are loaded once in each evaluation, e.g. for the entity age constraints of prerequisite flags.
+func (ev *evaluation) Seen(entity model.Entity) (*time.Time, *time.Time) {
+	if ev.seenLoaded {
+		return ev.firstSeen, ev.lastSeen
+	}
+
+	ev.seenLoaded = true
+
+	entities, err := ev.entityRepo.FindSeen([]model.Entity{entity})
+	if err != nil {
+		logrus.Errorf("failed to load entity seen timestamps: %s", err.Error())
+		return nil, nil
+	}
+
+	ev.firstSeen, ev.lastSeen = entities[0].FirstSeen, entities[0].LastSeen
+
+	return ev.firstSeen, ev.lastSeen
+}
+
+// fetchMembers checks all list memberships that the given flags need for the entity using a single
+// pipelined lookup, so that list constraints do not hit Redis one by one.
+func (ev *evaluation) fetchMembers(flags []string, entity model.Entity) {
+	var lookups []model.ListLookup
+
+	seen := map[model.ListLookup]bool{}
+
+	for _, flag := range flags {
+		for _, l := range ev.flagMap[flag].lists {
+			lookup, ok := l.Lookup(entity)
+			if !ok || seen[lookup] {
+				continue
+			}
+
+			seen[lookup] = true
+
+			lookups = append(lookups, lookup)
+		}
+	}
+
+	if len(lookups) == 0 {
+		return
+	}
+
+	members, err := ev.listRepo.Contains(lookups)
+	if err != nil {
+		logrus.Errorf("failed to check list memberships: %s", err.Error())
+		return
+	}
+
+	for i, lookup := range lookups {
+		ev.members[lookup] = members[i]
+	}
+}
+
+// IsMember is an implementation for the constraint.ListChecker interface.
+// Lookups that are not fetched before the evaluation, e.g. the lists of prerequisite flags, are checked one by one.
+func (ev *evaluation) IsMember(list string, value string) bool {
+	lookup := model.ListLookup{List: list, Value: value}
+
+	if member, ok := ev.members[lookup]; ok {
+		return member
+	}
+
+	members, err := ev.listRepo.Contains([]model.ListLookup{lookup})
+	if err != nil {
+		logrus.Errorf("failed to check list membership of %s in %s: %s", value, list, err.Error())
+		return false
+	}
+
+	ev.members[lookup] = members[0]
+
+	return members[0]
+}
+
+// fetchCounts reads all exposure counters that the given flags need for the entity at once, so that frequency
+// cap constraints do not hit Redis one by one.
+func (ev *evaluation) fetchCounts(flags []string, entity model.Entity) {
+	var counters []string
+
+	seen := map[string]bool{}
+
+	for _, flag := range flags {
+		for _, c := range ev.flagMap[flag].caps {
+			fc, ok := c.Cap(entity)
+			if !ok || seen[fc.Counter] {
+				continue
+			}
+
+			seen[fc.Counter] = true
+
+			counters = append(counters, fc.Counter)
+		}
+	}
+
+	if len(counters) == 0 {
+		return
+	}
+
+	counts, err := ev.frequencyCapRepo.Counts(counters)
+	if err != nil {
+		logrus.Errorf("failed to read exposure counters: %s", err.Error())
+		return
+	}
+
+	for i, counter := range counters {
+		ev.counts[counter] = counts[i]
+	}
+}
+
+// ExposureCount is an implementation for the constraint.FrequencyCapChecker interface.
+// Counters that are not fetched before the evaluation, e.g. the counters of prerequisite flags, are read one by one.
+// If a counter can not be read, it is reported as exhausted, so that the cap is not exceeded.
+func (ev *evaluation) ExposureCount(counter string) int64 {
+	if count, ok := ev.counts[counter]; ok {
+		return count
+	}
+
+	counts, err := ev.frequencyCapRepo.Counts([]string{counter})
+	if err != nil {
+		logrus.Errorf("failed to read exposure counter %s: %s", counter, err.Error())
+		return math.MaxInt64
+	}
+
+	ev.counts[counter] = counts[0]
+
+	return counts[0]
+}
+
+// expose counts an exposure for each frequency cap of the segment that won. The counters are checked and
+// increased atomically, so if a concurrent evaluation reaches a limit first, the segment does not win and
+// the next segments are tried.
+func (ev *evaluation) expose(segment flagSegment, entity model.Entity) bool {
+	var caps []model.FrequencyCap
+
+	seen := map[string]bool{}
+
+	for _, c := range segment.caps {
+		if fc, ok := c.Cap(entity); ok && !seen[fc.Counter] {
+			seen[fc.Counter] = true
+
+			caps = append(caps, fc)
+		}
+	}
+
+	if len(caps) == 0 {
+		return true
+	}
+
+	exposed, err := ev.frequencyCapRepo.Expose(caps)
+	if err != nil {
+		logrus.Errorf("failed to count exposure: %s", err.Error())
+		return false
+	}
+
+	if !exposed {
+		return false
+	}
+
+	for _, fc := range caps {
+		ev.counts[fc.Counter]++
+	}
+
+	return true
+}
+
+// fetchAssignments finds the sticky assignments of the entity in the given flags using a single pipelined
+// lookup, so that sticky flags do not hit Redis one by one.
+func (ev *evaluation) fetchAssignments(flags []string, entity model.Entity) {
+	var lookups []model.AssignmentLookup
+
+	for _, flag := range flags {
+		if ev.flagMap[flag].sticky {
+			lookups = append(lookups, assignmentLookup(flag, entity))
+		}
+	}
+
+	if len(lookups) == 0 {
+		return
+	}
+
+	variants, err := ev.assignmentRepo.Find(lookups)
+	if err != nil {
+		logrus.Errorf("failed to find sticky assignments: %s", err.Error())
+		return
+	}
+
+	for i, lookup := range lookups {
+		ev.assignments[lookup] = variants[i]
+	}
+}
+
+// savedVariant returns the variant that the entity is assigned to in the given sticky flag, if the variant is
+// still in the flag. The assignments of the prerequisite flags that are not fetched before the evaluation are
+// found one by one.
+func (ev *evaluation) savedVariant(flag string, f flagItem, entity model.Entity) (model.Variant, bool) {
+	lookup := assignmentLookup(flag, entity)
+
+	assigned, ok := ev.assignments[lookup]
+	if !ok {
+		variants, err := ev.assignmentRepo.Find([]model.AssignmentLookup{lookup})
+		if err != nil {
+			logrus.Errorf("failed to find sticky assignment of flag %s: %s", flag, err.Error())
+			return model.Variant{}, false
+		}
+
+		assigned = variants[0]
+		ev.assignments[lookup] = assigned
+	}
+
+	variant, ok := f.variants[assigned]
+
+	return variant, ok
+}
+
+// assign assigns the entity to the variant of the segment that won in the given sticky flag. An assignment to
+// a variant that is removed from the flag is replaced. If a concurrent evaluation assigns the entity first,
+// the variant of that assignment is returned.
+func (ev *evaluation) assign(flag string, f flagItem, entity model.Entity, variant model.Variant) model.Variant {
+	lookup := assignmentLookup(flag, entity)
+
+	if ev.assignments[lookup] != "" {
+		if err := ev.assignmentRepo.ResetEntity(lookup); err != nil {
+			logrus.Errorf("failed to reset stale sticky assignment of flag %s: %s", flag, err.Error())
+			return variant
+		}
+	}
+
+	assigned, err := ev.assignmentRepo.Assign(lookup, variant.VariantKey)
+	if err != nil {
+		logrus.Errorf("failed to save sticky assignment of flag %s: %s", flag, err.Error())
+		return variant
+	}
+
+	ev.assignments[lookup] = assigned
+
+	if v, ok := f.variants[assigned]; ok {
+		return v
+	}
+
+	return variant
+}
+
+func assignmentLookup(flag string, entity model.Entity) model.AssignmentLookup {
+	return model.AssignmentLookup{Flag: flag, EntityType: entity.EntityType, EntityID: entity.EntityID}
+}
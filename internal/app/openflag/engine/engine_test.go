@@ -2,7 +2,9 @@ package engine_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/engine"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
@@ -110,6 +112,481 @@ func (f *fakeFlagRepo) FindAll() ([]model.Flag, error) {
 	}, nil
 }
 
+type fakeWeightedFlagRepo struct {
+	model.FlagRepo
+}
+
+func (f *fakeWeightedFlagRepo) FindAll() ([]model.Flag, error) {
+	return []model.Flag{
+		{
+			ID:   12,
+			Flag: "flag3",
+			Segments: `
+			[
+				{
+					"description": "segment 1",
+					"constraints": {
+						"A": {
+							"name": "always",
+							"parameters": {}
+						}
+					},
+					"expression": "A",
+					"variants": [
+						{
+							"variant_key": "control",
+							"weight": 50
+						},
+						{
+							"variant_key": "treatment1",
+							"weight": 30
+						},
+						{
+							"variant_key": "treatment2",
+							"weight": 20
+						}
+					]
+				}
+			]
+		`,
+		},
+	}, nil
+}
+
+type fakeAudienceFlagRepo struct {
+	model.FlagRepo
+}
+
+func (f *fakeAudienceFlagRepo) FindAll() ([]model.Flag, error) {
+	return []model.Flag{
+		{
+			ID:   13,
+			Flag: "flag4",
+			Segments: `
+			[
+				{
+					"description": "segment 1",
+					"constraints": {
+						"A": {
+							"name": "audience",
+							"parameters": {
+								"audience": "unknown"
+							}
+						}
+					},
+					"expression": "A",
+					"variant": {
+						"variant_key": "on1"
+					}
+				},
+				{
+					"description": "segment 2",
+					"constraints": {
+						"A": {
+							"name": "audience",
+							"parameters": {
+								"audience": "employees"
+							}
+						},
+						"B": {
+							"name": "<",
+							"parameters": {
+								"value": 10
+							}
+						}
+					},
+					"expression": "A ∩ B",
+					"variant": {
+						"variant_key": "on2"
+					}
+				}
+			]
+		`,
+		},
+	}, nil
+}
+
+type fakePrerequisiteFlagRepo struct {
+	model.FlagRepo
+}
+
+func (f *fakePrerequisiteFlagRepo) FindAll() ([]model.Flag, error) {
+	prerequisite := func(flag, variant, key string) string {
+		return fmt.Sprintf(`[
+			{
+				"description": "segment 1",
+				"constraints": {
+					"A": {
+						"name": "prerequisite",
+						"parameters": {
+							"flag": "%s",
+							"variants": ["%s"]
+						}
+					}
+				},
+				"expression": "A",
+				"variant": {
+					"variant_key": "%s"
+				}
+			}
+		]`, flag, variant, key)
+	}
+
+	return []model.Flag{
+		{
+			ID:   14,
+			Flag: "payment.v2",
+			Segments: `
+			[
+				{
+					"description": "segment 1",
+					"constraints": {
+						"A": {
+							"name": "random",
+							"parameters": {}
+						}
+					},
+					"expression": "A",
+					"variant": {
+						"variant_key": "on"
+					}
+				}
+			]
+		`,
+		},
+		{ID: 15, Flag: "checkout", Segments: prerequisite("payment.v2", "on", "new")},
+		{ID: 16, Flag: "cycle1", Segments: prerequisite("cycle2", "on", "on")},
+		{ID: 17, Flag: "cycle2", Segments: prerequisite("cycle1", "on", "on")},
+		{ID: 18, Flag: "self", Segments: prerequisite("self", "on", "on")},
+		{ID: 19, Flag: "missing", Segments: prerequisite("unknown", "on", "on")},
+	}, nil
+}
+
+type fakeAudienceRepo struct {
+	model.AudienceRepo
+	repoError bool
+}
+
+func (f *fakeAudienceRepo) FindAll() ([]model.Audience, error) {
+	if f.repoError {
+		return nil, errors.New("fake audience repo error")
+	}
+
+	return []model.Audience{
+		{
+			ID:          1,
+			Name:        "employees",
+			Description: "employees",
+			Constraints: `{"A": {"name": "contains", "parameters": {"values": ["7", "8", "17"]}}}`,
+			Expression:  "A",
+		},
+	}, nil
+}
+
+type fakeListRepo struct {
+	model.ListRepo
+	lists map[string][]string
+	calls int
+}
+
+func (f *fakeListRepo) Contains(lookups []model.ListLookup) ([]bool, error) {
+	f.calls++
+
+	members := make([]bool, len(lookups))
+
+	for i, lookup := range lookups {
+		for _, item := range f.lists[lookup.List] {
+			if item == lookup.Value {
+				members[i] = true
+			}
+		}
+	}
+
+	return members, nil
+}
+
+type fakeListFlagRepo struct {
+	model.FlagRepo
+}
+
+func (f *fakeListFlagRepo) FindAll() ([]model.Flag, error) {
+	list := func(constraints string) string {
+		return fmt.Sprintf(`[
+			{
+				"description": "segment 1",
+				"constraints": %s,
+				"expression": "A",
+				"variant": {
+					"variant_key": "on"
+				}
+			}
+		]`, constraints)
+	}
+
+	return []model.Flag{
+		{
+			ID:       1,
+			Flag:     "beta",
+			Segments: list(`{"A": {"name": "list", "parameters": {"list": "beta.users"}}}`),
+		},
+		{
+			ID:   2,
+			Flag: "local",
+			Segments: list(`{"A": {"name": "∩", "parameters": {"constraints": [
+				{"name": "list", "parameters": {"list": "beta.users"}},
+				{"name": "!", "parameters": {"constraint": {
+					"name": "list", "parameters": {"list": "countries", "property": "country"}
+				}}}
+			]}}}`),
+		},
+	}, nil
+}
+
+type fakeFrequencyCapRepo struct {
+	counters map[string]int64
+	reject   bool
+	calls    int
+}
+
+func (f *fakeFrequencyCapRepo) Counts(counters []string) ([]int64, error) {
+	f.calls++
+
+	counts := make([]int64, len(counters))
+
+	for i, counter := range counters {
+		counts[i] = f.counters[counter]
+	}
+
+	return counts, nil
+}
+
+func (f *fakeFrequencyCapRepo) Expose(caps []model.FrequencyCap) (bool, error) {
+	if f.reject {
+		return false, nil
+	}
+
+	for _, c := range caps {
+		if f.counters[c.Counter] >= c.Limit {
+			return false, nil
+		}
+	}
+
+	for _, c := range caps {
+		f.counters[c.Counter]++
+	}
+
+	return true, nil
+}
+
+type fakeFrequencyCapFlagRepo struct {
+	model.FlagRepo
+}
+
+func (f *fakeFrequencyCapFlagRepo) FindAll() ([]model.Flag, error) {
+	return []model.Flag{
+		{
+			ID:   1,
+			Flag: "promo",
+			Segments: `[
+				{
+					"description": "capped",
+					"constraints": {"A": {"name": "frequency_cap", "parameters": {"limit": 2, "window": "24h"}}},
+					"expression": "A",
+					"variant": {"variant_key": "capped"}
+				},
+				{
+					"description": "fallback",
+					"constraints": {"A": {"name": "always", "parameters": {}}},
+					"expression": "A",
+					"variant": {"variant_key": "fallback"}
+				}
+			]`,
+		},
+		{
+			ID:   2,
+			Flag: "banner",
+			Segments: `[
+				{
+					"description": "segment 1",
+					"constraints": {"A": {"name": "frequency_cap", "parameters": {
+						"limit": 2, "window": "24h", "key": "promo", "check_only": true
+					}}},
+					"expression": "A",
+					"variant": {"variant_key": "on"}
+				}
+			]`,
+		},
+	}, nil
+}
+
+type fakeAssignmentRepo struct {
+	assignments map[model.AssignmentLookup]string
+	calls       int
+}
+
+func (f *fakeAssignmentRepo) Find(lookups []model.AssignmentLookup) ([]string, error) {
+	f.calls++
+
+	variants := make([]string, len(lookups))
+
+	for i, lookup := range lookups {
+		variants[i] = f.assignments[lookup]
+	}
+
+	return variants, nil
+}
+
+func (f *fakeAssignmentRepo) Assign(lookup model.AssignmentLookup, variant string) (string, error) {
+	if _, ok := f.assignments[lookup]; !ok {
+		f.assignments[lookup] = variant
+	}
+
+	return f.assignments[lookup], nil
+}
+
+func (f *fakeAssignmentRepo) Reset(flag string) error {
+	for lookup := range f.assignments {
+		if lookup.Flag == flag {
+			delete(f.assignments, lookup)
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeAssignmentRepo) ResetEntity(lookup model.AssignmentLookup) error {
+	delete(f.assignments, lookup)
+
+	return nil
+}
+
+type fakeStickyFlagRepo struct {
+	model.FlagRepo
+	variants  string
+	unmatched bool
+}
+
+func (f *fakeStickyFlagRepo) FindAll() ([]model.Flag, error) {
+	expression := "A"
+	if f.unmatched {
+		expression = "!A"
+	}
+
+	segments := fmt.Sprintf(`[
+		{
+			"description": "segment 1",
+			"constraints": {"A": {"name": "always", "parameters": {}}},
+			"expression": "%s",
+			"variants": %s
+		}
+	]`, expression, f.variants)
+
+	return []model.Flag{
+		{ID: 1, Flag: "experiment", Segments: segments, Sticky: true},
+		{ID: 2, Flag: "rollout", Segments: segments},
+	}, nil
+}
+
+type fakeLayerRepo struct {
+	model.LayerRepo
+	withHoldout bool
+}
+
+func (f *fakeLayerRepo) FindAll() ([]model.Layer, error) {
+	layers := []model.Layer{
+		{ID: 1, Name: "checkout", Description: "checkout experiments"},
+		{ID: 2, Name: "pricing", Description: "pricing experiments", Property: "company_id"},
+	}
+
+	if f.withHoldout {
+		layers = append(layers, model.Layer{ID: 3, Name: "holdout", Description: "global holdout", Holdout: 5})
+	}
+
+	return layers, nil
+}
+
+type fakeLayerFlagRepo struct {
+	model.FlagRepo
+}
+
+func (f *fakeLayerFlagRepo) FindAll() ([]model.Flag, error) {
+	segments := `[
+		{
+			"description": "segment 1",
+			"constraints": {"A": {"name": "always", "parameters": {}}},
+			"expression": "A",
+			"variant": {
+				"variant_key": "on"
+			}
+		}
+	]`
+
+	layered := func(id int64, flag, layer string, lower, upper int) model.Flag {
+		return model.Flag{
+			ID: id, Flag: flag, Segments: segments, Layer: &layer, LayerLowerBound: lower, LayerUpperBound: upper,
+		}
+	}
+
+	return []model.Flag{
+		layered(1, "checkout.a", "checkout", 0, 49),
+		layered(2, "checkout.b", "checkout", 50, 99),
+		layered(3, "pricing", "pricing", 0, 49),
+		layered(4, "unknown", "unknown", 0, 99),
+		layered(5, "held", "holdout", 0, 99),
+		{ID: 6, Flag: "global", Segments: segments},
+	}, nil
+}
+
+type fakeEntityRepo struct {
+	model.EntityRepo
+	seen  map[int64]time.Time
+	calls int
+}
+
+func (f *fakeEntityRepo) FindSeen(entities []model.Entity) ([]model.Entity, error) {
+	f.calls++
+
+	for i, entity := range entities {
+		if seen, ok := f.seen[entity.EntityID]; ok {
+			entities[i].FirstSeen, entities[i].LastSeen = &seen, &seen
+		}
+	}
+
+	return entities, nil
+}
+
+type fakeEntityAgeFlagRepo struct {
+	model.FlagRepo
+}
+
+func (f *fakeEntityAgeFlagRepo) FindAll() ([]model.Flag, error) {
+	segments := func(constraint string, key string) string {
+		return fmt.Sprintf(`[
+			{
+				"description": "segment 1",
+				"constraints": {"A": %s},
+				"expression": "A",
+				"variant": {
+					"variant_key": "%s"
+				}
+			}
+		]`, constraint, key)
+	}
+
+	return []model.Flag{
+		{
+			ID:       1,
+			Flag:     "new.users",
+			Segments: segments(`{"name": "entity_age", "parameters": {"max_age": "24h"}}`, "on"),
+		},
+		{
+			ID:       2,
+			Flag:     "welcome",
+			Segments: segments(`{"name": "prerequisite", "parameters": {"flag": "new.users", "variants": ["on"]}}`, "on"),
+		},
+	}, nil
+}
+
 type EngineSuite struct {
 	suite.Suite
 }
@@ -222,7 +699,10 @@ func (suite *EngineSuite) TestEngine() {
 
 			flagRepo.repoError = tc.repoError
 
-			eng := engine.New(logger, flagRepo)
+			eng := engine.New(
+				logger, flagRepo, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{}, &fakeFrequencyCapRepo{},
+				&fakeAssignmentRepo{}, &fakeEntityRepo{},
+			)
 
 			err := eng.Fetch()
 			if tc.repoError {
@@ -244,6 +724,437 @@ func (suite *EngineSuite) TestEngine() {
 	}
 }
 
+func (suite *EngineSuite) TestEngineWeightedVariants() {
+	const entities = 10000
+
+	eng := engine.New(
+		&fakeLogger{}, &fakeWeightedFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{},
+		&fakeFrequencyCapRepo{}, &fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	counts := map[string]int{}
+
+	for i := int64(1); i <= entities; i++ {
+		result, err := eng.Evaluate([]string{"flag3"}, model.Entity{EntityID: i})
+		suite.NoError(err)
+		suite.Len(result.Evaluations, 1)
+
+		variant := result.Evaluations[0].Variant.VariantKey
+		counts[variant]++
+
+		again, err := eng.Evaluate([]string{"flag3"}, model.Entity{EntityID: i})
+		suite.NoError(err)
+		suite.Equal(variant, again.Evaluations[0].Variant.VariantKey)
+	}
+
+	suite.InDelta(entities*0.5, counts["control"], entities*0.03)
+	suite.InDelta(entities*0.3, counts["treatment1"], entities*0.03)
+	suite.InDelta(entities*0.2, counts["treatment2"], entities*0.03)
+}
+
+func (suite *EngineSuite) TestEngineAudiences() {
+	eng := engine.New(
+		&fakeLogger{}, &fakeAudienceFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{},
+		&fakeFrequencyCapRepo{}, &fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	for _, tc := range []struct {
+		entityID int64
+		variants []string
+	}{
+		{entityID: 7, variants: []string{"on2"}},
+		{entityID: 8, variants: []string{"on2"}},
+		{entityID: 17, variants: []string{}},
+		{entityID: 9, variants: []string{}},
+	} {
+		result, err := eng.Evaluate([]string{"flag4"}, model.Entity{EntityID: tc.entityID})
+		suite.NoError(err)
+
+		variants := []string{}
+
+		for _, evaluation := range result.Evaluations {
+			variants = append(variants, evaluation.Variant.VariantKey)
+		}
+
+		suite.Equal(tc.variants, variants)
+	}
+
+	eng = engine.New(
+		&fakeLogger{}, &fakeAudienceFlagRepo{}, &fakeAudienceRepo{repoError: true}, &fakeLayerRepo{}, &fakeListRepo{},
+		&fakeFrequencyCapRepo{}, &fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.Error(eng.Fetch())
+}
+
+func (suite *EngineSuite) TestEnginePrerequisites() {
+	eng := engine.New(
+		&fakeLogger{}, &fakePrerequisiteFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{},
+		&fakeFrequencyCapRepo{}, &fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	for i := int64(1); i <= 100; i++ {
+		result, err := eng.Evaluate(
+			[]string{"checkout", "payment.v2", "cycle1", "cycle2", "self", "missing"}, model.Entity{EntityID: i},
+		)
+		suite.NoError(err)
+
+		variants := map[string]string{}
+
+		for _, evaluation := range result.Evaluations {
+			variants[evaluation.Flag] = evaluation.Variant.VariantKey
+		}
+
+		// The random prerequisite is evaluated once, so both flags should agree with each other.
+		if variants["payment.v2"] == "on" {
+			suite.Equal(map[string]string{"payment.v2": "on", "checkout": "new"}, variants)
+		} else {
+			suite.Empty(variants)
+		}
+	}
+}
+
+func (suite *EngineSuite) TestEngineLists() {
+	listRepo := &fakeListRepo{lists: map[string][]string{"beta.users": {"1", "2"}, "countries": {"IR"}}}
+
+	eng := engine.New(
+		&fakeLogger{}, &fakeListFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, listRepo, &fakeFrequencyCapRepo{},
+		&fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	for _, tc := range []struct {
+		entity   model.Entity
+		variants map[string]string
+	}{
+		{
+			entity:   model.Entity{EntityID: 1, EntityContext: model.Context{"country": model.StringValue("DE")}},
+			variants: map[string]string{"beta": "on", "local": "on"},
+		},
+		{
+			entity:   model.Entity{EntityID: 2, EntityContext: model.Context{"country": model.StringValue("IR")}},
+			variants: map[string]string{"beta": "on"},
+		},
+		{
+			entity:   model.Entity{EntityID: 3, EntityContext: model.Context{"country": model.StringValue("DE")}},
+			variants: map[string]string{},
+		},
+	} {
+		listRepo.calls = 0
+
+		result, err := eng.Evaluate([]string{"beta", "local"}, tc.entity)
+		suite.NoError(err)
+
+		variants := map[string]string{}
+
+		for _, evaluation := range result.Evaluations {
+			variants[evaluation.Flag] = evaluation.Variant.VariantKey
+		}
+
+		suite.Equal(tc.variants, variants)
+		suite.Equal(1, listRepo.calls)
+	}
+}
+
+func (suite *EngineSuite) TestEngineLayers() {
+	const entities = 10000
+
+	eng := engine.New(
+		&fakeLogger{}, &fakeLayerFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{withHoldout: true}, &fakeListRepo{},
+		&fakeFrequencyCapRepo{}, &fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	counts := map[string]int{}
+
+	for i := int64(1); i <= entities; i++ {
+		result, err := eng.Evaluate(nil, model.Entity{EntityID: i})
+		suite.NoError(err)
+
+		variants := map[string]string{}
+
+		for _, evaluation := range result.Evaluations {
+			variants[evaluation.Flag] = evaluation.Variant.VariantKey
+			counts[evaluation.Flag]++
+		}
+
+		suite.False(variants["checkout.a"] != "" && variants["checkout.b"] != "", "layer flags should be exclusive")
+		suite.Empty(variants["pricing"], "entities without the layer property should not be in the layer")
+
+		if variants["checkout.a"] == "" && variants["checkout.b"] == "" {
+			counts["held out"]++
+
+			suite.Empty(variants["global"], "flags without a layer should also be held out")
+		} else {
+			suite.Equal("on", variants["global"])
+		}
+	}
+
+	suite.Equal(entities-counts["held out"], counts["global"])
+	suite.Zero(counts["unknown"])
+	suite.Zero(counts["held"])
+	suite.InDelta(entities*0.05, counts["held out"], entities*0.01)
+	suite.InDelta(entities*0.475, counts["checkout.a"], entities*0.02)
+	suite.InDelta(entities*0.475, counts["checkout.b"], entities*0.02)
+
+	eng = engine.New(
+		&fakeLogger{}, &fakeLayerFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{},
+		&fakeFrequencyCapRepo{}, &fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	companies := 0
+
+	for company := 1; company <= 1000; company++ {
+		companyID := model.NumberValue(float64(company))
+
+		first, err := eng.Evaluate([]string{"pricing"}, model.Entity{
+			EntityID: 1, EntityContext: model.Context{"company_id": companyID},
+		})
+		suite.NoError(err)
+
+		second, err := eng.Evaluate([]string{"pricing"}, model.Entity{
+			EntityID: 2, EntityContext: model.Context{"company_id": companyID},
+		})
+		suite.NoError(err)
+
+		suite.Equal(first.Evaluations, second.Evaluations, "entities of a company should share the layer slice")
+
+		companies += len(first.Evaluations)
+	}
+
+	suite.InDelta(500, companies, 60)
+}
+
+func (suite *EngineSuite) TestEngineExplain() {
+	eng := engine.New(
+		&fakeLogger{}, &fakeFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{}, &fakeFrequencyCapRepo{},
+		&fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	entity := model.Entity{EntityID: 17}
+
+	evaluated, err := eng.Evaluate([]string{"flag1", "flag2"}, entity)
+	suite.NoError(err)
+	suite.Empty(evaluated.Explanations)
+
+	explained, err := eng.Explain([]string{"flag1", "flag2"}, entity)
+	suite.NoError(err)
+	suite.Equal(evaluated.Evaluations, explained.Evaluations)
+	suite.Len(explained.Explanations, 2)
+
+	flag1 := explained.Explanations[0]
+	suite.Equal("flag1", flag1.Flag)
+	suite.False(flag1.Excluded)
+	suite.Len(flag1.Segments, 2)
+	suite.Equal("segment 1", flag1.Segments[0].Description)
+	suite.False(*flag1.Segments[0].Constraint.Result)
+	suite.True(*flag1.Segments[1].Constraint.Result)
+	suite.Equal(1, *flag1.Segment)
+	suite.Equal("on2", flag1.Variant.VariantKey)
+
+	flag2 := explained.Explanations[1]
+	suite.Equal("flag2", flag2.Flag)
+	suite.Len(flag2.Segments, 1)
+	suite.Equal(0, *flag2.Segment)
+	suite.Equal("on3", flag2.Variant.VariantKey)
+
+	eng = engine.New(
+		&fakeLogger{}, &fakeLayerFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{}, &fakeFrequencyCapRepo{},
+		&fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	explained, err = eng.Explain([]string{"pricing"}, entity)
+	suite.NoError(err)
+	suite.Empty(explained.Evaluations)
+	suite.Len(explained.Explanations, 1)
+	suite.True(explained.Explanations[0].Excluded)
+	suite.Empty(explained.Explanations[0].Segments)
+	suite.Nil(explained.Explanations[0].Segment)
+}
+
+func (suite *EngineSuite) TestEngineFrequencyCaps() {
+	frequencyCapRepo := &fakeFrequencyCapRepo{counters: map[string]int64{}}
+
+	eng := engine.New(
+		&fakeLogger{}, &fakeFrequencyCapFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{}, frequencyCapRepo,
+		&fakeAssignmentRepo{}, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	evaluate := func(flag string, entityID int64) string {
+		result, err := eng.Evaluate([]string{flag}, model.Entity{EntityID: entityID, EntityType: "user"})
+		suite.NoError(err)
+
+		if len(result.Evaluations) == 0 {
+			return ""
+		}
+
+		return result.Evaluations[0].Variant.VariantKey
+	}
+
+	suite.Equal("on", evaluate("banner", 1))
+	suite.Equal(int64(0), frequencyCapRepo.counters["promo:user:1"])
+
+	suite.Equal("capped", evaluate("promo", 1))
+	suite.Equal("capped", evaluate("promo", 1))
+	suite.Equal("fallback", evaluate("promo", 1))
+	suite.Equal(int64(2), frequencyCapRepo.counters["promo:user:1"])
+
+	suite.Equal("", evaluate("banner", 1))
+	suite.Equal("on", evaluate("banner", 2))
+
+	explained, err := eng.Explain([]string{"promo"}, model.Entity{EntityID: 2, EntityType: "user"})
+	suite.NoError(err)
+	suite.Equal(0, *explained.Explanations[0].Segment)
+	suite.Zero(frequencyCapRepo.counters["promo:user:2"], "explaining should not count an exposure")
+
+	explained, err = eng.Explain([]string{"promo"}, model.Entity{EntityID: 1, EntityType: "user"})
+	suite.NoError(err)
+	suite.Equal(1, *explained.Explanations[0].Segment)
+	suite.Equal(int64(2), frequencyCapRepo.counters["promo:user:1"])
+
+	frequencyCapRepo.reject = true
+
+	suite.Equal("fallback", evaluate("promo", 3))
+	suite.Equal(int64(0), frequencyCapRepo.counters["promo:user:3"])
+}
+
+func (suite *EngineSuite) TestEngineStickyAssignments() {
+	flagRepo := &fakeStickyFlagRepo{variants: `[{"variant_key": "a", "weight": 100}]`}
+	assignmentRepo := &fakeAssignmentRepo{assignments: map[model.AssignmentLookup]string{}}
+
+	eng := engine.New(
+		&fakeLogger{}, flagRepo, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{}, &fakeFrequencyCapRepo{},
+		assignmentRepo, &fakeEntityRepo{},
+	)
+	suite.NoError(eng.Fetch())
+
+	evaluate := func(entityID int64) map[string]string {
+		assignmentRepo.calls = 0
+
+		result, err := eng.Evaluate([]string{"experiment", "rollout"}, model.Entity{EntityID: entityID, EntityType: "user"})
+		suite.NoError(err)
+		suite.Equal(1, assignmentRepo.calls)
+
+		variants := map[string]string{}
+
+		for _, evaluation := range result.Evaluations {
+			variants[evaluation.Flag] = evaluation.Variant.VariantKey
+		}
+
+		return variants
+	}
+
+	suite.Equal(map[string]string{"experiment": "a", "rollout": "a"}, evaluate(1))
+
+	flagRepo.variants = `[{"variant_key": "b", "weight": 100}, {"variant_key": "a", "weight": 0}]`
+	suite.NoError(eng.Fetch())
+
+	suite.Equal(map[string]string{"experiment": "a", "rollout": "b"}, evaluate(1))
+	suite.Equal(map[string]string{"experiment": "b", "rollout": "b"}, evaluate(2))
+
+	explained, err := eng.Explain([]string{"experiment"}, model.Entity{EntityID: 1, EntityType: "user"})
+	suite.NoError(err)
+	suite.True(explained.Explanations[0].Assigned)
+	suite.Equal("a", explained.Explanations[0].Variant.VariantKey)
+
+	explained, err = eng.Explain([]string{"experiment"}, model.Entity{EntityID: 3, EntityType: "user"})
+	suite.NoError(err)
+	suite.False(explained.Explanations[0].Assigned)
+	suite.Equal("b", explained.Explanations[0].Variant.VariantKey)
+	suite.NotContains(
+		assignmentRepo.assignments, model.AssignmentLookup{Flag: "experiment", EntityType: "user", EntityID: 3},
+		"explaining should not assign the entity",
+	)
+
+	flagRepo.variants = `[{"variant_key": "c", "weight": 100}]`
+	suite.NoError(eng.Fetch())
+
+	lookup := model.AssignmentLookup{Flag: "experiment", EntityType: "user", EntityID: 1}
+
+	explained, err = eng.Explain([]string{"experiment"}, model.Entity{EntityID: 1, EntityType: "user"})
+	suite.NoError(err)
+	suite.False(explained.Explanations[0].Assigned)
+	suite.Equal("c", explained.Explanations[0].Variant.VariantKey)
+	suite.Equal("a", assignmentRepo.assignments[lookup], "explaining should not replace a stale assignment")
+
+	suite.Equal(map[string]string{"experiment": "c", "rollout": "c"}, evaluate(1))
+	suite.Equal("c", assignmentRepo.assignments[lookup])
+
+	suite.NoError(assignmentRepo.Reset("experiment"))
+
+	flagRepo.variants = `[{"variant_key": "a", "weight": 100}]`
+	suite.NoError(eng.Fetch())
+
+	suite.Equal(map[string]string{"experiment": "a", "rollout": "a"}, evaluate(1))
+
+	flagRepo.unmatched = true
+	suite.NoError(eng.Fetch())
+
+	suite.Equal(map[string]string{"experiment": "a"}, evaluate(1), "assigned entities should keep their variant")
+	suite.Empty(evaluate(2))
+
+	explained, err = eng.Explain([]string{"experiment"}, model.Entity{EntityID: 1, EntityType: "user"})
+	suite.NoError(err)
+	suite.True(explained.Explanations[0].Assigned)
+	suite.Nil(explained.Explanations[0].Segment)
+	suite.Equal("a", explained.Explanations[0].Variant.VariantKey)
+}
+
+func (suite *EngineSuite) TestEngineEntityAge() {
+	recently := time.Now().Add(-1 * time.Hour)
+
+	entityRepo := &fakeEntityRepo{seen: map[int64]time.Time{1: recently, 2: time.Now().Add(-48 * time.Hour)}}
+
+	eng := engine.New(
+		&fakeLogger{}, &fakeEntityAgeFlagRepo{}, &fakeAudienceRepo{}, &fakeLayerRepo{}, &fakeListRepo{},
+		&fakeFrequencyCapRepo{}, &fakeAssignmentRepo{}, entityRepo,
+	)
+	suite.NoError(eng.Fetch())
+
+	for _, tc := range []struct {
+		flag     string
+		entity   model.Entity
+		variants []string
+		calls    int
+	}{
+		{flag: "new.users", entity: model.Entity{EntityID: 1}, variants: []string{"on"}, calls: 1},
+		{flag: "new.users", entity: model.Entity{EntityID: 2}, calls: 1},
+		{flag: "new.users", entity: model.Entity{EntityID: 3}, calls: 1},
+		{flag: "welcome", entity: model.Entity{EntityID: 1}, variants: []string{"on"}, calls: 1},
+		{flag: "welcome", entity: model.Entity{EntityID: 2}, calls: 1},
+		{
+			flag:     "new.users",
+			entity:   model.Entity{EntityID: 2, FirstSeen: &recently},
+			variants: []string{"on"},
+		},
+	} {
+		entityRepo.calls = 0
+
+		result, err := eng.Evaluate([]string{tc.flag}, tc.entity)
+		suite.NoError(err)
+
+		var variants []string
+
+		for _, evaluation := range result.Evaluations {
+			variants = append(variants, evaluation.Variant.VariantKey)
+		}
+
+		suite.Equal(tc.variants, variants)
+		suite.Equal(tc.calls, entityRepo.calls)
+	}
+
+	explained, err := eng.Explain([]string{"new.users"}, model.Entity{EntityID: 1})
+	suite.NoError(err)
+	suite.Equal(0, *explained.Explanations[0].Segment)
+	suite.NotNil(explained.Entity.FirstSeen, "the loaded timestamps should be explained")
+}
+
 func TestEngineSuite(t *testing.T) {
 	suite.Run(t, new(EngineSuite))
 }
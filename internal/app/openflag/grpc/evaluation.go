@@ -2,7 +2,9 @@ package grpc
 
 import (
 	"context"
+	"time"
 
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/engine"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/evaluation"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
@@ -50,7 +52,12 @@ func (s *evaluationServer) Evaluate(
 	resps := []*evaluation.EvaluationResponse{}
 
 	for _, entity := range entities {
-		result, err := s.Engine.Evaluate(req.Flags, entity)
+		evaluate := s.Engine.Evaluate
+		if req.Explain {
+			evaluate = s.Engine.Explain
+		}
+
+		result, err := evaluate(req.Flags, entity)
 		if err != nil {
 			logrus.Errorf("grpc evaluation handler failed to evaluate: %s", err.Error())
 			return nil, status.Error(codes.Internal, ErrInternalServerError.Error())
@@ -68,14 +75,23 @@ func (s *evaluationServer) Evaluate(
 			})
 		}
 
-		resps = append(resps, &evaluation.EvaluationResponse{
+		resp := &evaluation.EvaluationResponse{
 			Entity: &evaluation.Entity{
 				EntityID:      result.Entity.EntityID,
 				EntityType:    result.Entity.EntityType,
-				EntityContext: result.Entity.EntityContext,
+				EntityContext: result.Entity.EntityContext.Strings(),
+				TypedContext:  typedContext(result.Entity.EntityContext),
 			},
-			Evaluations: evaluations,
-		})
+			Evaluations:  evaluations,
+			Explanations: explanations(result.Explanations),
+		}
+
+		if req.Explain {
+			resp.Entity.FirstSeen = unix(result.Entity.FirstSeen)
+			resp.Entity.LastSeen = unix(result.Entity.LastSeen)
+		}
+
+		resps = append(resps, resp)
 	}
 
 	if req.SaveContexts {
@@ -98,7 +114,7 @@ func (s *evaluationServer) validate(req *evaluation.EvaluationRequest) (request.
 			entity = request.Entity{
 				EntityID:      req.Entities[i].EntityID,
 				EntityType:    req.Entities[i].EntityType,
-				EntityContext: req.Entities[i].EntityContext,
+				EntityContext: entityContext(req.Entities[i]),
 			}
 		}
 
@@ -110,7 +126,143 @@ func (s *evaluationServer) validate(req *evaluation.EvaluationRequest) (request.
 		Flags:             req.Flags,
 		SaveContexts:      req.SaveContexts,
 		UseStoredContexts: req.UseStoredContexts,
+		Explain:           req.Explain,
 	}
 
 	return result, result.Validate()
 }
+
+func explanations(explanations []engine.Explanation) []*evaluation.EvaluationResponse_Explanation {
+	var resps []*evaluation.EvaluationResponse_Explanation
+
+	for _, explanation := range explanations {
+		resp := &evaluation.EvaluationResponse_Explanation{
+			Flag:     explanation.Flag,
+			Excluded: explanation.Excluded,
+			Segment:  -1,
+			Assigned: explanation.Assigned,
+		}
+
+		if explanation.Segment != nil {
+			resp.Segment = int32(*explanation.Segment)
+		}
+
+		for _, segment := range explanation.Segments {
+			resp.Segments = append(resp.Segments, &evaluation.EvaluationResponse_SegmentExplanation{
+				Description: segment.Description,
+				Constraint:  trace(segment.Constraint),
+			})
+		}
+
+		if explanation.Variant != nil {
+			resp.Variant = &evaluation.EvaluationResponse_Variant{
+				VariantKey:        explanation.Variant.VariantKey,
+				VariantAttachment: explanation.Variant.VariantAttachment,
+			}
+		}
+
+		resps = append(resps, resp)
+	}
+
+	return resps
+}
+
+func trace(t constraint.Trace) *evaluation.EvaluationResponse_ConstraintTrace {
+	resp := &evaluation.EvaluationResponse_ConstraintTrace{
+		Name:       t.Name,
+		Parameters: string(t.Parameters),
+		Evaluated:  t.Result != nil,
+		Result:     t.Result != nil && *t.Result,
+		Properties: typedContext(t.Properties),
+		Missing:    t.Missing,
+	}
+
+	for _, child := range t.Children {
+		resp.Children = append(resp.Children, trace(child))
+	}
+
+	return resp
+}
+
+// unix returns the unix timestamp of the given time or zero if it is not set.
+func unix(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+// entityContext returns the context of the given entity. The typed context overrides the string context
+// that the old clients send.
+func entityContext(e *evaluation.Entity) model.Context {
+	if len(e.EntityContext) == 0 && len(e.TypedContext) == 0 {
+		return nil
+	}
+
+	context := model.StringContext(e.EntityContext)
+	if context == nil {
+		context = model.Context{}
+	}
+
+	for key, value := range contextFromValues(e.TypedContext) {
+		context[key] = value
+	}
+
+	return context
+}
+
+// contextFromValues converts the gRPC values into a context. Values without a kind are ignored.
+func contextFromValues(values map[string]*evaluation.Value) model.Context {
+	context := model.Context{}
+
+	for key, value := range values {
+		switch kind := value.GetKind().(type) {
+		case *evaluation.Value_StringValue:
+			context[key] = model.StringValue(kind.StringValue)
+		case *evaluation.Value_NumberValue:
+			context[key] = model.NumberValue(kind.NumberValue)
+		case *evaluation.Value_BoolValue:
+			context[key] = model.BoolValue(kind.BoolValue)
+		case *evaluation.Value_ListValue:
+			context[key] = model.ListValue(kind.ListValue.GetValues())
+		case *evaluation.Value_ObjectValue:
+			context[key] = model.ObjectValue(contextFromValues(kind.ObjectValue.GetValues()))
+		}
+	}
+
+	return context
+}
+
+// typedContext returns the typed context of the gRPC entity.
+func typedContext(context model.Context) map[string]*evaluation.Value {
+	if context == nil {
+		return nil
+	}
+
+	values := map[string]*evaluation.Value{}
+
+	for key, value := range context {
+		switch value.Kind() {
+		case model.NumberKind:
+			n, _ := value.Number()
+			values[key] = &evaluation.Value{Kind: &evaluation.Value_NumberValue{NumberValue: n}}
+		case model.BoolKind:
+			b, _ := value.Bool()
+			values[key] = &evaluation.Value{Kind: &evaluation.Value_BoolValue{BoolValue: b}}
+		case model.ListKind:
+			values[key] = &evaluation.Value{
+				Kind: &evaluation.Value_ListValue{ListValue: &evaluation.StringList{Values: value.List()}},
+			}
+		case model.ObjectKind:
+			object, _ := value.Object()
+			values[key] = &evaluation.Value{
+				Kind: &evaluation.Value_ObjectValue{ObjectValue: &evaluation.ValueMap{Values: typedContext(object)}},
+			}
+		default:
+			values[key] = &evaluation.Value{Kind: &evaluation.Value_StringValue{StringValue: value.String()}}
+		}
+	}
+
+	return values
+}
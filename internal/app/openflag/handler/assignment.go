@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// AssignmentHandler represents a requests handler for the sticky variant assignments of the flags.
+type AssignmentHandler struct {
+	AssignmentRepo model.AssignmentRepo
+}
+
+// Reset resets the sticky variant assignments of a flag using an http request, so each entity is assigned
+// again on its next evaluation.
+func (a AssignmentHandler) Reset(c echo.Context) error {
+	req := request.ResetAssignmentsRequest{Flag: c.Param("flag")}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("assignment handler validate (reset): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if err := a.AssignmentRepo.Reset(req.Flag); err != nil {
+		logrus.Errorf("assignment handler failed to reset assignments: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ResetEntity resets the sticky variant assignment of an entity in a flag using an http request.
+func (a AssignmentHandler) ResetEntity(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("entity_id"), 0, 64)
+	if err != nil {
+		logrus.Errorf("assignment handler param (reset entity): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	req := request.ResetEntityAssignmentRequest{
+		Flag:   c.Param("flag"),
+		Entity: request.Entity{EntityID: id, EntityType: c.Param("entity_type")},
+	}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("assignment handler validate (reset entity): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	lookup := model.AssignmentLookup{Flag: req.Flag, EntityType: req.Entity.EntityType, EntityID: req.Entity.EntityID}
+
+	if err := a.AssignmentRepo.ResetEntity(lookup); err != nil {
+		logrus.Errorf("assignment handler failed to reset entity assignment: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,130 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/handler"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeAssignmentRepo struct {
+	model.AssignmentRepo
+	repoError error
+	flags     []string
+	lookups   []model.AssignmentLookup
+}
+
+func (f *fakeAssignmentRepo) Reset(flag string) error {
+	if f.repoError != nil {
+		return f.repoError
+	}
+
+	f.flags = append(f.flags, flag)
+
+	return nil
+}
+
+func (f *fakeAssignmentRepo) ResetEntity(lookup model.AssignmentLookup) error {
+	if f.repoError != nil {
+		return f.repoError
+	}
+
+	f.lookups = append(f.lookups, lookup)
+
+	return nil
+}
+
+type AssignmentHandlerSuite struct {
+	suite.Suite
+	engine             *echo.Echo
+	fakeAssignmentRepo *fakeAssignmentRepo
+}
+
+func (suite *AssignmentHandlerSuite) SetupSuite() {
+	suite.engine = echo.New()
+
+	suite.fakeAssignmentRepo = &fakeAssignmentRepo{}
+
+	h := handler.AssignmentHandler{AssignmentRepo: suite.fakeAssignmentRepo}
+
+	suite.engine.DELETE("/v1/assignment/:flag", h.Reset)
+	suite.engine.DELETE("/v1/assignment/:flag/:entity_type/:entity_id", h.ResetEntity)
+}
+
+func (suite *AssignmentHandlerSuite) TestReset() {
+	cases := []struct {
+		name      string
+		path      string
+		status    int
+		repoError error
+		flags     []string
+		lookups   []model.AssignmentLookup
+	}{
+		{
+			name:   "successfully reset assignments of flag",
+			path:   "/v1/assignment/checkout.v2",
+			status: http.StatusNoContent,
+			flags:  []string{"checkout.v2"},
+		},
+		{
+			name:    "successfully reset assignment of entity",
+			path:    "/v1/assignment/checkout.v2/user/42",
+			status:  http.StatusNoContent,
+			lookups: []model.AssignmentLookup{{Flag: "checkout.v2", EntityType: "user", EntityID: 42}},
+		},
+		{
+			name:   "failed to reset assignments with invalid flag",
+			path:   "/v1/assignment/Checkout",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "failed to reset assignment with invalid entity id",
+			path:   "/v1/assignment/checkout.v2/user/x",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "failed to reset assignment with zero entity id",
+			path:   "/v1/assignment/checkout.v2/user/0",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "failed to reset assignments because of repo error",
+			path:      "/v1/assignment/checkout.v2",
+			status:    http.StatusInternalServerError,
+			repoError: errors.New("repo error"),
+		},
+		{
+			name:      "failed to reset assignment of entity because of repo error",
+			path:      "/v1/assignment/checkout.v2/user/42",
+			status:    http.StatusInternalServerError,
+			repoError: errors.New("repo error"),
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+
+		suite.Run(tc.name, func() {
+			suite.fakeAssignmentRepo.repoError = tc.repoError
+			suite.fakeAssignmentRepo.flags = nil
+			suite.fakeAssignmentRepo.lookups = nil
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", tc.path, nil)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+			suite.Equal(tc.flags, suite.fakeAssignmentRepo.flags, tc.name)
+			suite.Equal(tc.lookups, suite.fakeAssignmentRepo.lookups, tc.name)
+		})
+	}
+}
+
+func TestAssignmentHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AssignmentHandlerSuite))
+}
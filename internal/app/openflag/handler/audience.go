@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrAudienceInUse represents an error that we return when we want to delete an audience that is
+	// referenced by a flag.
+	ErrAudienceInUse = errors.New("audience is referenced by a flag")
+)
+
+// AudienceHandler represents a requests handler for audiences.
+type AudienceHandler struct {
+	AudienceRepo model.AudienceRepo
+	FlagRepo     model.FlagRepo
+}
+
+// Create creates an audience using an http request.
+func (a AudienceHandler) Create(c echo.Context) error {
+	req := request.CreateAudienceRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		logrus.Errorf("audience handler bind (create): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, ErrInvalidJSONSyntax.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("audience handler validate (create): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	audience, err := a.audienceFromRequest(req.Audience)
+	if err != nil {
+		logrus.Errorf("audience handler audience from request failed: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if err := a.AudienceRepo.Create(audience); err != nil {
+		if err == model.ErrDuplicateAudienceFound {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
+		logrus.Errorf("audience handler failed to create audience: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	resp, err := a.responseFromAudience(audience)
+	if err != nil {
+		logrus.Errorf("audience handler response from audience failed: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, *resp)
+}
+
+// Delete deletes an audience using an http request. It refuses to delete audiences that are still
+// referenced by a flag.
+func (a AudienceHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		logrus.Errorf("audience handler param (delete): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	audience, err := a.AudienceRepo.FindByID(id)
+	if err != nil {
+		if err == model.ErrAudienceNotFound {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		logrus.Errorf("audience handler failed to find audience for delete: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	flag, err := a.referencingFlag(audience.Name)
+	if err != nil {
+		logrus.Errorf("audience handler failed to find audience references: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if flag != "" {
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("%s: %s", ErrAudienceInUse.Error(), flag))
+	}
+
+	if err := a.AudienceRepo.Delete(id); err != nil {
+		logrus.Errorf("audience handler failed to delete audience: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Update updates an audience using an http request.
+func (a AudienceHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		logrus.Errorf("audience handler param (update): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	req := request.UpdateAudienceRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		logrus.Errorf("audience handler bind data (update): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, ErrInvalidJSONSyntax.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("audience handler validate (update): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	audience, err := a.audienceFromRequest(req.Audience)
+	if err != nil {
+		logrus.Errorf("audience handler audience from request failed: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if err := a.AudienceRepo.Update(id, audience); err != nil {
+		if err == model.ErrAudienceNotFound {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		if err == model.ErrInvalidAudienceForUpdate {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		logrus.Errorf("audience handler failed to update audience: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	resp, err := a.responseFromAudience(audience)
+	if err != nil {
+		logrus.Errorf("audience handler response from audience failed: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, *resp)
+}
+
+// FindByID finds an audience by its given id using an http request.
+func (a AudienceHandler) FindByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		logrus.Errorf("audience handler param (find by id): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	audience, err := a.AudienceRepo.FindByID(id)
+	if err != nil {
+		if err == model.ErrAudienceNotFound {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		logrus.Errorf("audience handler failed to find by id: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	resp, err := a.responseFromAudience(audience)
+	if err != nil {
+		logrus.Errorf("audience handler response from audience failed: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, *resp)
+}
+
+// FindAll finds all audiences using an http request.
+func (a AudienceHandler) FindAll(c echo.Context) error {
+	audiences, err := a.AudienceRepo.FindAll()
+	if err != nil {
+		logrus.Errorf("audience handler failed to find all: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	resps := []response.Audience{}
+
+	for _, audience := range audiences {
+		au := audience
+
+		resp, err := a.responseFromAudience(&au)
+		if err != nil {
+			logrus.Errorf("audience handler response from audience failed: %s", err.Error())
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+
+		resps = append(resps, *resp)
+	}
+
+	return c.JSON(http.StatusOK, resps)
+}
+
+// referencingFlag returns the key of a flag that references the given audience or an empty string
+// if there is not any.
+func (a AudienceHandler) referencingFlag(name string) (string, error) {
+	flags, err := a.FlagRepo.FindAll()
+	if err != nil {
+		return "", err
+	}
+
+	for _, flag := range flags {
+		var segments []model.Segment
+
+		if err := json.Unmarshal([]byte(flag.Segments), &segments); err != nil {
+			return "", err
+		}
+
+		for _, segment := range segments {
+			for _, c := range segment.Constraints {
+				if audience, ok := constraint.ReferencedAudience(c); ok && audience == name {
+					return flag.Flag, nil
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func (a AudienceHandler) audienceFromRequest(req request.Audience) (*model.Audience, error) {
+	constraints := map[string]model.Constraint{}
+
+	for identifier, c := range req.Constraints {
+		constraints[identifier] = model.Constraint{
+			Name:       c.Name,
+			Parameters: c.Parameters,
+		}
+	}
+
+	constraintsByte, err := json.Marshal(constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	audience := model.Audience{
+		Name:        req.Name,
+		Description: req.Description,
+		Constraints: string(constraintsByte),
+		Expression:  req.Expression,
+	}
+
+	return &audience, nil
+}
+
+func (a AudienceHandler) responseFromAudience(audience *model.Audience) (*response.Audience, error) {
+	var audienceConstraints map[string]model.Constraint
+
+	if err := json.Unmarshal([]byte(audience.Constraints), &audienceConstraints); err != nil {
+		return nil, err
+	}
+
+	constraints := map[string]response.Constraint{}
+
+	for identifier, c := range audienceConstraints {
+		constraints[identifier] = response.Constraint{
+			Name:       c.Name,
+			Parameters: c.Parameters,
+		}
+	}
+
+	resp := response.Audience{
+		ID:          audience.ID,
+		Name:        audience.Name,
+		Description: audience.Description,
+		Constraints: constraints,
+		Expression:  audience.Expression,
+		CreatedAt:   audience.CreatedAt,
+		UpdatedAt:   audience.UpdatedAt,
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,387 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/handler"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeAudienceRepo struct {
+	repoError    error
+	toBeDeleteID int64
+	toBeUpdateID int64
+}
+
+func (f *fakeAudienceRepo) audiences() []model.Audience {
+	return []model.Audience{
+		{
+			ID:          10,
+			Name:        "employees",
+			Description: "description 1",
+			Constraints: `{"A": {"name": "contains", "parameters": {"values": ["1", "2"]}}}`,
+			Expression:  "A",
+		},
+		{
+			ID:          11,
+			Name:        "testers",
+			Description: "description 2",
+			Constraints: `{"A": {"name": "contains", "parameters": {"values": ["3"]}}}`,
+			Expression:  "A",
+		},
+	}
+}
+
+func (f *fakeAudienceRepo) Create(audience *model.Audience) error {
+	return f.repoError
+}
+
+func (f *fakeAudienceRepo) Delete(id int64) error {
+	if f.repoError != nil {
+		return f.repoError
+	}
+
+	f.toBeDeleteID = id
+
+	return nil
+}
+
+func (f *fakeAudienceRepo) Update(id int64, audience *model.Audience) error {
+	if f.repoError != nil {
+		return f.repoError
+	}
+
+	f.toBeUpdateID = id
+
+	return nil
+}
+
+func (f *fakeAudienceRepo) FindAll() ([]model.Audience, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	return f.audiences(), nil
+}
+
+func (f *fakeAudienceRepo) FindByID(id int64) (*model.Audience, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	for _, audience := range f.audiences() {
+		if audience.ID == id {
+			a := audience
+			return &a, nil
+		}
+	}
+
+	return nil, model.ErrAudienceNotFound
+}
+
+type fakeAudienceFlagRepo struct {
+	model.FlagRepo
+}
+
+func (f *fakeAudienceFlagRepo) FindAll() ([]model.Flag, error) {
+	return []model.Flag{
+		{
+			ID:   10,
+			Flag: "flag1",
+			Segments: `
+			[
+				{
+					"description": "segment 1",
+					"constraints": {
+						"A": {
+							"name": "audience",
+							"parameters": {
+								"audience": "employees"
+							}
+						}
+					},
+					"expression": "A",
+					"variant": {
+						"variant_key": "on"
+					}
+				}
+			]
+		`,
+		},
+	}, nil
+}
+
+type AudienceHandlerSuite struct {
+	suite.Suite
+	engine           *echo.Echo
+	fakeAudienceRepo *fakeAudienceRepo
+}
+
+func (suite *AudienceHandlerSuite) SetupSuite() {
+	suite.engine = echo.New()
+
+	suite.fakeAudienceRepo = &fakeAudienceRepo{}
+
+	h := handler.AudienceHandler{AudienceRepo: suite.fakeAudienceRepo, FlagRepo: &fakeAudienceFlagRepo{}}
+
+	suite.engine.POST("/v1/audience", h.Create)
+	suite.engine.DELETE("/v1/audience/:id", h.Delete)
+	suite.engine.PUT("/v1/audience/:id", h.Update)
+	suite.engine.GET("/v1/audience/:id", h.FindByID)
+	suite.engine.GET("/v1/audiences", h.FindAll)
+}
+
+func (suite *AudienceHandlerSuite) audienceRequest() request.Audience {
+	return request.Audience{
+		Name:        "employees",
+		Description: "description",
+		Constraints: map[string]request.Constraint{
+			"A": {
+				Name:       constraint.ContainsConstraintName,
+				Parameters: json.RawMessage(`{"values": ["1", "2"]}`),
+			},
+			"B": {
+				Name:       constraint.BiggerThanConstraintName,
+				Parameters: json.RawMessage(`{"value": 5}`),
+			},
+		},
+		Expression: fmt.Sprintf("A %s B", constraint.UnionConstraintName),
+	}
+}
+
+func (suite *AudienceHandlerSuite) TestCreateAudience() {
+	nested := suite.audienceRequest()
+	nested.Constraints = map[string]request.Constraint{
+		"A": {
+			Name:       constraint.AudienceConstraintName,
+			Parameters: json.RawMessage(`{"audience": "testers"}`),
+		},
+	}
+	nested.Expression = "A"
+
+	invalidExpression := suite.audienceRequest()
+	invalidExpression.Expression = "A ∩ C"
+
+	invalidName := suite.audienceRequest()
+	invalidName.Name = "Employees"
+
+	cases := []struct {
+		name      string
+		req       request.CreateAudienceRequest
+		status    int
+		repoError error
+	}{
+		{
+			name:   "successfully create audience",
+			req:    request.CreateAudienceRequest{Audience: suite.audienceRequest()},
+			status: http.StatusOK,
+		},
+		{
+			name:   "failed to create audience with another audience",
+			req:    request.CreateAudienceRequest{Audience: nested},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "failed to create audience with invalid expression",
+			req:    request.CreateAudienceRequest{Audience: invalidExpression},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "failed to create audience with invalid name",
+			req:    request.CreateAudienceRequest{Audience: invalidName},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "failed to create duplicate audience",
+			req:       request.CreateAudienceRequest{Audience: suite.audienceRequest()},
+			status:    http.StatusConflict,
+			repoError: model.ErrDuplicateAudienceFound,
+		},
+		{
+			name:      "failed to create audience with repo error",
+			req:       request.CreateAudienceRequest{Audience: suite.audienceRequest()},
+			status:    http.StatusInternalServerError,
+			repoError: errors.New("fake audience repo error"),
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			suite.fakeAudienceRepo.repoError = tc.repoError
+
+			data, err := json.Marshal(tc.req)
+			suite.NoError(err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/v1/audience", bytes.NewReader(data))
+
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+
+			if tc.status == http.StatusOK {
+				resp := response.Audience{}
+
+				suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+				suite.Equal(tc.req.Name, resp.Name)
+				suite.Equal(tc.req.Expression, resp.Expression)
+				suite.Len(resp.Constraints, len(tc.req.Constraints))
+			}
+		})
+	}
+}
+
+func (suite *AudienceHandlerSuite) TestDeleteAudience() {
+	cases := []struct {
+		name       string
+		audienceID string
+		status     int
+		repoError  error
+	}{
+		{
+			name:       "successfully delete audience",
+			audienceID: "11",
+			status:     http.StatusNoContent,
+		},
+		{
+			name:       "failed to delete audience that is referenced by a flag",
+			audienceID: "10",
+			status:     http.StatusConflict,
+		},
+		{
+			name:       "failed to delete audience that does not exist",
+			audienceID: "12",
+			status:     http.StatusNotFound,
+		},
+		{
+			name:       "failed to delete audience with invalid id",
+			audienceID: "11s",
+			status:     http.StatusBadRequest,
+		},
+		{
+			name:       "failed to delete audience with repo error",
+			audienceID: "11",
+			status:     http.StatusInternalServerError,
+			repoError:  errors.New("fake audience repo error"),
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			suite.fakeAudienceRepo.repoError = tc.repoError
+			suite.fakeAudienceRepo.toBeDeleteID = 0
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/v1/audience/%s", tc.audienceID), nil)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+
+			if tc.status == http.StatusNoContent {
+				suite.Equal(tc.audienceID, fmt.Sprintf("%d", suite.fakeAudienceRepo.toBeDeleteID))
+			} else {
+				suite.Equal(int64(0), suite.fakeAudienceRepo.toBeDeleteID)
+			}
+		})
+	}
+}
+
+func (suite *AudienceHandlerSuite) TestUpdateAudience() {
+	cases := []struct {
+		name       string
+		audienceID string
+		status     int
+		repoError  error
+	}{
+		{
+			name:       "successfully update audience",
+			audienceID: "10",
+			status:     http.StatusOK,
+		},
+		{
+			name:       "failed to update audience that does not exist",
+			audienceID: "12",
+			status:     http.StatusNotFound,
+			repoError:  model.ErrAudienceNotFound,
+		},
+		{
+			name:       "failed to update audience name",
+			audienceID: "11",
+			status:     http.StatusBadRequest,
+			repoError:  model.ErrInvalidAudienceForUpdate,
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			suite.fakeAudienceRepo.repoError = tc.repoError
+
+			data, err := json.Marshal(request.UpdateAudienceRequest{Audience: suite.audienceRequest()})
+			suite.NoError(err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/v1/audience/%s", tc.audienceID), bytes.NewReader(data))
+
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+
+			if tc.status == http.StatusOK {
+				suite.Equal(tc.audienceID, fmt.Sprintf("%d", suite.fakeAudienceRepo.toBeUpdateID))
+			}
+		})
+	}
+}
+
+func (suite *AudienceHandlerSuite) TestFindAudiences() {
+	suite.fakeAudienceRepo.repoError = nil
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/v1/audience/10", nil)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+
+	audience := response.Audience{}
+
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &audience))
+	suite.Equal("employees", audience.Name)
+	suite.Equal(constraint.ContainsConstraintName, audience.Constraints["A"].Name)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v1/audience/12", nil)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v1/audiences", nil)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var audiences []response.Audience
+
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &audiences))
+	suite.Len(audiences, 2)
+}
+
+func TestAudienceHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AudienceHandlerSuite))
+}
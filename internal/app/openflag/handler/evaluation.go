@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/engine"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
@@ -55,7 +56,12 @@ func (e EvaluationHandler) Evaluate(c echo.Context) error {
 	resps := []response.EvaluationResponse{}
 
 	for _, entity := range entities {
-		result, err := e.Engine.Evaluate(req.Flags, entity)
+		evaluate := e.Engine.Evaluate
+		if req.Explain {
+			evaluate = e.Engine.Explain
+		}
+
+		result, err := evaluate(req.Flags, entity)
 		if err != nil {
 			logrus.Errorf("evaluation handler failed to evaluate: %s", err.Error())
 			return echo.NewHTTPError(http.StatusInternalServerError)
@@ -73,14 +79,22 @@ func (e EvaluationHandler) Evaluate(c echo.Context) error {
 			})
 		}
 
-		resps = append(resps, response.EvaluationResponse{
+		resp := response.EvaluationResponse{
 			Entity: response.Entity{
 				EntityID:      result.Entity.EntityID,
 				EntityType:    result.Entity.EntityType,
 				EntityContext: result.Entity.EntityContext,
 			},
-			Evaluations: evaluations,
-		})
+			Evaluations:  evaluations,
+			Explanations: explanations(result.Explanations),
+		}
+
+		if req.Explain {
+			resp.Entity.FirstSeen = result.Entity.FirstSeen
+			resp.Entity.LastSeen = result.Entity.LastSeen
+		}
+
+		resps = append(resps, resp)
 	}
 
 	if req.SaveContexts {
@@ -92,3 +106,51 @@ func (e EvaluationHandler) Evaluate(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, resps)
 }
+
+func explanations(explanations []engine.Explanation) []response.Explanation {
+	var resps []response.Explanation
+
+	for _, explanation := range explanations {
+		resp := response.Explanation{
+			Flag:     explanation.Flag,
+			Excluded: explanation.Excluded,
+			Segments: []response.SegmentExplanation{},
+			Segment:  explanation.Segment,
+			Assigned: explanation.Assigned,
+		}
+
+		for _, segment := range explanation.Segments {
+			resp.Segments = append(resp.Segments, response.SegmentExplanation{
+				Description: segment.Description,
+				Constraint:  trace(segment.Constraint),
+			})
+		}
+
+		if explanation.Variant != nil {
+			resp.Variant = &response.Variant{
+				VariantKey:        explanation.Variant.VariantKey,
+				VariantAttachment: explanation.Variant.VariantAttachment,
+			}
+		}
+
+		resps = append(resps, resp)
+	}
+
+	return resps
+}
+
+func trace(t constraint.Trace) response.Trace {
+	resp := response.Trace{
+		Name:       t.Name,
+		Parameters: t.Parameters,
+		Result:     t.Result,
+		Properties: t.Properties,
+		Missing:    t.Missing,
+	}
+
+	for _, child := range t.Children {
+		resp.Children = append(resp.Children, trace(child))
+	}
+
+	return resp
+}
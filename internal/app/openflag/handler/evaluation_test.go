@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/engine"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/handler"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
@@ -27,6 +28,7 @@ type (
 	fakeEvaluationEngine struct {
 		engine.Engine
 		evaluateFunc func(flags []string, entity model.Entity) (result *engine.Result, e error)
+		explainFunc  func(flags []string, entity model.Entity) (result *engine.Result, e error)
 	}
 )
 
@@ -42,6 +44,10 @@ func (f fakeEvaluationEngine) Evaluate(flags []string, entity model.Entity) (*en
 	return f.evaluateFunc(flags, entity)
 }
 
+func (f fakeEvaluationEngine) Explain(flags []string, entity model.Entity) (*engine.Result, error) {
+	return f.explainFunc(flags, entity)
+}
+
 type EvaluationHandlerSuite struct {
 	suite.Suite
 	engine               *echo.Echo
@@ -78,8 +84,8 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					{
 						EntityID:   1,
 						EntityType: "type1",
-						EntityContext: map[string]string{
-							"k1": "v1",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
 						},
 					},
 				},
@@ -110,8 +116,8 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					Entity: response.Entity{
 						EntityID:   1,
 						EntityType: "type1",
-						EntityContext: map[string]string{
-							"k1": "v1",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
 						},
 					},
 					Evaluations: []response.Evaluation{
@@ -126,6 +132,43 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 				},
 			},
 		},
+		{
+			name: "successfully send evaluation request with typed context",
+			req: request.EvaluationRequest{
+				Entities: []request.Entity{
+					{
+						EntityID:   1,
+						EntityType: "type1",
+						EntityContext: model.Context{
+							"age":   model.NumberValue(21),
+							"beta":  model.BoolValue(true),
+							"roles": model.ListValue([]string{"admin", "editor"}),
+						},
+					},
+				},
+				Flags: []string{"flag1"},
+			},
+			contextsStore:  func(entities []model.Entity) error { return nil },
+			contextsReader: func(entities []model.Entity) (entities2 []model.Entity, e error) { return nil, nil },
+			evaluationEngine: func(flags []string, entity model.Entity) (result *engine.Result, e error) {
+				return &engine.Result{Entity: entity, Evaluations: []engine.Evaluation{}, Timestamp: time.Now()}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: []response.EvaluationResponse{
+				{
+					Entity: response.Entity{
+						EntityID:   1,
+						EntityType: "type1",
+						EntityContext: model.Context{
+							"age":   model.NumberValue(21),
+							"beta":  model.BoolValue(true),
+							"roles": model.ListValue([]string{"admin", "editor"}),
+						},
+					},
+					Evaluations: []response.Evaluation{},
+				},
+			},
+		},
 		{
 			name: "successfully send evaluation request and get response 2",
 			req: request.EvaluationRequest{
@@ -133,8 +176,8 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					{
 						EntityID:   1,
 						EntityType: "type1",
-						EntityContext: map[string]string{
-							"k1": "v1",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
 						},
 					},
 				},
@@ -148,9 +191,9 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					{
 						EntityID:   1,
 						EntityType: "type1",
-						EntityContext: map[string]string{
-							"k1": "v1",
-							"k2": "v2",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
+							"k2": model.StringValue("v2"),
 						},
 					},
 				}, nil
@@ -176,9 +219,9 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					Entity: response.Entity{
 						EntityID:   1,
 						EntityType: "type1",
-						EntityContext: map[string]string{
-							"k1": "v1",
-							"k2": "v2",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
+							"k2": model.StringValue("v2"),
 						},
 					},
 					Evaluations: []response.Evaluation{
@@ -200,15 +243,15 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					{
 						EntityID:   1,
 						EntityType: "type1",
-						EntityContext: map[string]string{
-							"k1": "v1",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
 						},
 					},
 					{
 						EntityID:   2,
 						EntityType: "type2",
-						EntityContext: map[string]string{
-							"k1": "v1",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
 						},
 					},
 				},
@@ -222,17 +265,17 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					{
 						EntityID:   1,
 						EntityType: "type1",
-						EntityContext: map[string]string{
-							"k1": "v1",
-							"k2": "v2",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
+							"k2": model.StringValue("v2"),
 						},
 					},
 					{
 						EntityID:   2,
 						EntityType: "type2",
-						EntityContext: map[string]string{
-							"k1": "v1",
-							"k2": "v2",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
+							"k2": model.StringValue("v2"),
 						},
 					},
 				}, nil
@@ -258,9 +301,9 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					Entity: response.Entity{
 						EntityID:   1,
 						EntityType: "type1",
-						EntityContext: map[string]string{
-							"k1": "v1",
-							"k2": "v2",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
+							"k2": model.StringValue("v2"),
 						},
 					},
 					Evaluations: []response.Evaluation{
@@ -277,9 +320,9 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 					Entity: response.Entity{
 						EntityID:   2,
 						EntityType: "type2",
-						EntityContext: map[string]string{
-							"k1": "v1",
-							"k2": "v2",
+						EntityContext: model.Context{
+							"k1": model.StringValue("v1"),
+							"k2": model.StringValue("v2"),
 						},
 					},
 					Evaluations: []response.Evaluation{
@@ -349,6 +392,89 @@ func (suite *EvaluationHandlerSuite) TestEvaluation() {
 	}
 }
 
+func (suite *EvaluationHandlerSuite) TestExplanation() {
+	result := true
+	failed := false
+	segment := 1
+	firstSeen := time.Date(2020, 12, 1, 9, 0, 0, 0, time.UTC)
+	lastSeen := time.Date(2020, 12, 10, 9, 0, 0, 0, time.UTC)
+
+	suite.fakeEntityRepo.findFunc = func(entities []model.Entity) ([]model.Entity, error) {
+		for i := range entities {
+			entities[i].FirstSeen, entities[i].LastSeen = &firstSeen, &lastSeen
+		}
+
+		return entities, nil
+	}
+
+	suite.fakeEvaluationEngine.explainFunc = func(flags []string, entity model.Entity) (*engine.Result, error) {
+		return &engine.Result{
+			Entity: entity,
+			Evaluations: []engine.Evaluation{
+				{Flag: "flag1", Variant: model.Variant{VariantKey: "control"}},
+			},
+			Explanations: []engine.Explanation{
+				{
+					Flag: "flag1",
+					Segments: []engine.SegmentExplanation{
+						{
+							Description: "segment 1",
+							Constraint: constraint.Trace{
+								Name:    constraint.IntersectionConstraintName,
+								Result:  &failed,
+								Missing: []string{"country"},
+							},
+						},
+						{
+							Description: "segment 2",
+							Constraint: constraint.Trace{
+								Name:       constraint.ContainsConstraintName,
+								Result:     &result,
+								Properties: model.Context{"os": model.StringValue("ios")},
+							},
+						},
+					},
+					Segment: &segment,
+					Variant: &model.Variant{VariantKey: "control"},
+				},
+			},
+		}, nil
+	}
+
+	data, err := json.Marshal(request.EvaluationRequest{
+		Entities:          []request.Entity{{EntityID: 123, EntityType: "user"}},
+		UseStoredContexts: true,
+		Explain:           true,
+	})
+	suite.NoError(err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/v1/evaluation", bytes.NewReader(data))
+
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var resp []response.EvaluationResponse
+
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Len(resp, 1)
+	suite.Len(resp[0].Explanations, 1)
+	suite.True(firstSeen.Equal(*resp[0].Entity.FirstSeen))
+	suite.True(lastSeen.Equal(*resp[0].Entity.LastSeen))
+
+	explanation := resp[0].Explanations[0]
+
+	suite.Equal("flag1", explanation.Flag)
+	suite.Equal(&segment, explanation.Segment)
+	suite.Equal("control", explanation.Variant.VariantKey)
+	suite.Len(explanation.Segments, 2)
+	suite.Equal([]string{"country"}, explanation.Segments[0].Constraint.Missing)
+	suite.Equal(&failed, explanation.Segments[0].Constraint.Result)
+	suite.Equal("ios", explanation.Segments[1].Constraint.Properties["os"].String())
+}
+
 func TestEvaluationHandlerSuite(t *testing.T) {
 	suite.Run(t, new(EvaluationHandlerSuite))
 }
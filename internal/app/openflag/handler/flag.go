@@ -3,10 +3,13 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
@@ -14,14 +17,25 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// ruleIdentifier is the identifier of the constraint that a segment rule compiles into.
+const ruleIdentifier = "rule"
+
 var (
 	// ErrInvalidJSONSyntax represents an error that we return when we can not parse the given json request.
 	ErrInvalidJSONSyntax = errors.New("invalid json syntax")
+	// ErrPrerequisiteCycle represents an error that we return when the prerequisites of a flag create a cycle.
+	ErrPrerequisiteCycle = errors.New("prerequisite cycle found")
+	// ErrHoldoutLayer represents an error that we return when we want to assign a flag to a holdout layer.
+	ErrHoldoutLayer = errors.New("flags can not be assigned to a holdout layer")
 )
 
 // FlagHandler represents a requests handler for flags.
 type FlagHandler struct {
-	FlagRepo model.FlagRepo
+	FlagRepo       model.FlagRepo
+	ListRepo       model.ListRepo
+	LayerRepo      model.LayerRepo
+	AssignmentRepo model.AssignmentRepo
+	AudienceRepo   model.AudienceRepo
 }
 
 // Create creates a flag using an http request.
@@ -38,6 +52,10 @@ func (f FlagHandler) Create(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	if err := f.check(req.Flag, "create"); err != nil {
+		return err
+	}
+
 	flag, err := f.flagFromRequest(req.Flag)
 	if err != nil {
 		logrus.Errorf("flag handler flag from request failed: %s", err.Error())
@@ -45,22 +63,24 @@ func (f FlagHandler) Create(c echo.Context) error {
 	}
 
 	if err := f.FlagRepo.Create(flag); err != nil {
-		if err == model.ErrDuplicateFlagFound {
+		if err == model.ErrDuplicateFlagFound || errors.Is(err, model.ErrLayerSliceOverlap) {
 			return echo.NewHTTPError(http.StatusConflict, err.Error())
 		}
 
+		if err == model.ErrLayerNotFound {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
 		logrus.Errorf("flag handler failed to create flag: %s", err.Error())
 
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	resp, err := f.responseFromFlag(flag)
-	if err != nil {
-		logrus.Errorf("flag handler response from flag failed: %s", err.Error())
-		return echo.NewHTTPError(http.StatusInternalServerError)
+	if flag.Sticky {
+		f.resetAssignments(flag.Flag, "create")
 	}
 
-	return c.JSON(http.StatusOK, *resp)
+	return f.respondWithWarnings(c, flag)
 }
 
 // Delete deletes a flag using an http request.
@@ -99,33 +119,75 @@ func (f FlagHandler) Update(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	if err := f.check(req.Flag, "update"); err != nil {
+		return err
+	}
+
 	flag, err := f.flagFromRequest(req.Flag)
 	if err != nil {
 		logrus.Errorf("flag handler flag from request failed: %s", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
+	experiment, err := f.startsExperiment(id, flag)
+	if err != nil {
+		logrus.Errorf("flag handler failed to find flag (update): %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
 	if err := f.FlagRepo.Update(id, flag); err != nil {
 		if err == model.ErrFlagNotFound {
 			return echo.NewHTTPError(http.StatusNotFound, err.Error())
 		}
 
-		if err == model.ErrInvalidFlagForUpdate {
+		if err == model.ErrInvalidFlagForUpdate || err == model.ErrLayerNotFound {
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 
+		if errors.Is(err, model.ErrLayerSliceOverlap) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
 		logrus.Errorf("flag handler failed to update flag: %s", err.Error())
 
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	resp, err := f.responseFromFlag(flag)
+	if experiment {
+		f.resetAssignments(flag.Flag, "update")
+	}
+
+	return f.respondWithWarnings(c, flag)
+}
+
+// Lint analyzes the segments of a flag without saving it using an http request. It reports the unreachable
+// segments, the contradictions, the tautologies and the duplicate constraints.
+func (f FlagHandler) Lint(c echo.Context) error {
+	req := request.LintFlagRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		logrus.Errorf("flag handler bind (lint): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, ErrInvalidJSONSyntax.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("flag handler validate (lint): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	flag, err := f.flagFromRequest(req.Flag)
 	if err != nil {
-		logrus.Errorf("flag handler response from flag failed: %s", err.Error())
+		logrus.Errorf("flag handler flag from request failed: %s", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	return c.JSON(http.StatusOK, *resp)
+	warnings, err := f.analyze(flag)
+	if err != nil {
+		logrus.Errorf("flag handler failed to analyze flag (lint): %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, response.Lint{Warnings: warnings})
 }
 
 // FindByID finds a flag by its given id using an http request.
@@ -248,6 +310,237 @@ func (f FlagHandler) FindFlags(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+// check checks the given flag against the other flags and returns an http error if the flag can not be saved.
+func (f FlagHandler) check(req request.Flag, action string) error {
+	if err := f.checkPrerequisites(req); err != nil {
+		if errors.Is(err, ErrPrerequisiteCycle) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		logrus.Errorf("flag handler failed to check prerequisites (%s): %s", action, err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if err := f.checkAudiences(req); err != nil {
+		if errors.Is(err, model.ErrAudienceNotFound) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		logrus.Errorf("flag handler failed to check audiences (%s): %s", action, err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if err := f.checkLayer(req); err != nil {
+		if errors.Is(err, model.ErrLayerNotFound) || errors.Is(err, ErrHoldoutLayer) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		logrus.Errorf("flag handler failed to check layer (%s): %s", action, err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// checkPrerequisites checks that the prerequisites of the given flag do not create a cycle with
+// the prerequisites of the existing flags.
+func (f FlagHandler) checkPrerequisites(req request.Flag) error {
+	dependencies := map[string][]string{}
+
+	for _, segment := range req.Segments {
+		constraints := map[string]model.Constraint{}
+
+		for identifier, c := range segment.Constraints {
+			constraints[identifier] = model.Constraint{Name: c.Name, Parameters: c.Parameters}
+		}
+
+		dependencies[req.Flag] = append(dependencies[req.Flag], constraint.PrerequisiteFlags(constraints)...)
+	}
+
+	if len(dependencies[req.Flag]) == 0 {
+		return nil
+	}
+
+	flags, err := f.FlagRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	for _, flag := range flags {
+		if flag.Flag == req.Flag {
+			continue
+		}
+
+		var segments []model.Segment
+
+		if err := json.Unmarshal([]byte(flag.Segments), &segments); err != nil {
+			return err
+		}
+
+		for _, segment := range segments {
+			dependencies[flag.Flag] = append(
+				dependencies[flag.Flag], constraint.PrerequisiteFlags(segment.Constraints)...,
+			)
+		}
+	}
+
+	for _, cycle := range constraint.FindPrerequisiteCycles(dependencies) {
+		for _, flag := range cycle {
+			if flag == req.Flag {
+				return fmt.Errorf("%w: %s", ErrPrerequisiteCycle, strings.Join(cycle, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkAudiences checks that the audiences that the segments of the given flag reference exist, because
+// the segments with an unknown audience never match.
+func (f FlagHandler) checkAudiences(req request.Flag) error {
+	var names []string
+
+	for _, segment := range req.Segments {
+		for _, c := range segment.Constraints {
+			if name, ok := constraint.ReferencedAudience(model.Constraint{Name: c.Name, Parameters: c.Parameters}); ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	audiences, err := f.AudienceRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	found := map[string]bool{}
+
+	for _, audience := range audiences {
+		found[audience.Name] = true
+	}
+
+	for _, name := range names {
+		if !found[name] {
+			return fmt.Errorf("%w: %s", model.ErrAudienceNotFound, name)
+		}
+	}
+
+	return nil
+}
+
+// checkLayer checks that the layer of the given flag exists and that it is not a holdout. The overlap of the slice
+// of the flag with the slices of the other flags in the layer is checked by the flag repo while saving the flag.
+func (f FlagHandler) checkLayer(req request.Flag) error {
+	if req.Layer == nil {
+		return nil
+	}
+
+	layer, err := f.LayerRepo.FindByName(req.Layer.Name)
+	if err != nil {
+		return err
+	}
+
+	if layer.Holdout > 0 {
+		return ErrHoldoutLayer
+	}
+
+	return nil
+}
+
+// startsExperiment checks whether updating the flag with the given id starts a new sticky experiment, i.e. the flag
+// becomes sticky. The assignments of the earlier experiments of the flag should be reset then.
+func (f FlagHandler) startsExperiment(id int64, flag *model.Flag) (bool, error) {
+	if !flag.Sticky {
+		return false, nil
+	}
+
+	previous, err := f.FlagRepo.FindByID(id)
+	if err != nil {
+		if err == model.ErrFlagNotFound {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	return !previous.Sticky, nil
+}
+
+// resetAssignments resets the sticky assignments of a flag that starts a new experiment. It is called after
+// the flag is saved, so a failed save does not touch the assignments of a live experiment. The flag is already
+// saved when the reset fails, so the failure is only logged and the assignments can be reset using the API.
+func (f FlagHandler) resetAssignments(flag string, action string) {
+	if err := f.AssignmentRepo.Reset(flag); err != nil {
+		logrus.Errorf("flag handler failed to reset assignments (%s): %s", action, err.Error())
+	}
+}
+
+// respondWithWarnings responds with the given flag that is saved and the warnings of its segments.
+func (f FlagHandler) respondWithWarnings(c echo.Context, flag *model.Flag) error {
+	resp, err := f.responseFromFlag(flag)
+	if err != nil {
+		logrus.Errorf("flag handler response from flag failed: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	warnings, err := f.analyze(flag)
+	if err != nil {
+		logrus.Errorf("flag handler failed to analyze flag: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	resp.Warnings = warnings
+
+	return c.JSON(http.StatusOK, *resp)
+}
+
+// analyze analyzes the segments of the given flag. The audiences are not resolved, so an audience constraint
+// is only compared with the other references to the same audience.
+func (f FlagHandler) analyze(flag *model.Flag) ([]response.Warning, error) {
+	var segments []model.Segment
+
+	if err := json.Unmarshal([]byte(flag.Segments), &segments); err != nil {
+		return nil, err
+	}
+
+	parser := constraint.Parser{}
+
+	trees := make([]model.Constraint, 0, len(segments))
+
+	for _, segment := range segments {
+		tree, err := parser.Parse(segment.Expression, segment.Constraints)
+		if err != nil {
+			return nil, err
+		}
+
+		trees = append(trees, *tree)
+	}
+
+	warnings, err := constraint.Analyze(trees)
+	if err != nil {
+		return nil, err
+	}
+
+	resps := []response.Warning{}
+
+	for _, warning := range warnings {
+		resps = append(resps, response.Warning{
+			Segment: warning.Segment,
+			Kind:    warning.Kind,
+			Message: warning.Message,
+		})
+	}
+
+	return resps, nil
+}
+
 func (f FlagHandler) flagFromRequest(req request.Flag) (*model.Flag, error) {
 	segments := []model.Segment{}
 
@@ -261,14 +554,40 @@ func (f FlagHandler) flagFromRequest(req request.Flag) (*model.Flag, error) {
 			}
 		}
 
+		var variants []model.WeightedVariant
+
+		for _, variant := range segment.Variants {
+			variants = append(variants, model.WeightedVariant{
+				Variant: model.Variant{
+					VariantKey:        variant.VariantKey,
+					VariantAttachment: variant.VariantAttachment,
+				},
+				Weight: variant.Weight,
+			})
+		}
+
+		expression := segment.Expression
+
+		if segment.Rule != "" {
+			c, err := constraint.CompileRule(segment.Rule)
+			if err != nil {
+				return nil, err
+			}
+
+			constraints = map[string]model.Constraint{ruleIdentifier: *c}
+			expression = ruleIdentifier
+		}
+
 		segments = append(segments, model.Segment{
 			Description: segment.Description,
 			Constraints: constraints,
-			Expression:  segment.Expression,
+			Expression:  expression,
+			Rule:        segment.Rule,
 			Variant: model.Variant{
 				VariantKey:        segment.Variant.VariantKey,
 				VariantAttachment: segment.Variant.VariantAttachment,
 			},
+			Variants: variants,
 		})
 	}
 
@@ -296,6 +615,13 @@ func (f FlagHandler) flagFromRequest(req request.Flag) (*model.Flag, error) {
 		Description: req.Description,
 		Flag:        req.Flag,
 		Segments:    segmentsStr,
+		Sticky:      req.Sticky,
+	}
+
+	if req.Layer != nil {
+		flag.Layer = &req.Layer.Name
+		flag.LayerLowerBound = req.Layer.LowerBound
+		flag.LayerUpperBound = req.Layer.UpperBound
 	}
 
 	return &flag, nil
@@ -310,7 +636,13 @@ func (f FlagHandler) responseFromFlag(flag *model.Flag) (*response.Flag, error)
 
 	segments := []response.Segment{}
 
+	var lists []string
+
 	for _, segment := range flagSegments {
+		for _, c := range segment.Constraints {
+			lists = append(lists, constraint.ReferencedLists(c)...)
+		}
+
 		constraints := map[string]response.Constraint{}
 
 		for identifier, constraint := range segment.Constraints {
@@ -320,14 +652,28 @@ func (f FlagHandler) responseFromFlag(flag *model.Flag) (*response.Flag, error)
 			}
 		}
 
+		var variants []response.WeightedVariant
+
+		for _, variant := range segment.Variants {
+			variants = append(variants, response.WeightedVariant{
+				Variant: response.Variant{
+					VariantKey:        variant.VariantKey,
+					VariantAttachment: variant.VariantAttachment,
+				},
+				Weight: variant.Weight,
+			})
+		}
+
 		segments = append(segments, response.Segment{
 			Description: segment.Description,
 			Constraints: constraints,
 			Expression:  segment.Expression,
+			Rule:        segment.Rule,
 			Variant: response.Variant{
 				VariantKey:        segment.Variant.VariantKey,
 				VariantAttachment: segment.Variant.VariantAttachment,
 			},
+			Variants: variants,
 		})
 	}
 
@@ -345,10 +691,28 @@ func (f FlagHandler) responseFromFlag(flag *model.Flag) (*response.Flag, error)
 		Description: flag.Description,
 		Flag:        flag.Flag,
 		Segments:    segments,
+		Sticky:      flag.Sticky,
 		CreatedAt:   flag.CreatedAt,
 		DeletedAt:   flag.DeletedAt,
 	}
 
+	if flag.Layer != nil {
+		resp.Layer = &response.LayerSlice{
+			Name:       *flag.Layer,
+			LowerBound: flag.LayerLowerBound,
+			UpperBound: flag.LayerUpperBound,
+		}
+	}
+
+	if len(lists) > 0 {
+		versions, err := f.ListRepo.Versions(lists)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Lists = versions
+	}
+
 	return &resp, nil
 }
 
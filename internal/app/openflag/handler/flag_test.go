@@ -28,7 +28,11 @@ type fakeFlagRepo struct {
 }
 
 func (f *fakeFlagRepo) Create(flag *model.Flag) error {
-	return f.repoError
+	if f.repoError != nil {
+		return f.repoError
+	}
+
+	return f.checkLayerSlice(flag)
 }
 
 func (f *fakeFlagRepo) Delete(id int64) error {
@@ -46,11 +50,35 @@ func (f *fakeFlagRepo) Update(id int64, flag *model.Flag) error {
 		return f.repoError
 	}
 
+	if err := f.checkLayerSlice(flag); err != nil {
+		return err
+	}
+
 	f.toBeUpdateID = id
 
 	return nil
 }
 
+func (f *fakeFlagRepo) checkLayerSlice(flag *model.Flag) error {
+	if flag.Layer == nil {
+		return nil
+	}
+
+	flags, err := f.FindByLayer(*flag.Layer)
+	if err != nil {
+		return err
+	}
+
+	for _, other := range flags {
+		if other.Flag != flag.Flag &&
+			flag.LayerLowerBound <= other.LayerUpperBound && other.LayerLowerBound <= flag.LayerUpperBound {
+			return fmt.Errorf("%w: %s", model.ErrLayerSliceOverlap, other.Flag)
+		}
+	}
+
+	return nil
+}
+
 func (f *fakeFlagRepo) FindByID(id int64) (*model.Flag, error) {
 	if f.repoError != nil {
 		return nil, f.repoError
@@ -90,13 +118,74 @@ func (f *fakeFlagRepo) FindByID(id int64) (*model.Flag, error) {
 		`,
 	}
 
-	if id == flag.ID {
+	listFlag := &model.Flag{
+		ID:          12,
+		Description: "description 3",
+		Flag:        "flag3",
+		Segments: `
+			[
+				{
+					"description": "segment 1",
+					"constraints": {
+						"A": {
+							"name": "list",
+							"parameters": {
+								"list": "beta.users"
+							}
+						}
+					},
+					"expression": "A",
+					"variant": {
+						"variant_key": "on"
+					}
+				}
+			]
+		`,
+	}
+
+	switch id {
+	case flag.ID:
 		return flag, nil
+	case listFlag.ID:
+		return listFlag, nil
 	}
 
 	return nil, model.ErrFlagNotFound
 }
 
+func (f *fakeFlagRepo) FindAll() ([]model.Flag, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	return []model.Flag{
+		{
+			ID:   11,
+			Flag: "payment",
+			Segments: `
+			[
+				{
+					"description": "segment 1",
+					"constraints": {
+						"A": {
+							"name": "prerequisite",
+							"parameters": {
+								"flag": "checkout",
+								"variants": ["on"]
+							}
+						}
+					},
+					"expression": "A",
+					"variant": {
+						"variant_key": "on"
+					}
+				}
+			]
+		`,
+		},
+	}, nil
+}
+
 func (f *fakeFlagRepo) FindByTag(tag string) ([]model.Flag, error) {
 	if f.repoError != nil {
 		return nil, f.repoError
@@ -113,6 +202,20 @@ func (f *fakeFlagRepo) FindByFlag(flag string) ([]model.Flag, error) {
 	return []model.Flag{}, nil
 }
 
+func (f *fakeFlagRepo) FindByLayer(layer string) ([]model.Flag, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	if layer != "checkout" {
+		return []model.Flag{}, nil
+	}
+
+	return []model.Flag{
+		{ID: 13, Flag: "checkout.a", Segments: `[]`, Layer: &layer, LayerLowerBound: 0, LayerUpperBound: 49},
+	}, nil
+}
+
 func (f *fakeFlagRepo) FindFlags(offset int, limit int, t time.Time) ([]model.Flag, error) {
 	if f.repoError != nil {
 		return nil, f.repoError
@@ -132,15 +235,39 @@ func (suite *FlagHandlerSuite) SetupSuite() {
 
 	suite.fakeFlagRepo = &fakeFlagRepo{}
 
-	suite.engine.POST("/v1/flag", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo}.Create)
+	suite.engine.POST(
+		"/v1/flag", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo, LayerRepo: &fakeLayerRepo{}}.Create,
+	)
 	suite.engine.DELETE("/v1/flag/:id", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo}.Delete)
-	suite.engine.PUT("/v1/flag/:id", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo}.Update)
-	suite.engine.GET("/v1/flag/:id", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo}.FindByID)
+	suite.engine.PUT(
+		"/v1/flag/:id", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo, LayerRepo: &fakeLayerRepo{}}.Update,
+	)
+	suite.engine.GET(
+		"/v1/flag/:id", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo, ListRepo: &fakeListRepo{}}.FindByID,
+	)
+	suite.engine.POST("/v1/flag/lint", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo}.Lint)
 	suite.engine.POST("/v1/flag/tag", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo}.FindByTag)
 	suite.engine.POST("/v1/flag/history", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo}.FindByFlag)
 	suite.engine.POST("/v1/flags", handler.FlagHandler{FlagRepo: suite.fakeFlagRepo}.FindFlags)
 }
 
+func (suite *FlagHandlerSuite) layerFlag(flag string, layer string, lower int, upper int) request.Flag {
+	return request.Flag{
+		Description: "description",
+		Flag:        flag,
+		Segments: []request.Segment{
+			{
+				Description: "description",
+				Rule:        `country == "DE"`,
+				Variant: request.Variant{
+					VariantKey: "on",
+				},
+			},
+		},
+		Layer: &request.LayerSlice{Name: layer, LowerBound: lower, UpperBound: upper},
+	}
+}
+
 func (suite *FlagHandlerSuite) TestCreateFlag() {
 	cases := []struct {
 		name      string
@@ -209,6 +336,73 @@ func (suite *FlagHandlerSuite) TestCreateFlag() {
 			repoError: nil,
 			status:    http.StatusOK,
 		},
+		{
+			name: "successfully create flag with rule",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "flag",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Rule:        `country in ["IR", "DE"] and age >= 18`,
+							Variant: request.Variant{
+								VariantKey: "on",
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusOK,
+		},
+		{
+			name: "failed to create flag with rule and expression",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "flag",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.LessThanConstraintName,
+									Parameters: json.RawMessage(`{"value": 10}`),
+								},
+							},
+							Expression: "A",
+							Rule:       `age >= 18`,
+							Variant: request.Variant{
+								VariantKey: "on",
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusBadRequest,
+		},
+		{
+			name: "failed to create flag with invalid rule",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "flag",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Rule:        `age >= `,
+							Variant: request.Variant{
+								VariantKey: "on",
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusBadRequest,
+		},
 		{
 			name: "failed to create flag 1",
 			req: request.CreateFlagRequest{
@@ -457,6 +651,226 @@ func (suite *FlagHandlerSuite) TestCreateFlag() {
 			repoError: model.ErrDuplicateFlagFound,
 			status:    http.StatusConflict,
 		},
+		{
+			name: "successfully create flag with weighted variants",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "flag",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.RolloutConstraintName,
+									Parameters: json.RawMessage(`{"percentage": 10}`),
+								},
+							},
+							Expression: "A",
+							Variants: []request.WeightedVariant{
+								{Variant: request.Variant{VariantKey: "control"}, Weight: 50},
+								{Variant: request.Variant{VariantKey: "treatment.a"}, Weight: 30},
+								{Variant: request.Variant{VariantKey: "treatment.b"}, Weight: 20},
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusOK,
+		},
+		{
+			name: "failed to create flag with invalid variant weights",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "flag",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.RolloutConstraintName,
+									Parameters: json.RawMessage(`{"percentage": 10}`),
+								},
+							},
+							Expression: "A",
+							Variants: []request.WeightedVariant{
+								{Variant: request.Variant{VariantKey: "control"}, Weight: 50},
+								{Variant: request.Variant{VariantKey: "treatment"}, Weight: 40},
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusBadRequest,
+		},
+		{
+			name: "failed to create flag with both variant and variants",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "flag",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.RolloutConstraintName,
+									Parameters: json.RawMessage(`{"percentage": 10}`),
+								},
+							},
+							Expression: "A",
+							Variant: request.Variant{
+								VariantKey: "on",
+							},
+							Variants: []request.WeightedVariant{
+								{Variant: request.Variant{VariantKey: "control"}, Weight: 50},
+								{Variant: request.Variant{VariantKey: "treatment"}, Weight: 50},
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusBadRequest,
+		},
+		{
+			name: "failed to create flag with duplicate variants",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "flag",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.RolloutConstraintName,
+									Parameters: json.RawMessage(`{"percentage": 10}`),
+								},
+							},
+							Expression: "A",
+							Variants: []request.WeightedVariant{
+								{Variant: request.Variant{VariantKey: "control"}, Weight: 50},
+								{Variant: request.Variant{VariantKey: "control"}, Weight: 50},
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusBadRequest,
+		},
+		{
+			name: "successfully create flag with prerequisite",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "checkout",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.PrerequisiteConstraintName,
+									Parameters: json.RawMessage(`{"flag": "login", "variants": ["on"]}`),
+								},
+							},
+							Expression: "A",
+							Variant: request.Variant{
+								VariantKey: "on",
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusOK,
+		},
+		{
+			name: "failed to create flag with prerequisite cycle",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "checkout",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.PrerequisiteConstraintName,
+									Parameters: json.RawMessage(`{"flag": "payment", "variants": ["on"]}`),
+								},
+							},
+							Expression: "A",
+							Variant: request.Variant{
+								VariantKey: "on",
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:   "successfully create flag in a layer",
+			req:    request.CreateFlagRequest{Flag: suite.layerFlag("checkout.b", "checkout", 50, 99)},
+			status: http.StatusOK,
+		},
+		{
+			name:   "successfully resize the layer slice of a flag",
+			req:    request.CreateFlagRequest{Flag: suite.layerFlag("checkout.a", "checkout", 0, 59)},
+			status: http.StatusOK,
+		},
+		{
+			name:   "failed to create flag with overlapping layer slice",
+			req:    request.CreateFlagRequest{Flag: suite.layerFlag("checkout.b", "checkout", 40, 60)},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "failed to create flag in a holdout layer",
+			req:    request.CreateFlagRequest{Flag: suite.layerFlag("checkout.b", "holdout", 0, 99)},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "failed to create flag in an unknown layer",
+			req:    request.CreateFlagRequest{Flag: suite.layerFlag("checkout.b", "unknown", 0, 99)},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "failed to create flag with invalid layer slice",
+			req:    request.CreateFlagRequest{Flag: suite.layerFlag("checkout.b", "checkout", 60, 40)},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "failed to create flag with itself as prerequisite",
+			req: request.CreateFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "checkout",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.PrerequisiteConstraintName,
+									Parameters: json.RawMessage(`{"flag": "checkout", "variants": ["on"]}`),
+								},
+							},
+							Expression: "A",
+							Variant: request.Variant{
+								VariantKey: "on",
+							},
+						},
+					},
+				},
+			},
+			repoError: nil,
+			status:    http.StatusBadRequest,
+		},
 	}
 
 	for i := range cases {
@@ -479,6 +893,352 @@ func (suite *FlagHandlerSuite) TestCreateFlag() {
 	}
 }
 
+func (suite *FlagHandlerSuite) ruleFlag(rules ...string) request.Flag {
+	flag := request.Flag{Description: "description", Flag: "flag"}
+
+	for _, rule := range rules {
+		flag.Segments = append(flag.Segments, request.Segment{
+			Description: "description",
+			Rule:        rule,
+			Variant: request.Variant{
+				VariantKey: "on",
+			},
+		})
+	}
+
+	return flag
+}
+
+func (suite *FlagHandlerSuite) TestLintFlag() {
+	cases := []struct {
+		name     string
+		req      request.LintFlagRequest
+		status   int
+		warnings []response.Warning
+	}{
+		{
+			name:     "successfully lint flag without warnings",
+			req:      request.LintFlagRequest{Flag: suite.ruleFlag(`country == "IR"`, `always`)},
+			status:   http.StatusOK,
+			warnings: []response.Warning{},
+		},
+		{
+			name:   "successfully lint flag with warnings",
+			req:    request.LintFlagRequest{Flag: suite.ruleFlag(`always`, `age < 5 and age > 10`)},
+			status: http.StatusOK,
+			warnings: []response.Warning{
+				{Segment: 1, Kind: constraint.ContradictionWarning, Message: "age < 5 and age > 10 never matches"},
+				{
+					Segment: 1,
+					Kind:    constraint.UnreachableSegmentWarning,
+					Message: "segment is unreachable because segment 0 always matches",
+				},
+			},
+		},
+		{
+			name: "successfully lint flag with constraints and expression",
+			req: request.LintFlagRequest{
+				Flag: request.Flag{
+					Description: "description",
+					Flag:        "flag",
+					Segments: []request.Segment{
+						{
+							Description: "description",
+							Constraints: map[string]request.Constraint{
+								"A": {
+									Name:       constraint.ContainsConstraintName,
+									Parameters: json.RawMessage(`{"values": ["IR"], "property": "country"}`),
+								},
+							},
+							Expression: fmt.Sprintf("A %s A", constraint.UnionConstraintName),
+							Variant: request.Variant{
+								VariantKey: "on",
+							},
+						},
+					},
+				},
+			},
+			status: http.StatusOK,
+			warnings: []response.Warning{
+				{Segment: 0, Kind: constraint.DuplicateConstraintWarning, Message: `duplicate constraint country == "IR"`},
+			},
+		},
+		{
+			name:   "failed to lint invalid flag",
+			req:    request.LintFlagRequest{Flag: suite.ruleFlag(`country ==`)},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+
+		suite.Run(tc.name, func() {
+			data, err := json.Marshal(tc.req)
+			suite.NoError(err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/v1/flag/lint", bytes.NewReader(data))
+
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+
+			if tc.status == http.StatusOK {
+				var resp response.Lint
+
+				suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+				suite.Equal(tc.warnings, resp.Warnings)
+			}
+		})
+	}
+}
+
+func (suite *FlagHandlerSuite) TestCreateFlagWarnings() {
+	suite.fakeFlagRepo.repoError = nil
+
+	data, err := json.Marshal(request.CreateFlagRequest{Flag: suite.ruleFlag(`always`, `country == "IR"`)})
+	suite.NoError(err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/v1/flag", bytes.NewReader(data))
+
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var resp response.Flag
+
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Equal([]response.Warning{
+		{
+			Segment: 1,
+			Kind:    constraint.UnreachableSegmentWarning,
+			Message: "segment is unreachable because segment 0 always matches",
+		},
+	}, resp.Warnings)
+}
+
+func (suite *FlagHandlerSuite) TestStickyFlag() {
+	suite.fakeFlagRepo.repoError = nil
+
+	assignmentRepo := &fakeAssignmentRepo{}
+
+	h := handler.FlagHandler{FlagRepo: suite.fakeFlagRepo, LayerRepo: &fakeLayerRepo{}, AssignmentRepo: assignmentRepo}
+
+	e := echo.New()
+	e.POST("/v1/flag", h.Create)
+	e.PUT("/v1/flag/:id", h.Update)
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		path   string
+		sticky bool
+		flags  []string
+	}{
+		{
+			name:   "reset assignments of new sticky flag",
+			method: "POST",
+			path:   "/v1/flag",
+			sticky: true,
+			flags:  []string{"flag"},
+		},
+		{name: "keep assignments of new flag", method: "POST", path: "/v1/flag", sticky: false},
+		{
+			name:   "reset assignments when flag becomes sticky",
+			method: "PUT",
+			path:   "/v1/flag/10",
+			sticky: true,
+			flags:  []string{"flag"},
+		},
+		{name: "keep assignments when flag is not sticky", method: "PUT", path: "/v1/flag/10", sticky: false},
+	} {
+		assignmentRepo.flags = nil
+
+		flag := suite.ruleFlag(`country == "IR"`)
+		flag.Sticky = tc.sticky
+
+		data, err := json.Marshal(flag)
+		suite.NoError(err)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewReader(data))
+
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		e.ServeHTTP(w, req)
+		suite.Equal(http.StatusOK, w.Code, tc.name)
+		suite.Equal(tc.flags, assignmentRepo.flags, tc.name)
+
+		var resp response.Flag
+
+		suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal(tc.sticky, resp.Sticky, tc.name)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		path       string
+		repoError  error
+		resetError error
+		status     int
+	}{
+		{
+			name:      "keep assignments of existing flag on duplicate create",
+			method:    "POST",
+			path:      "/v1/flag",
+			repoError: model.ErrDuplicateFlagFound,
+			status:    http.StatusConflict,
+		},
+		{
+			name:      "keep assignments when update fails",
+			method:    "PUT",
+			path:      "/v1/flag/10",
+			repoError: model.ErrFlagNotFound,
+			status:    http.StatusNotFound,
+		},
+		{
+			name:       "create flag when reset fails",
+			method:     "POST",
+			path:       "/v1/flag",
+			resetError: errors.New("failed to reset assignments"),
+			status:     http.StatusOK,
+		},
+	} {
+		suite.fakeFlagRepo.repoError = tc.repoError
+		assignmentRepo.repoError = tc.resetError
+		assignmentRepo.flags = nil
+
+		flag := suite.ruleFlag(`country == "IR"`)
+		flag.Sticky = true
+
+		data, err := json.Marshal(flag)
+		suite.NoError(err)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewReader(data))
+
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		e.ServeHTTP(w, req)
+		suite.Equal(tc.status, w.Code, tc.name)
+		suite.Nil(assignmentRepo.flags, tc.name)
+	}
+
+	suite.fakeFlagRepo.repoError = nil
+	assignmentRepo.repoError = nil
+
+	for _, sticky := range []bool{true, false} {
+		flag := suite.ruleFlag()
+		flag.Sticky = sticky
+		flag.Segments = []request.Segment{
+			{
+				Description: "description",
+				Constraints: map[string]request.Constraint{
+					"A": {Name: constraint.AlwaysConstraintName, Parameters: json.RawMessage(`{}`)},
+					"B": {
+						Name:       constraint.FrequencyCapConstraintName,
+						Parameters: json.RawMessage(`{"limit": 3, "window": "24h"}`),
+					},
+				},
+				Expression: "A ∩ B",
+				Variant:    request.Variant{VariantKey: "on"},
+			},
+		}
+
+		data, err := json.Marshal(flag)
+		suite.NoError(err)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/v1/flag", bytes.NewReader(data))
+
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		e.ServeHTTP(w, req)
+
+		if sticky {
+			suite.Equal(http.StatusBadRequest, w.Code, "sticky flags should not have frequency caps")
+		} else {
+			suite.Equal(http.StatusOK, w.Code)
+		}
+	}
+}
+
+func (suite *FlagHandlerSuite) TestFlagAudiences() {
+	suite.fakeFlagRepo.repoError = nil
+
+	audienceRepo := &fakeAudienceRepo{}
+
+	h := handler.FlagHandler{FlagRepo: suite.fakeFlagRepo, LayerRepo: &fakeLayerRepo{}, AudienceRepo: audienceRepo}
+
+	e := echo.New()
+	e.POST("/v1/flag", h.Create)
+	e.PUT("/v1/flag/:id", h.Update)
+
+	for _, tc := range []struct {
+		name      string
+		method    string
+		path      string
+		audience  string
+		repoError error
+		status    int
+	}{
+		{name: "create flag with audience", method: "POST", path: "/v1/flag", audience: "employees", status: http.StatusOK},
+		{
+			name:     "create flag with unknown audience",
+			method:   "POST",
+			path:     "/v1/flag",
+			audience: "unknown",
+			status:   http.StatusBadRequest,
+		},
+		{name: "update flag with audience", method: "PUT", path: "/v1/flag/10", audience: "testers", status: http.StatusOK},
+		{
+			name:     "update flag with unknown audience",
+			method:   "PUT",
+			path:     "/v1/flag/10",
+			audience: "unknown",
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:      "failed to find audiences",
+			method:    "POST",
+			path:      "/v1/flag",
+			audience:  "employees",
+			repoError: errors.New("failed to find audiences"),
+			status:    http.StatusInternalServerError,
+		},
+	} {
+		audienceRepo.repoError = tc.repoError
+
+		flag := suite.ruleFlag()
+		flag.Segments = []request.Segment{
+			{
+				Description: "description",
+				Constraints: map[string]request.Constraint{
+					"A": {Name: constraint.AudienceConstraintName, Parameters: json.RawMessage(`{"audience": "` + tc.audience + `"}`)},
+				},
+				Expression: "A",
+				Variant:    request.Variant{VariantKey: "on"},
+			},
+		}
+
+		data, err := json.Marshal(flag)
+		suite.NoError(err)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewReader(data))
+
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		e.ServeHTTP(w, req)
+		suite.Equal(tc.status, w.Code, tc.name)
+	}
+}
+
 func (suite *FlagHandlerSuite) TestDeleteFlag() {
 	cases := []struct {
 		name      string
@@ -630,6 +1390,18 @@ func (suite *FlagHandlerSuite) TestUpdateFlag() {
 			repoError: model.ErrInvalidFlagForUpdate,
 			status:    http.StatusBadRequest,
 		},
+		{
+			name:   "successfully resize the layer slice of a flag",
+			flagID: "13",
+			req:    request.UpdateFlagRequest{Flag: suite.layerFlag("checkout.a", "checkout", 0, 59)},
+			status: http.StatusOK,
+		},
+		{
+			name:   "failed to update flag with overlapping layer slice",
+			flagID: "14",
+			req:    request.UpdateFlagRequest{Flag: suite.layerFlag("checkout.b", "checkout", 40, 60)},
+			status: http.StatusConflict,
+		},
 	}
 
 	for i := range cases {
@@ -695,6 +1467,33 @@ func (suite *FlagHandlerSuite) TestFindByID() {
 				},
 			},
 		},
+		{
+			name:      "successfully find flag by its id 2",
+			flagID:    "12",
+			status:    http.StatusOK,
+			repoError: nil,
+			resp: response.Flag{
+				ID:          12,
+				Description: "description 3",
+				Flag:        "flag3",
+				Segments: []response.Segment{
+					{
+						Description: "segment 1",
+						Constraints: map[string]response.Constraint{
+							"A": {
+								Name:       constraint.ListConstraintName,
+								Parameters: json.RawMessage(`{"list": "beta.users"}`),
+							},
+						},
+						Expression: "A",
+						Variant: response.Variant{
+							VariantKey: "on",
+						},
+					},
+				},
+				Lists: map[string]int64{"beta.users": 1},
+			},
+		},
 		{
 			name:      "failed to find flag by its id 1",
 			flagID:    "11",
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrLayerInUse represents an error that we return when we want to delete a layer or make it a holdout
+	// while a flag is assigned to it.
+	ErrLayerInUse = errors.New("layer is used by a flag")
+)
+
+// LayerHandler represents a requests handler for layers.
+type LayerHandler struct {
+	LayerRepo model.LayerRepo
+	FlagRepo  model.FlagRepo
+}
+
+// Create creates a layer using an http request.
+func (l LayerHandler) Create(c echo.Context) error {
+	req := request.CreateLayerRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		logrus.Errorf("layer handler bind (create): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, ErrInvalidJSONSyntax.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("layer handler validate (create): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	layer := l.layerFromRequest(req.Layer)
+
+	if err := l.LayerRepo.Create(layer); err != nil {
+		if err == model.ErrDuplicateLayerFound {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
+		logrus.Errorf("layer handler failed to create layer: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, l.responseFromLayer(layer))
+}
+
+// Delete deletes a layer using an http request. It refuses to delete layers that flags are still assigned to.
+func (l LayerHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		logrus.Errorf("layer handler param (delete): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	layer, err := l.LayerRepo.FindByID(id)
+	if err != nil {
+		if err == model.ErrLayerNotFound {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		logrus.Errorf("layer handler failed to find layer for delete: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if err := l.checkUnused(layer.Name); err != nil {
+		if errors.Is(err, ErrLayerInUse) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
+		logrus.Errorf("layer handler failed to find layer flags: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	if err := l.LayerRepo.Delete(id); err != nil {
+		logrus.Errorf("layer handler failed to delete layer: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Update updates a layer using an http request. A layer can only become a holdout when no flag is assigned to it.
+func (l LayerHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		logrus.Errorf("layer handler param (update): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	req := request.UpdateLayerRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		logrus.Errorf("layer handler bind data (update): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, ErrInvalidJSONSyntax.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("layer handler validate (update): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if req.Holdout > 0 {
+		if err := l.checkUnused(req.Name); err != nil {
+			if errors.Is(err, ErrLayerInUse) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			}
+
+			logrus.Errorf("layer handler failed to find layer flags: %s", err.Error())
+
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+	}
+
+	layer := l.layerFromRequest(req.Layer)
+
+	if err := l.LayerRepo.Update(id, layer); err != nil {
+		if err == model.ErrLayerNotFound {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		if err == model.ErrInvalidLayerForUpdate {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		logrus.Errorf("layer handler failed to update layer: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, l.responseFromLayer(layer))
+}
+
+// FindByID finds a layer by its given id using an http request.
+func (l LayerHandler) FindByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 0, 64)
+	if err != nil {
+		logrus.Errorf("layer handler param (find by id): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	layer, err := l.LayerRepo.FindByID(id)
+	if err != nil {
+		if err == model.ErrLayerNotFound {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		logrus.Errorf("layer handler failed to find by id: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, l.responseFromLayer(layer))
+}
+
+// FindAll finds all layers using an http request.
+func (l LayerHandler) FindAll(c echo.Context) error {
+	layers, err := l.LayerRepo.FindAll()
+	if err != nil {
+		logrus.Errorf("layer handler failed to find all: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	resps := []response.Layer{}
+
+	for _, layer := range layers {
+		la := layer
+		resps = append(resps, l.responseFromLayer(&la))
+	}
+
+	return c.JSON(http.StatusOK, resps)
+}
+
+// checkUnused checks that no flag is assigned to the given layer.
+func (l LayerHandler) checkUnused(name string) error {
+	flags, err := l.FlagRepo.FindByLayer(name)
+	if err != nil {
+		return err
+	}
+
+	if len(flags) > 0 {
+		return fmt.Errorf("%w: %s", ErrLayerInUse, flags[0].Flag)
+	}
+
+	return nil
+}
+
+func (l LayerHandler) layerFromRequest(req request.Layer) *model.Layer {
+	return &model.Layer{
+		Name:        req.Name,
+		Description: req.Description,
+		Property:    req.Property,
+		Holdout:     req.Holdout,
+	}
+}
+
+func (l LayerHandler) responseFromLayer(layer *model.Layer) response.Layer {
+	return response.Layer{
+		ID:          layer.ID,
+		Name:        layer.Name,
+		Description: layer.Description,
+		Property:    layer.Property,
+		Holdout:     layer.Holdout,
+		CreatedAt:   layer.CreatedAt,
+		UpdatedAt:   layer.UpdatedAt,
+	}
+}
@@ -0,0 +1,346 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/handler"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeLayerRepo struct {
+	repoError    error
+	toBeDeleteID int64
+	toBeUpdateID int64
+}
+
+func (f *fakeLayerRepo) layers() []model.Layer {
+	return []model.Layer{
+		{ID: 10, Name: "checkout", Description: "description 1"},
+		{ID: 11, Name: "holdout", Description: "description 2", Holdout: 5},
+		{ID: 12, Name: "pricing", Description: "description 3", Property: "company_id"},
+	}
+}
+
+func (f *fakeLayerRepo) Create(layer *model.Layer) error {
+	return f.repoError
+}
+
+func (f *fakeLayerRepo) Delete(id int64) error {
+	if f.repoError != nil {
+		return f.repoError
+	}
+
+	f.toBeDeleteID = id
+
+	return nil
+}
+
+func (f *fakeLayerRepo) Update(id int64, layer *model.Layer) error {
+	if f.repoError != nil {
+		return f.repoError
+	}
+
+	f.toBeUpdateID = id
+
+	return nil
+}
+
+func (f *fakeLayerRepo) FindAll() ([]model.Layer, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	return f.layers(), nil
+}
+
+func (f *fakeLayerRepo) FindByID(id int64) (*model.Layer, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	for _, layer := range f.layers() {
+		if layer.ID == id {
+			l := layer
+			return &l, nil
+		}
+	}
+
+	return nil, model.ErrLayerNotFound
+}
+
+func (f *fakeLayerRepo) FindByName(name string) (*model.Layer, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	for _, layer := range f.layers() {
+		if layer.Name == name {
+			l := layer
+			return &l, nil
+		}
+	}
+
+	return nil, model.ErrLayerNotFound
+}
+
+type LayerHandlerSuite struct {
+	suite.Suite
+	engine        *echo.Echo
+	fakeLayerRepo *fakeLayerRepo
+}
+
+func (suite *LayerHandlerSuite) SetupSuite() {
+	suite.engine = echo.New()
+
+	suite.fakeLayerRepo = &fakeLayerRepo{}
+
+	h := handler.LayerHandler{LayerRepo: suite.fakeLayerRepo, FlagRepo: &fakeFlagRepo{}}
+
+	suite.engine.POST("/v1/layer", h.Create)
+	suite.engine.DELETE("/v1/layer/:id", h.Delete)
+	suite.engine.PUT("/v1/layer/:id", h.Update)
+	suite.engine.GET("/v1/layer/:id", h.FindByID)
+	suite.engine.GET("/v1/layers", h.FindAll)
+}
+
+func (suite *LayerHandlerSuite) TestCreateLayer() {
+	cases := []struct {
+		name      string
+		req       request.Layer
+		status    int
+		repoError error
+	}{
+		{
+			name:   "successfully create layer",
+			req:    request.Layer{Name: "checkout", Description: "description"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "successfully create holdout layer",
+			req:    request.Layer{Name: "holdout", Description: "description", Holdout: 5},
+			status: http.StatusOK,
+		},
+		{
+			name:   "failed to create layer with invalid name",
+			req:    request.Layer{Name: "Checkout", Description: "description"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "failed to create layer with invalid property",
+			req:    request.Layer{Name: "checkout", Description: "description", Property: "company..id"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "failed to create layer with invalid holdout",
+			req:    request.Layer{Name: "holdout", Description: "description", Holdout: 101},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:      "failed to create duplicate layer",
+			req:       request.Layer{Name: "checkout", Description: "description"},
+			status:    http.StatusConflict,
+			repoError: model.ErrDuplicateLayerFound,
+		},
+		{
+			name:      "failed to create layer with repo error",
+			req:       request.Layer{Name: "checkout", Description: "description"},
+			status:    http.StatusInternalServerError,
+			repoError: errors.New("fake layer repo error"),
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			suite.fakeLayerRepo.repoError = tc.repoError
+
+			data, err := json.Marshal(request.CreateLayerRequest{Layer: tc.req})
+			suite.NoError(err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/v1/layer", bytes.NewReader(data))
+
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+
+			if tc.status == http.StatusOK {
+				resp := response.Layer{}
+
+				suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+				suite.Equal(tc.req.Name, resp.Name)
+				suite.Equal(tc.req.Holdout, resp.Holdout)
+			}
+		})
+	}
+}
+
+func (suite *LayerHandlerSuite) TestDeleteLayer() {
+	cases := []struct {
+		name      string
+		layerID   string
+		status    int
+		repoError error
+	}{
+		{
+			name:    "successfully delete layer",
+			layerID: "12",
+			status:  http.StatusNoContent,
+		},
+		{
+			name:    "failed to delete layer that a flag is assigned to",
+			layerID: "10",
+			status:  http.StatusConflict,
+		},
+		{
+			name:    "failed to delete layer that does not exist",
+			layerID: "13",
+			status:  http.StatusNotFound,
+		},
+		{
+			name:    "failed to delete layer with invalid id",
+			layerID: "12s",
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:      "failed to delete layer with repo error",
+			layerID:   "12",
+			status:    http.StatusInternalServerError,
+			repoError: errors.New("fake layer repo error"),
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			suite.fakeLayerRepo.repoError = tc.repoError
+			suite.fakeLayerRepo.toBeDeleteID = 0
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/v1/layer/%s", tc.layerID), nil)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+
+			if tc.status == http.StatusNoContent {
+				suite.Equal(tc.layerID, fmt.Sprintf("%d", suite.fakeLayerRepo.toBeDeleteID))
+			} else {
+				suite.Equal(int64(0), suite.fakeLayerRepo.toBeDeleteID)
+			}
+		})
+	}
+}
+
+func (suite *LayerHandlerSuite) TestUpdateLayer() {
+	cases := []struct {
+		name      string
+		layerID   string
+		req       request.Layer
+		status    int
+		repoError error
+	}{
+		{
+			name:    "successfully update layer",
+			layerID: "10",
+			req:     request.Layer{Name: "checkout", Description: "description", Property: "device.id"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "successfully make a layer without flags a holdout",
+			layerID: "12",
+			req:     request.Layer{Name: "pricing", Description: "description", Holdout: 10},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "failed to make a layer with flags a holdout",
+			layerID: "10",
+			req:     request.Layer{Name: "checkout", Description: "description", Holdout: 10},
+			status:  http.StatusConflict,
+		},
+		{
+			name:      "failed to update layer that does not exist",
+			layerID:   "13",
+			req:       request.Layer{Name: "others", Description: "description"},
+			status:    http.StatusNotFound,
+			repoError: model.ErrLayerNotFound,
+		},
+		{
+			name:      "failed to update layer name",
+			layerID:   "12",
+			req:       request.Layer{Name: "others", Description: "description"},
+			status:    http.StatusBadRequest,
+			repoError: model.ErrInvalidLayerForUpdate,
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			suite.fakeLayerRepo.repoError = tc.repoError
+			suite.fakeLayerRepo.toBeUpdateID = 0
+
+			data, err := json.Marshal(request.UpdateLayerRequest{Layer: tc.req})
+			suite.NoError(err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/v1/layer/%s", tc.layerID), bytes.NewReader(data))
+
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+
+			if tc.status == http.StatusOK {
+				suite.Equal(tc.layerID, fmt.Sprintf("%d", suite.fakeLayerRepo.toBeUpdateID))
+			}
+		})
+	}
+}
+
+func (suite *LayerHandlerSuite) TestFindLayers() {
+	suite.fakeLayerRepo.repoError = nil
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/v1/layer/12", nil)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+
+	layer := response.Layer{}
+
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &layer))
+	suite.Equal("pricing", layer.Name)
+	suite.Equal("company_id", layer.Property)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v1/layer/13", nil)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v1/layers", nil)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var layers []response.Layer
+
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &layers))
+	suite.Len(layers, 3)
+}
+
+func TestLayerHandlerSuite(t *testing.T) {
+	suite.Run(t, new(LayerHandlerSuite))
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	mimeTextCSV = "text/csv"
+)
+
+// ListHandler represents a requests handler for lists.
+type ListHandler struct {
+	ListRepo model.ListRepo
+}
+
+// Upload uploads a list using an http request. The request body contains the IDs of the list,
+// either as CSV (text/csv) or one ID per line. Uploading a list replaces its previous items.
+func (l ListHandler) Upload(c echo.Context) error {
+	ids, err := readListItems(c.Request().Body, c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		logrus.Errorf("list handler read (upload): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	req := request.UploadListRequest{Name: c.Param("name"), IDs: ids}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("list handler validate (upload): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	list, err := l.ListRepo.Upload(req.Name, req.IDs)
+	if err != nil {
+		logrus.Errorf("list handler failed to upload list: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, responseFromList(list))
+}
+
+// Find finds a list by its given name using an http request.
+func (l ListHandler) Find(c echo.Context) error {
+	list, err := l.ListRepo.Find(c.Param("name"))
+	if err != nil {
+		if err == model.ErrListNotFound {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+
+		logrus.Errorf("list handler failed to find list: %s", err.Error())
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, responseFromList(list))
+}
+
+// readListItems reads the non-empty items of a CSV or newline separated body.
+func readListItems(body io.Reader, contentType string) ([]string, error) {
+	var ids []string
+
+	if strings.HasPrefix(contentType, mimeTextCSV) {
+		reader := csv.NewReader(body)
+		reader.FieldsPerRecord = -1
+
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			for _, field := range record {
+				if id := strings.TrimSpace(field); id != "" {
+					ids = append(ids, id)
+				}
+			}
+		}
+
+		return ids, nil
+	}
+
+	scanner := bufio.NewScanner(body)
+
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, scanner.Err()
+}
+
+func responseFromList(list *model.List) response.List {
+	return response.List{
+		Name:    list.Name,
+		Version: list.Version,
+		Size:    list.Size,
+	}
+}
@@ -0,0 +1,186 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/handler"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeListRepo struct {
+	repoError error
+	uploaded  []string
+}
+
+func (f *fakeListRepo) Upload(name string, ids []string) (*model.List, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	f.uploaded = ids
+
+	return &model.List{Name: name, Version: 2, Size: int64(len(ids))}, nil
+}
+
+func (f *fakeListRepo) Find(name string) (*model.List, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	if name != "beta.users" {
+		return nil, model.ErrListNotFound
+	}
+
+	return &model.List{Name: name, Version: 1, Size: 3}, nil
+}
+
+func (f *fakeListRepo) Versions(names []string) (map[string]int64, error) {
+	if f.repoError != nil {
+		return nil, f.repoError
+	}
+
+	versions := map[string]int64{}
+
+	for _, name := range names {
+		if name == "beta.users" {
+			versions[name] = 1
+		}
+	}
+
+	return versions, nil
+}
+
+func (f *fakeListRepo) Contains(lookups []model.ListLookup) ([]bool, error) {
+	return make([]bool, len(lookups)), f.repoError
+}
+
+type ListHandlerSuite struct {
+	suite.Suite
+	engine       *echo.Echo
+	fakeListRepo *fakeListRepo
+}
+
+func (suite *ListHandlerSuite) SetupSuite() {
+	suite.engine = echo.New()
+
+	suite.fakeListRepo = &fakeListRepo{}
+
+	h := handler.ListHandler{ListRepo: suite.fakeListRepo}
+
+	suite.engine.POST("/v1/list/:name", h.Upload)
+	suite.engine.GET("/v1/list/:name", h.Find)
+}
+
+func (suite *ListHandlerSuite) TestUploadList() {
+	cases := []struct {
+		name        string
+		list        string
+		contentType string
+		body        string
+		ids         []string
+		status      int
+		repoError   error
+	}{
+		{
+			name:        "successfully upload newline separated list",
+			list:        "beta.users",
+			contentType: echo.MIMETextPlain,
+			body:        "1\n 2 \r\n\n3\n",
+			ids:         []string{"1", "2", "3"},
+			status:      http.StatusOK,
+		},
+		{
+			name:        "successfully upload csv list",
+			list:        "beta.users",
+			contentType: "text/csv",
+			body:        "1,2\n3,,4\n",
+			ids:         []string{"1", "2", "3", "4"},
+			status:      http.StatusOK,
+		},
+		{
+			name:        "failed to upload invalid csv list",
+			list:        "beta.users",
+			contentType: "text/csv",
+			body:        "\"1,2\n",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "failed to upload empty list",
+			list:        "beta.users",
+			contentType: echo.MIMETextPlain,
+			body:        "\n\n",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "failed to upload list with invalid name",
+			list:        "Beta",
+			contentType: echo.MIMETextPlain,
+			body:        "1\n",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "failed to upload list with repo error",
+			list:        "beta.users",
+			contentType: echo.MIMETextPlain,
+			body:        "1\n",
+			status:      http.StatusInternalServerError,
+			repoError:   errors.New("fake list repo error"),
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			suite.fakeListRepo.repoError = tc.repoError
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/v1/list/"+tc.list, strings.NewReader(tc.body))
+
+			req.Header.Set(echo.HeaderContentType, tc.contentType)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, tc.name)
+
+			if tc.status == http.StatusOK {
+				resp := response.List{}
+
+				suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+				suite.Equal(response.List{Name: tc.list, Version: 2, Size: int64(len(tc.ids))}, resp)
+				suite.Equal(tc.ids, suite.fakeListRepo.uploaded)
+			}
+		})
+	}
+}
+
+func (suite *ListHandlerSuite) TestFindList() {
+	suite.fakeListRepo.repoError = nil
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/v1/list/beta.users", nil)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusOK, w.Code)
+
+	resp := response.List{}
+
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Equal(response.List{Name: "beta.users", Version: 1, Size: 3}, resp)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v1/list/countries", nil)
+
+	suite.engine.ServeHTTP(w, req)
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func TestListHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ListHandlerSuite))
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// RuleHandler represents a requests handler for segment rules.
+type RuleHandler struct{}
+
+// Render turns the constraints and the expression of a segment into a rule using an http request.
+func (r RuleHandler) Render(c echo.Context) error {
+	req := request.RenderRuleRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		logrus.Errorf("rule handler bind (render): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, ErrInvalidJSONSyntax.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		logrus.Errorf("rule handler validate (render): %s", err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	tree, err := req.Constraint()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	rule, err := constraint.RenderRule(*tree)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, response.Rule{Rule: rule})
+}
@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/handler"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/response"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type RuleHandlerSuite struct {
+	suite.Suite
+	engine *echo.Echo
+}
+
+func (suite *RuleHandlerSuite) SetupSuite() {
+	suite.engine = echo.New()
+
+	suite.engine.POST("/v1/rule/render", handler.RuleHandler{}.Render)
+}
+
+func (suite *RuleHandlerSuite) TestRenderRule() {
+	cases := []struct {
+		name   string
+		req    request.RenderRuleRequest
+		status int
+		rule   string
+	}{
+		{
+			name: "successfully render rule",
+			req: request.RenderRuleRequest{
+				Constraints: map[string]request.Constraint{
+					"country": {Name: "contains", Parameters: json.RawMessage(`{"values": ["IR"], "property": "c"}`)},
+					"adult":   {Name: ">=", Parameters: json.RawMessage(`{"value": 18, "property": "age"}`)},
+					"beta":    {Name: "list", Parameters: json.RawMessage(`{"list": "beta.users"}`)},
+				},
+				Expression: "(country or beta) and !adult",
+			},
+			status: http.StatusOK,
+			rule:   `not age >= 18 and (entity_id in list "beta.users" or c == "IR")`,
+		},
+		{
+			name: "failed to render unsupported constraint",
+			req: request.RenderRuleRequest{
+				Constraints: map[string]request.Constraint{
+					"A": {Name: "rollout", Parameters: json.RawMessage(`{"percentage": 10}`)},
+				},
+				Expression: "A",
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "failed to render invalid expression",
+			req: request.RenderRuleRequest{
+				Constraints: map[string]request.Constraint{
+					"A": {Name: ">=", Parameters: json.RawMessage(`{"value": 18}`)},
+				},
+				Expression: "A and B",
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		suite.Run(tc.name, func() {
+			body, err := json.Marshal(tc.req)
+			suite.NoError(err)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/v1/rule/render", bytes.NewReader(body))
+
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			suite.engine.ServeHTTP(w, req)
+			suite.Equal(tc.status, w.Code, w.Body.String())
+
+			if tc.status == http.StatusOK {
+				resp := response.Rule{}
+
+				suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+				suite.Equal(tc.rule, resp.Rule)
+			}
+		})
+	}
+}
+
+func TestRuleHandlerSuite(t *testing.T) {
+	suite.Run(t, new(RuleHandlerSuite))
+}
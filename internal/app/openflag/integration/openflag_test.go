@@ -15,6 +15,7 @@ import (
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/cmd"
 
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
 	"github.com/OpenFlag/OpenFlag/internal/app/openflag/request"
 	"github.com/OpenFlag/OpenFlag/pkg/database"
 	"github.com/OpenFlag/OpenFlag/pkg/redis"
@@ -307,8 +308,8 @@ func (suite *OpenFlagSuite) TestScenario() {
 				{
 					EntityID:   8,
 					EntityType: "type1",
-					EntityContext: map[string]string{
-						"c1": "v1",
+					EntityContext: model.Context{
+						"c1": model.StringValue("v1"),
 					},
 				},
 			},
@@ -343,8 +344,8 @@ func (suite *OpenFlagSuite) TestScenario() {
 				Entity: response.Entity{
 					EntityID:   8,
 					EntityType: "type1",
-					EntityContext: map[string]string{
-						"c1": "v1",
+					EntityContext: model.Context{
+						"c1": model.StringValue("v1"),
 					},
 				},
 				Evaluations: []response.Evaluation{
@@ -378,8 +379,8 @@ func (suite *OpenFlagSuite) TestScenario() {
 				{
 					EntityID:   8,
 					EntityType: "type1",
-					EntityContext: map[string]string{
-						"c2": "v2",
+					EntityContext: model.Context{
+						"c2": model.StringValue("v2"),
 					},
 				},
 			},
@@ -415,9 +416,9 @@ func (suite *OpenFlagSuite) TestScenario() {
 				Entity: response.Entity{
 					EntityID:   8,
 					EntityType: "type1",
-					EntityContext: map[string]string{
-						"c1": "v1",
-						"c2": "v2",
+					EntityContext: model.Context{
+						"c1": model.StringValue("v1"),
+						"c2": model.StringValue("v2"),
 					},
 				},
 				Evaluations: []response.Evaluation{
@@ -485,9 +486,9 @@ func (suite *OpenFlagSuite) TestScenario() {
 				Entity: response.Entity{
 					EntityID:   8,
 					EntityType: "type1",
-					EntityContext: map[string]string{
-						"c1": "v1",
-						"c2": "v2",
+					EntityContext: model.Context{
+						"c1": model.StringValue("v1"),
+						"c2": model.StringValue("v2"),
 					},
 				},
 				Evaluations: []response.Evaluation{
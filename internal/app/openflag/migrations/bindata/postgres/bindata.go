@@ -2,6 +2,12 @@
 // sources:
 // 20200704133101_init.down.sql
 // 20200704133101_init.up.sql
+// 20201120100000_audiences.down.sql
+// 20201120100000_audiences.up.sql
+// 20201201100000_layers.down.sql
+// 20201201100000_layers.up.sql
+// 20201210100000_sticky.down.sql
+// 20201210100000_sticky.up.sql
 // DO NOT EDIT!
 
 package postgres
@@ -109,6 +115,126 @@ func _20200704133101_initUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __20201120100000_audiencesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4b\x29\xca\x2f\x50\x28\x49\x4c\xca\x49\x55\xc8\x4c\x53\x48\xad\xc8\x2c\x2e\x29\x56\x48\x2c\x4d\xc9\x4c\xcd\x4b\x4e\x2d\xb6\xe6\x02\x00\xad\x1f\xb4\xef\x20\x00\x00\x00")
+
+func _20201120100000_audiencesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20201120100000_audiencesDownSql,
+		"20201120100000_audiences.down.sql",
+	)
+}
+
+func _20201120100000_audiencesDownSql() (*asset, error) {
+	bytes, err := _20201120100000_audiencesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20201120100000_audiences.down.sql", size: 32, mode: os.FileMode(420), modTime: time.Unix(1792309320, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20201120100000_audiencesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x50\xbb\x6e\xc3\x30\x0c\xdc\xf5\x15\x1c\x65\xa0\x53\x80\x4c\xfd\x18\x83\x96\x98\x96\xad\x4d\xa9\x22\x95\x2a\x7f\x5f\xd9\x49\xf3\x00\x82\x0e\xbd\x8d\xe4\xdd\xe1\x78\xa1\x10\x1a\x81\xe1\x34\x13\xf0\x01\x24\x19\x50\x63\x35\x05\xac\x91\x49\x02\xa9\xf3\x0e\x3a\x38\xc2\x03\x26\x7e\x53\x2a\x8c\xf3\xcb\x76\x16\x5c\xe8\xfe\x7c\xc4\x12\xde\xb1\xf8\xdd\x7e\x3f\x6c\xb6\x52\xe7\x0b\x35\x92\x86\xc2\xd9\x38\xc9\x46\x35\x6a\x76\xd5\x3d\x52\x43\x12\xb5\x82\x2c\x3d\xd0\x8a\x0f\x4d\x32\x3d\xa7\x52\xcb\x85\x54\x7f\x4d\xff\x74\xdd\xbe\x8e\x23\x5e\x08\xc6\x0b\xa9\xe1\x92\xef\xa9\x3d\xe6\x01\xeb\xdc\x87\xf4\xed\x87\xb3\xb0\xe6\xf8\x3f\x61\x2e\xbc\x60\x39\xc1\x27\x9d\xc0\x73\x1c\xdc\xf0\xea\xdc\x39\x06\x54\xe1\xaf\xda\xdb\x97\x48\xed\xd6\xfa\xb8\x16\x3a\x72\x6c\xd0\x1f\xba\x6e\xfd\xba\xed\xda\x1f\x58\x67\x4d\x2b\xb8\x01\x00\x00")
+
+func _20201120100000_audiencesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20201120100000_audiencesUpSql,
+		"20201120100000_audiences.up.sql",
+	)
+}
+
+func _20201120100000_audiencesUpSql() (*asset, error) {
+	bytes, err := _20201120100000_audiencesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20201120100000_audiences.up.sql", size: 440, mode: os.FileMode(420), modTime: time.Unix(1792309320, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20201201100000_layersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\xce\x31\x0e\x80\x20\x0c\x85\xe1\x9d\x53\xf4\x1e\x1c\x86\x80\x2d\x86\xa4\x02\xa1\x10\xf1\xf6\xa2\x2c\xc6\x4d\x97\x2e\x7f\xbf\xe4\x61\x49\x19\x42\x44\xea\x10\x3c\x50\x0f\x52\x05\x3c\xdb\x55\x0c\xdb\x83\x8a\x09\xd8\xb5\x52\x96\x2b\x15\xa8\xd6\x31\xcd\x0a\x78\xc1\x25\x71\xdb\xe2\x43\x4e\xd3\x72\x1e\xd7\xa5\x16\x51\x7f\xa4\x9c\xf6\x9f\x74\xac\xbc\xeb\x7c\x7f\x45\xd1\xea\x04\xcc\x08\x6c\xd3\xea\x00\x00\x00")
+
+func _20201201100000_layersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20201201100000_layersDownSql,
+		"20201201100000_layers.down.sql",
+	)
+}
+
+func _20201201100000_layersDownSql() (*asset, error) {
+	bytes, err := _20201201100000_layersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20201201100000_layers.down.sql", size: 234, mode: os.FileMode(420), modTime: time.Unix(1792311558, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20201201100000_layersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x91\x41\x6e\x84\x30\x0c\x45\xf7\x9c\xc2\xbb\x01\xa9\x8b\xaa\xd2\xac\xe6\x30\xc8\x10\xc3\x58\x0d\x49\x9a\x38\x1d\xb8\x7d\x43\x42\x69\x51\xab\x0a\xd5\x8b\x2c\x1c\xbf\x9f\xfc\xef\xde\x13\x0a\x81\x60\xa7\x09\x78\x00\x63\x05\x68\xe6\x20\x01\x34\x2e\xe4\x43\x55\x57\x90\x8a\x15\x1c\xaa\xe3\x31\x90\x67\xd4\x4f\xf9\xda\xe0\x44\xdf\xaf\xdf\xd1\xf7\x77\xf4\xf5\xcb\xf5\xda\x64\x4d\x13\xf5\x36\xaa\x28\xf4\x9e\x9d\xb0\x35\x79\x54\x68\x96\x9d\x3b\x8e\x3a\x6f\x1d\x79\x59\xfe\x54\x4d\x82\x03\x46\x2d\x70\xb9\x14\xea\x6e\xb5\xb2\xf1\x4b\x93\x8d\xd0\x48\xfe\xf8\xc0\x4e\x3d\x17\xa8\xcf\x39\xa8\x16\x37\x4e\x78\xa2\x20\x38\xb9\x5f\x21\x63\x1f\x75\x53\xc0\xe8\xd4\xff\x40\xe7\x79\x42\xbf\xc0\x2b\x2d\x50\xb3\x6a\xaa\xe6\x56\x55\xe5\x1b\x10\x0d\xbf\xc5\xb4\x0f\xa3\x68\xde\xf6\xd0\xae\x11\xb7\xac\x66\x48\xb9\x95\x56\xbd\xb6\x56\x0a\xb5\x24\x7f\x65\x87\x83\xc6\x31\x00\x2a\x05\xbd\xd5\x71\xda\x66\x0f\xd1\xdd\x4e\x00\xad\xb6\x8f\x74\x76\x36\x1a\xb5\x27\xf8\x33\xbc\x53\x52\xd1\xb9\x53\x52\x9f\xee\x8b\xed\x2c\xd6\x16\x85\xcd\x76\x6e\xd5\xb9\x95\x4c\x7c\x00\xe2\x2c\x50\xe1\xbb\x02\x00\x00")
+
+func _20201201100000_layersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20201201100000_layersUpSql,
+		"20201201100000_layers.up.sql",
+	)
+}
+
+func _20201201100000_layersUpSql() (*asset, error) {
+	bytes, err := _20201201100000_layersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20201201100000_layers.up.sql", size: 699, mode: os.FileMode(420), modTime: time.Unix(1792311558, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20201210100000_stickyDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x30\x00\xcf\xff\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x66\x6c\x61\x67\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x73\x74\x69\x63\x6b\x79\x3b\x0a\x03\x00\x8b\x8d\x09\xe4\x30\x00\x00\x00")
+
+func _20201210100000_stickyDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20201210100000_stickyDownSql,
+		"20201210100000_sticky.down.sql",
+	)
+}
+
+func _20201210100000_stickyDownSql() (*asset, error) {
+	bytes, err := _20201210100000_stickyDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20201210100000_sticky.down.sql", size: 48, mode: os.FileMode(420), modTime: time.Unix(1792311558, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __20201210100000_stickyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x44\x00\xbb\xff\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x66\x6c\x61\x67\x73\x20\x61\x64\x64\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x73\x74\x69\x63\x6b\x79\x20\x62\x6f\x6f\x6c\x65\x61\x6e\x20\x6e\x6f\x74\x20\x6e\x75\x6c\x6c\x20\x64\x65\x66\x61\x75\x6c\x74\x20\x66\x61\x6c\x73\x65\x3b\x0a\x03\x00\x78\x0a\xa7\x70\x44\x00\x00\x00")
+
+func _20201210100000_stickyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__20201210100000_stickyUpSql,
+		"20201210100000_sticky.up.sql",
+	)
+}
+
+func _20201210100000_stickyUpSql() (*asset, error) {
+	bytes, err := _20201210100000_stickyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20201210100000_sticky.up.sql", size: 68, mode: os.FileMode(420), modTime: time.Unix(1792311558, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -161,8 +287,14 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"20200704133101_init.down.sql": _20200704133101_initDownSql,
-	"20200704133101_init.up.sql":   _20200704133101_initUpSql,
+	"20200704133101_init.down.sql":      _20200704133101_initDownSql,
+	"20200704133101_init.up.sql":        _20200704133101_initUpSql,
+	"20201120100000_audiences.down.sql": _20201120100000_audiencesDownSql,
+	"20201120100000_audiences.up.sql":   _20201120100000_audiencesUpSql,
+	"20201201100000_layers.down.sql":    _20201201100000_layersDownSql,
+	"20201201100000_layers.up.sql":      _20201201100000_layersUpSql,
+	"20201210100000_sticky.down.sql":    _20201210100000_stickyDownSql,
+	"20201210100000_sticky.up.sql":      _20201210100000_stickyUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -206,8 +338,14 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"20200704133101_init.down.sql": {_20200704133101_initDownSql, map[string]*bintree{}},
-	"20200704133101_init.up.sql":   {_20200704133101_initUpSql, map[string]*bintree{}},
+	"20200704133101_init.down.sql":      {_20200704133101_initDownSql, map[string]*bintree{}},
+	"20200704133101_init.up.sql":        {_20200704133101_initUpSql, map[string]*bintree{}},
+	"20201120100000_audiences.down.sql": {_20201120100000_audiencesDownSql, map[string]*bintree{}},
+	"20201120100000_audiences.up.sql":   {_20201120100000_audiencesUpSql, map[string]*bintree{}},
+	"20201201100000_layers.down.sql":    {_20201201100000_layersDownSql, map[string]*bintree{}},
+	"20201201100000_layers.up.sql":      {_20201201100000_layersUpSql, map[string]*bintree{}},
+	"20201210100000_sticky.down.sql":    {_20201210100000_stickyDownSql, map[string]*bintree{}},
+	"20201210100000_sticky.up.sql":      {_20201210100000_stickyUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,13 @@
+create table if not exists audiences
+(
+    id              bigserial,
+    name            varchar(255) not null,
+    description     text         not null,
+    constraints     jsonb        not null,
+    expression      text         not null,
+    created_at      timestamp    not null default now(),
+    updated_at      timestamp    not null default now(),
+    primary key (id)
+);
+
+create unique index audiences_name_idx on audiences(name);
@@ -0,0 +1,7 @@
+drop index if exists flags_layer_idx;
+
+alter table flags drop column if exists layer_upper_bound;
+alter table flags drop column if exists layer_lower_bound;
+alter table flags drop column if exists layer;
+
+drop table if exists layers;
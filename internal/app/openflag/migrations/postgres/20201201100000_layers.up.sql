@@ -0,0 +1,19 @@
+create table if not exists layers
+(
+    id              bigserial,
+    name            varchar(255) not null,
+    description     text         not null,
+    property        varchar(255) not null default '',
+    holdout         integer      not null default 0,
+    created_at      timestamp    not null default now(),
+    updated_at      timestamp    not null default now(),
+    primary key (id)
+);
+
+create unique index layers_name_idx on layers(name);
+
+alter table flags add column layer varchar(255);
+alter table flags add column layer_lower_bound integer not null default 0;
+alter table flags add column layer_upper_bound integer not null default 0;
+
+create index flags_layer_idx on flags(layer);
@@ -0,0 +1 @@
+alter table flags drop column if exists sticky;
@@ -0,0 +1 @@
+alter table flags add column sticky boolean not null default false;
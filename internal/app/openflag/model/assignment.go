@@ -0,0 +1,129 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	assignmentName = "redis_assignment"
+)
+
+// AssignmentLookup represents the sticky variant assignment of an entity in a flag.
+type AssignmentLookup struct {
+	Flag       string
+	EntityType string
+	EntityID   int64
+}
+
+// AssignmentRepo represents an interface for working with the sticky variant assignments of the flags.
+type AssignmentRepo interface {
+	Find(lookups []AssignmentLookup) ([]string, error)
+	Assign(lookup AssignmentLookup, variant string) (string, error)
+	Reset(flag string) error
+	ResetEntity(lookup AssignmentLookup) error
+}
+
+// RedisAssignmentRepo is an implementation of AssignmentRepo for Redis. It keeps the assignments of each flag
+// in a Redis hash from the entities to their variant keys, so the assignments of a flag are reset at once.
+type RedisAssignmentRepo struct {
+	RedisMaster redis.Cmdable
+	RedisSlave  redis.Cmdable
+}
+
+func (r RedisAssignmentRepo) assignmentKey(flag string) string {
+	return fmt.Sprintf("openflag:assignment:flag:%s", flag)
+}
+
+func (r RedisAssignmentRepo) entityField(lookup AssignmentLookup) string {
+	return fmt.Sprintf("type:%s:id:%d", lookup.EntityType, lookup.EntityID)
+}
+
+// Find finds the variant keys of the given assignments using a single pipelined request to Redis.
+// The variant key of an entity that is not assigned yet is empty.
+func (r RedisAssignmentRepo) Find(lookups []AssignmentLookup) (_ []string, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(assignmentName, "find", startTime, finalErr) }()
+
+	if len(lookups) == 0 {
+		return []string{}, nil
+	}
+
+	result, err := r.RedisSlave.Pipelined(func(pipeliner redis.Pipeliner) error {
+		for _, lookup := range lookups {
+			pipeliner.HGet(r.assignmentKey(lookup.Flag), r.entityField(lookup))
+		}
+
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	variants := make([]string, len(result))
+
+	for i := range result {
+		cmd, ok := result[i].(*redis.StringCmd)
+		if !ok {
+			return nil, errors.New("cannot read pipeline result")
+		}
+
+		variant, err := cmd.Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+
+		variants[i] = variant
+	}
+
+	return variants, nil
+}
+
+// Assign assigns the given variant to the entity if it is not assigned yet. It returns the variant that
+// the entity is assigned to, so concurrent evaluations agree on the first assignment.
+func (r RedisAssignmentRepo) Assign(lookup AssignmentLookup, variant string) (_ string, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(assignmentName, "assign", startTime, finalErr) }()
+
+	key, field := r.assignmentKey(lookup.Flag), r.entityField(lookup)
+
+	result, err := r.RedisMaster.TxPipelined(func(pipeliner redis.Pipeliner) error {
+		pipeliner.HSetNX(key, field, variant)
+		pipeliner.HGet(key, field)
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	cmd, ok := result[1].(*redis.StringCmd)
+	if !ok {
+		return "", errors.New("cannot read pipeline result")
+	}
+
+	return cmd.Result()
+}
+
+// Reset removes all assignments of the given flag.
+func (r RedisAssignmentRepo) Reset(flag string) (finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(assignmentName, "reset", startTime, finalErr) }()
+
+	return r.RedisMaster.Del(r.assignmentKey(flag)).Err()
+}
+
+// ResetEntity removes the assignment of the given entity in the flag.
+func (r RedisAssignmentRepo) ResetEntity(lookup AssignmentLookup) (finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(assignmentName, "reset_entity", startTime, finalErr) }()
+
+	return r.RedisMaster.HDel(r.assignmentKey(lookup.Flag), r.entityField(lookup)).Err()
+}
@@ -0,0 +1,78 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/config"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/pkg/redis"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AssignmentRepoSuite struct {
+	suite.Suite
+	assignmentRepo model.RedisAssignmentRepo
+}
+
+func (suite *AssignmentRepoSuite) SetupSuite() {
+	cfg := config.Init()
+
+	redisCfg := cfg.Redis
+
+	rMaster, _ := redis.Create(redisCfg.MasterAddress, redisCfg.Options, true)
+	rSlave, _ := redis.Create(redisCfg.SlaveAddress, redisCfg.Options, false)
+
+	suite.assignmentRepo = model.RedisAssignmentRepo{RedisMaster: rMaster, RedisSlave: rSlave}
+}
+
+func (suite *AssignmentRepoSuite) SetupTest() {
+	suite.NoError(suite.assignmentRepo.RedisMaster.FlushDB().Err())
+}
+
+func (suite *AssignmentRepoSuite) TearDownTest() {
+	suite.NoError(suite.assignmentRepo.RedisMaster.FlushDB().Err())
+}
+
+func (suite *AssignmentRepoSuite) TestAssignmentRepo() {
+	user1 := model.AssignmentLookup{Flag: "checkout", EntityType: "user", EntityID: 1}
+	user2 := model.AssignmentLookup{Flag: "checkout", EntityType: "user", EntityID: 2}
+	other := model.AssignmentLookup{Flag: "pricing", EntityType: "user", EntityID: 1}
+
+	variants, err := suite.assignmentRepo.Find([]model.AssignmentLookup{user1, user2})
+	suite.NoError(err)
+	suite.Equal([]string{"", ""}, variants)
+
+	variant, err := suite.assignmentRepo.Assign(user1, "green")
+	suite.NoError(err)
+	suite.Equal("green", variant)
+
+	variant, err = suite.assignmentRepo.Assign(user1, "red")
+	suite.NoError(err)
+	suite.Equal("green", variant)
+
+	for _, lookup := range []model.AssignmentLookup{user2, other} {
+		_, err = suite.assignmentRepo.Assign(lookup, "red")
+		suite.NoError(err)
+	}
+
+	variants, err = suite.assignmentRepo.Find([]model.AssignmentLookup{user1, user2, other})
+	suite.NoError(err)
+	suite.Equal([]string{"green", "red", "red"}, variants)
+
+	suite.NoError(suite.assignmentRepo.ResetEntity(user1))
+
+	variants, err = suite.assignmentRepo.Find([]model.AssignmentLookup{user1, user2, other})
+	suite.NoError(err)
+	suite.Equal([]string{"", "red", "red"}, variants)
+
+	suite.NoError(suite.assignmentRepo.Reset("checkout"))
+
+	variants, err = suite.assignmentRepo.Find([]model.AssignmentLookup{user1, user2, other})
+	suite.NoError(err)
+	suite.Equal([]string{"", "", "red"}, variants)
+}
+
+func TestAssignmentRepoSuite(t *testing.T) {
+	suite.Run(t, new(AssignmentRepoSuite))
+}
@@ -0,0 +1,146 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	audienceName = "sql_audience"
+)
+
+var (
+	// ErrAudienceNotFound represents an error for returning when we can't find an audience with given parameters.
+	ErrAudienceNotFound = errors.New("audience not found")
+	// ErrDuplicateAudienceFound represents an error for returning when we find an audience with a given name
+	// in audience creation.
+	ErrDuplicateAudienceFound = errors.New("duplicate audience found")
+	// ErrInvalidAudienceForUpdate represents an error for returning when we find that an audience is invalid
+	// in the update method.
+	ErrInvalidAudienceForUpdate = errors.New("invalid audience for update")
+)
+
+// Audience represents each row of audiences table in SQL database.
+// An audience is a named and reusable set of constraints that segments can reference using the
+// audience constraint.
+type Audience struct {
+	ID          int64     `json:"id" gorm:"primary_key"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Constraints string    `json:"constraints"`
+	Expression  string    `json:"expression"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AudienceRepo represents an interface for working with persist audiences.
+type AudienceRepo interface {
+	Create(audience *Audience) error
+	Delete(id int64) error
+	Update(id int64, audience *Audience) error
+	FindAll() ([]Audience, error)
+	FindByID(id int64) (*Audience, error)
+}
+
+// SQLAudienceRepo is an implementation of AudienceRepo for SQL databases.
+type SQLAudienceRepo struct {
+	Driver   string
+	MasterDB *gorm.DB
+	SlaveDB  *gorm.DB
+}
+
+// Create creates an audience in SQL database.
+func (s SQLAudienceRepo) Create(audience *Audience) (finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(audienceName, "create", startTime, finalErr) }()
+
+	return s.MasterDB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("name = ?", audience.Name).Take(&Audience{}).Error
+		if err == nil {
+			return ErrDuplicateAudienceFound
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		if err := tx.Create(audience).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Delete deletes an audience from SQL database.
+func (s SQLAudienceRepo) Delete(id int64) (finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(audienceName, "delete", startTime, finalErr) }()
+
+	return s.MasterDB.Where("id = ?", id).Delete(&Audience{}).Error
+}
+
+// Update updates an audience in SQL database. The audience name can not be changed because flags reference
+// audiences by their names.
+func (s SQLAudienceRepo) Update(id int64, audience *Audience) (finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(audienceName, "update", startTime, finalErr) }()
+
+	return s.MasterDB.Transaction(func(tx *gorm.DB) error {
+		var a Audience
+
+		if err := tx.Where("id = ?", id).Find(&a).Error; err != nil {
+			if gorm.IsRecordNotFoundError(err) {
+				return ErrAudienceNotFound
+			}
+
+			return err
+		}
+
+		if a.Name != audience.Name {
+			return ErrInvalidAudienceForUpdate
+		}
+
+		audience.ID = a.ID
+		audience.CreatedAt = a.CreatedAt
+
+		return tx.Save(audience).Error
+	})
+}
+
+// FindAll finds all audiences from SQL database.
+func (s SQLAudienceRepo) FindAll() (_ []Audience, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(audienceName, "find_all", startTime, finalErr) }()
+
+	var result []Audience
+
+	if err := s.SlaveDB.Order("id").Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindByID finds an audience with it's given id from SQL database.
+func (s SQLAudienceRepo) FindByID(id int64) (_ *Audience, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(audienceName, "find_by_id", startTime, finalErr) }()
+
+	var result Audience
+
+	if err := s.SlaveDB.Where("id = ?", id).Find(&result).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, ErrAudienceNotFound
+		}
+
+		return nil, err
+	}
+
+	return &result, nil
+}
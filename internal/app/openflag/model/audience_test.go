@@ -0,0 +1,116 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/config"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/pkg/database"
+	"github.com/stretchr/testify/suite"
+)
+
+type AudienceRepoSuite struct {
+	suite.Suite
+	repo model.SQLAudienceRepo
+}
+
+func (suite *AudienceRepoSuite) SetupSuite() {
+	cfg := config.Init()
+	dbCfg := cfg.Database
+
+	masterDb, err := database.Create(dbCfg.Driver, dbCfg.MasterConnStr, dbCfg.Options)
+	suite.NoError(err)
+	suite.NotNil(masterDb)
+
+	slaveDb, err := database.Create(dbCfg.Driver, dbCfg.SlaveConnStr, dbCfg.Options)
+	suite.NoError(err)
+	suite.NotNil(slaveDb)
+
+	suite.repo = model.SQLAudienceRepo{
+		Driver:   dbCfg.Driver,
+		MasterDB: masterDb,
+		SlaveDB:  slaveDb,
+	}
+}
+
+func (suite *AudienceRepoSuite) TearDownSuite() {
+	suite.NoError(suite.repo.MasterDB.Close())
+}
+
+func (suite *AudienceRepoSuite) SetupTest() {
+	suite.NoError(suite.repo.MasterDB.Exec(`truncate table audiences`).Error)
+}
+
+func (suite *AudienceRepoSuite) TearDownTest() {
+	suite.NoError(suite.repo.MasterDB.Exec(`truncate table audiences`).Error)
+}
+
+func (suite *AudienceRepoSuite) TestScenario() {
+	audiences := []model.Audience{
+		{
+			Name:        "employees",
+			Description: "Description 1",
+			Constraints: `{"A": {"name": "contains", "parameters": {"values": ["1", "2"]}}}`,
+			Expression:  "A",
+		},
+		{
+			Name:        "beta.testers",
+			Description: "Description 2",
+			Constraints: `{"A": {"name": "contains", "parameters": {"values": ["3"]}}}`,
+			Expression:  "A",
+		},
+	}
+
+	// nolint:scopelint
+	for _, a := range audiences {
+		err := suite.repo.Create(&a)
+		suite.NoError(err)
+	}
+
+	err := suite.repo.Create(&audiences[0])
+	suite.Equal(model.ErrDuplicateAudienceFound, err)
+
+	findAllDbAudiences, err := suite.repo.FindAll()
+	suite.NoError(err)
+	suite.Equal(len(audiences), len(findAllDbAudiences))
+
+	findByIDDbAudience, err := suite.repo.FindByID(findAllDbAudiences[0].ID)
+	suite.NoError(err)
+	suite.Equal(findAllDbAudiences[0].Name, findByIDDbAudience.Name)
+
+	_, err = suite.repo.FindByID(100)
+	suite.Equal(model.ErrAudienceNotFound, err)
+
+	editedAudience := model.Audience{
+		Name:        "others",
+		Description: "Description 3",
+		Constraints: `{"A": {"name": "contains", "parameters": {"values": ["4"]}}}`,
+		Expression:  "A",
+	}
+
+	err = suite.repo.Update(100, &editedAudience)
+	suite.Equal(model.ErrAudienceNotFound, err)
+
+	err = suite.repo.Update(findAllDbAudiences[0].ID, &editedAudience)
+	suite.Equal(model.ErrInvalidAudienceForUpdate, err)
+
+	editedAudience.Name = findAllDbAudiences[0].Name
+
+	err = suite.repo.Update(findAllDbAudiences[0].ID, &editedAudience)
+	suite.NoError(err)
+
+	findByIDDbAudience, err = suite.repo.FindByID(findAllDbAudiences[0].ID)
+	suite.NoError(err)
+	suite.Equal(editedAudience.Description, findByIDDbAudience.Description)
+
+	err = suite.repo.Delete(findAllDbAudiences[0].ID)
+	suite.NoError(err)
+
+	findAllDbAudiences, err = suite.repo.FindAll()
+	suite.NoError(err)
+	suite.Equal(len(audiences)-1, len(findAllDbAudiences))
+}
+
+func TestAudienceRepoSuite(t *testing.T) {
+	suite.Run(t, new(AudienceRepoSuite))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -12,23 +13,46 @@ import (
 const (
 	entityName           = "redis_entity"
 	pipelineExecDuration = 1 * time.Second
+
+	firstSeenField = "first_seen"
+	lastSeenField  = "last_seen"
 )
 
 type (
 	// Entity represents the context of what we are going to assign the variant on.
 	// Usually, OpenFlag expects the context coming with the entity,
 	// so that one can define constraints based on the context of the entity.
+	// FirstSeen and LastSeen are the first and the last time that the context of the entity is saved.
+	// They are set for the entities that are loaded with their stored contexts and are loaded by the evaluation
+	// engine for the flags with entity age constraints.
 	Entity struct {
-		EntityID      int64             `json:"entity_id"`
-		EntityType    string            `json:"entity_type"`
-		EntityContext map[string]string `json:"entity_context,omitempty"`
+		EntityID      int64      `json:"entity_id"`
+		EntityType    string     `json:"entity_type"`
+		EntityContext Context    `json:"entity_context,omitempty"`
+		FirstSeen     *time.Time `json:"first_seen,omitempty"`
+		LastSeen      *time.Time `json:"last_seen,omitempty"`
+		scope         interface{}
 	}
 )
 
+// WithScope returns a copy of the entity that carries the given evaluation scope.
+// The evaluation engine uses the scope for sharing the state of a single evaluation with constraints.
+func (e Entity) WithScope(scope interface{}) Entity {
+	e.scope = scope
+
+	return e
+}
+
+// Scope returns the evaluation scope of the entity.
+func (e Entity) Scope() interface{} {
+	return e.scope
+}
+
 // EntityRepo represents an interface for working with persist entities.
 type EntityRepo interface {
 	Save(entities []Entity) error
 	Find(entities []Entity) ([]Entity, error)
+	FindSeen(entities []Entity) ([]Entity, error)
 }
 
 // RedisEntityRepo is an implementation of EntityRepo for Redis.
@@ -37,15 +61,21 @@ type RedisEntityRepo struct {
 	RedisSlave  redis.Cmdable
 	Pipeliner   redis.Pipeliner
 	Expiration  time.Duration
+	// SeenExpiration is the expiration of the first seen and the last seen timestamps. They are kept
+	// forever if it is zero.
+	SeenExpiration time.Duration
 }
 
 // NewRedisEntityRepo created a new Redis entity repo.
-func NewRedisEntityRepo(rMaster redis.Cmdable, rSlave redis.Cmdable, expiration time.Duration) RedisEntityRepo {
+func NewRedisEntityRepo(
+	rMaster redis.Cmdable, rSlave redis.Cmdable, expiration time.Duration, seenExpiration time.Duration,
+) RedisEntityRepo {
 	repo := RedisEntityRepo{
-		RedisMaster: rMaster,
-		RedisSlave:  rSlave,
-		Pipeliner:   rMaster.Pipeline(),
-		Expiration:  expiration,
+		RedisMaster:    rMaster,
+		RedisSlave:     rSlave,
+		Pipeliner:      rMaster.Pipeline(),
+		Expiration:     expiration,
+		SeenExpiration: seenExpiration,
 	}
 
 	repo.runPipeline()
@@ -70,12 +100,20 @@ func (r RedisEntityRepo) entityIDKey(eID int64, eType string) string {
 	return fmt.Sprintf("openflag:entity:type:%s:id:%d", eType, eID)
 }
 
-// Save saves entity context to Redis.
+func (r RedisEntityRepo) entitySeenKey(eID int64, eType string) string {
+	return fmt.Sprintf("openflag:entity:type:%s:id:%d:seen", eType, eID)
+}
+
+// Save saves entity context to Redis. It also records the first time and the last time that the context
+// of each entity is saved. The timestamps have their own expiration, so they outlive the cached context
+// and an entity is only seen for the first time again if it is not seen for the seen expiration.
 func (r RedisEntityRepo) Save(entities []Entity) (finalErr error) {
 	startTime := time.Now()
 
 	defer func() { metrics.report(entityName, "save", startTime, finalErr) }()
 
+	now := strconv.FormatInt(startTime.Unix(), 10)
+
 	for _, e := range entities {
 		rKey := r.entityIDKey(e.EntityID, e.EntityType)
 
@@ -85,12 +123,23 @@ func (r RedisEntityRepo) Save(entities []Entity) (finalErr error) {
 		}
 
 		r.Pipeliner.Set(rKey, context, r.Expiration)
+
+		seenKey := r.entitySeenKey(e.EntityID, e.EntityType)
+
+		r.Pipeliner.HSetNX(seenKey, firstSeenField, now)
+		r.Pipeliner.HSet(seenKey, lastSeenField, now)
+
+		if r.SeenExpiration > 0 {
+			r.Pipeliner.Expire(seenKey, r.SeenExpiration)
+		}
 	}
 
 	return nil
 }
 
-// Find finds entity context from Redis.
+// Find finds entity context from Redis. It also loads the first seen and the last seen timestamps of
+// the entities.
+// nolint:funlen
 func (r RedisEntityRepo) Find(entities []Entity) (_ []Entity, finalErr error) {
 	startTime := time.Now()
 
@@ -99,6 +148,7 @@ func (r RedisEntityRepo) Find(entities []Entity) (_ []Entity, finalErr error) {
 	result, err := r.RedisSlave.Pipelined(func(pipeliner redis.Pipeliner) error {
 		for _, entity := range entities {
 			pipeliner.Get(r.entityIDKey(entity.EntityID, entity.EntityType))
+			pipeliner.HMGet(r.entitySeenKey(entity.EntityID, entity.EntityType), firstSeenField, lastSeenField)
 		}
 
 		return nil
@@ -107,12 +157,24 @@ func (r RedisEntityRepo) Find(entities []Entity) (_ []Entity, finalErr error) {
 		return nil, err
 	}
 
-	for i := range result {
-		redisContext, ok := result[i].(*redis.StringCmd)
+	for i := range entities {
+		redisContext, ok := result[2*i].(*redis.StringCmd)
+		if !ok {
+			return nil, errors.New("cannot read pipeline result")
+		}
+
+		redisSeen, ok := result[2*i+1].(*redis.SliceCmd)
 		if !ok {
 			return nil, errors.New("cannot read pipeline result")
 		}
 
+		seen, err := redisSeen.Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+
+		entities[i].FirstSeen, entities[i].LastSeen = seenTimestamp(seen, 0), seenTimestamp(seen, 1)
+
 		jsonContext, err := redisContext.Result()
 		if err != nil && err != redis.Nil {
 			return nil, err
@@ -120,7 +182,7 @@ func (r RedisEntityRepo) Find(entities []Entity) (_ []Entity, finalErr error) {
 			continue
 		}
 
-		context := map[string]string{}
+		context := Context{}
 
 		err = json.Unmarshal([]byte(jsonContext), &context)
 		if err != nil {
@@ -134,7 +196,7 @@ func (r RedisEntityRepo) Find(entities []Entity) (_ []Entity, finalErr error) {
 			}
 
 			if entities[i].EntityContext == nil {
-				entities[i].EntityContext = map[string]string{}
+				entities[i].EntityContext = Context{}
 			}
 
 			entities[i].EntityContext[key] = value
@@ -143,3 +205,58 @@ func (r RedisEntityRepo) Find(entities []Entity) (_ []Entity, finalErr error) {
 
 	return entities, nil
 }
+
+// FindSeen loads the first seen and the last seen timestamps of the entities from Redis without their contexts.
+func (r RedisEntityRepo) FindSeen(entities []Entity) (_ []Entity, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(entityName, "find_seen", startTime, finalErr) }()
+
+	result, err := r.RedisSlave.Pipelined(func(pipeliner redis.Pipeliner) error {
+		for _, entity := range entities {
+			pipeliner.HMGet(r.entitySeenKey(entity.EntityID, entity.EntityType), firstSeenField, lastSeenField)
+		}
+
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	for i := range entities {
+		redisSeen, ok := result[i].(*redis.SliceCmd)
+		if !ok {
+			return nil, errors.New("cannot read pipeline result")
+		}
+
+		seen, err := redisSeen.Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+
+		entities[i].FirstSeen, entities[i].LastSeen = seenTimestamp(seen, 0), seenTimestamp(seen, 1)
+	}
+
+	return entities, nil
+}
+
+// seenTimestamp returns the timestamp at the given index of the HMGET result if it is recorded.
+func seenTimestamp(values []interface{}, index int) *time.Time {
+	if index >= len(values) {
+		return nil
+	}
+
+	value, ok := values[index].(string)
+	if !ok {
+		return nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	t := time.Unix(seconds, 0)
+
+	return &t
+}
@@ -24,7 +24,7 @@ func (suite *EntityRepoSuite) SetupSuite() {
 	rMaster, _ := redis.Create(redisCfg.MasterAddress, redisCfg.Options, true)
 	rSlave, _ := redis.Create(redisCfg.SlaveAddress, redisCfg.Options, false)
 
-	suite.entityRepo = model.NewRedisEntityRepo(rMaster, rSlave, 1*time.Hour)
+	suite.entityRepo = model.NewRedisEntityRepo(rMaster, rSlave, 1*time.Hour, 24*time.Hour)
 }
 
 func (suite *EntityRepoSuite) SetupTest() {
@@ -48,16 +48,16 @@ func (suite *EntityRepoSuite) TestEntityRepo() {
 				{
 					EntityID:   1,
 					EntityType: "t1",
-					EntityContext: map[string]string{
-						"k1": "v1",
+					EntityContext: model.Context{
+						"k1": model.StringValue("v1"),
 					},
 				},
 				{
 					EntityID:   2,
 					EntityType: "t2",
-					EntityContext: map[string]string{
-						"k1": "v1",
-						"k2": "v2",
+					EntityContext: model.Context{
+						"k1": model.StringValue("v1"),
+						"k2": model.StringValue("v2"),
 					},
 				},
 			},
@@ -69,8 +69,8 @@ func (suite *EntityRepoSuite) TestEntityRepo() {
 				{
 					EntityID:   2,
 					EntityType: "t2",
-					EntityContext: map[string]string{
-						"k2": "v2o",
+					EntityContext: model.Context{
+						"k2": model.StringValue("v2o"),
 					},
 				},
 			},
@@ -78,16 +78,16 @@ func (suite *EntityRepoSuite) TestEntityRepo() {
 				{
 					EntityID:   1,
 					EntityType: "t1",
-					EntityContext: map[string]string{
-						"k1": "v1",
+					EntityContext: model.Context{
+						"k1": model.StringValue("v1"),
 					},
 				},
 				{
 					EntityID:   2,
 					EntityType: "t2",
-					EntityContext: map[string]string{
-						"k1": "v1",
-						"k2": "v2o",
+					EntityContext: model.Context{
+						"k1": model.StringValue("v1"),
+						"k2": model.StringValue("v2o"),
 					},
 				},
 			},
@@ -98,8 +98,8 @@ func (suite *EntityRepoSuite) TestEntityRepo() {
 				{
 					EntityID:   1,
 					EntityType: "t1",
-					EntityContext: map[string]string{
-						"k1": "v1",
+					EntityContext: model.Context{
+						"k1": model.StringValue("v1"),
 					},
 				},
 			},
@@ -117,8 +117,8 @@ func (suite *EntityRepoSuite) TestEntityRepo() {
 				{
 					EntityID:   1,
 					EntityType: "t1",
-					EntityContext: map[string]string{
-						"k1": "v1",
+					EntityContext: model.Context{
+						"k1": model.StringValue("v1"),
 					},
 				},
 				{
@@ -143,12 +143,89 @@ func (suite *EntityRepoSuite) TestEntityRepo() {
 			suite.NoError(err)
 
 			for i, e := range tc.Result {
+				saved := false
+
+				for _, s := range tc.EntitiesForSave {
+					saved = saved || (s.EntityID == e.EntityID && s.EntityType == e.EntityType)
+				}
+
+				suite.Equal(saved, entities[i].FirstSeen != nil)
+				suite.Equal(saved, entities[i].LastSeen != nil)
+
+				entities[i].FirstSeen, entities[i].LastSeen = nil, nil
+
 				suite.Equal(e, entities[i])
 			}
 		})
 	}
 }
 
+func (suite *EntityRepoSuite) TestEntitySeen() {
+	entity := model.Entity{EntityID: 1, EntityType: "t1"}
+
+	suite.NoError(suite.entityRepo.Save([]model.Entity{entity}))
+
+	time.Sleep(2 * time.Second)
+
+	first, err := suite.entityRepo.Find([]model.Entity{entity})
+	suite.NoError(err)
+	suite.NotNil(first[0].FirstSeen)
+	suite.Equal(first[0].FirstSeen, first[0].LastSeen)
+
+	suite.NoError(suite.entityRepo.Save([]model.Entity{entity}))
+
+	time.Sleep(2 * time.Second)
+
+	second, err := suite.entityRepo.Find([]model.Entity{entity})
+	suite.NoError(err)
+	suite.Equal(first[0].FirstSeen, second[0].FirstSeen)
+	suite.True(second[0].LastSeen.After(*first[0].LastSeen))
+}
+
+func (suite *EntityRepoSuite) TestEntityFindSeen() {
+	entity := model.Entity{EntityID: 1, EntityType: "t1", EntityContext: model.Context{"k1": model.StringValue("v1")}}
+
+	suite.NoError(suite.entityRepo.Save([]model.Entity{entity}))
+
+	time.Sleep(2 * time.Second)
+
+	entities, err := suite.entityRepo.FindSeen(
+		[]model.Entity{{EntityID: 1, EntityType: "t1"}, {EntityID: 2, EntityType: "t1"}},
+	)
+	suite.NoError(err)
+	suite.Nil(entities[0].EntityContext, "the context should not be loaded")
+	suite.NotNil(entities[0].FirstSeen)
+	suite.NotNil(entities[0].LastSeen)
+	suite.Nil(entities[1].FirstSeen)
+	suite.Nil(entities[1].LastSeen)
+}
+
+func (suite *EntityRepoSuite) TestEntitySeenAfterContextExpiration() {
+	entityRepo := model.NewRedisEntityRepo(
+		suite.entityRepo.RedisMaster, suite.entityRepo.RedisSlave, 1*time.Second, 24*time.Hour,
+	)
+
+	entity := model.Entity{EntityID: 1, EntityType: "t1", EntityContext: model.Context{"k1": model.StringValue("v1")}}
+
+	suite.NoError(entityRepo.Save([]model.Entity{entity}))
+
+	time.Sleep(3 * time.Second)
+
+	first, err := entityRepo.Find([]model.Entity{{EntityID: 1, EntityType: "t1"}})
+	suite.NoError(err)
+	suite.Nil(first[0].EntityContext, "the context should be expired")
+	suite.NotNil(first[0].FirstSeen)
+
+	suite.NoError(entityRepo.Save([]model.Entity{entity}))
+
+	time.Sleep(2 * time.Second)
+
+	second, err := entityRepo.Find([]model.Entity{{EntityID: 1, EntityType: "t1"}})
+	suite.NoError(err)
+	suite.Equal(first[0].FirstSeen, second[0].FirstSeen)
+	suite.True(second[0].LastSeen.After(*first[0].LastSeen))
+}
+
 func TestEntityRepoSuite(t *testing.T) {
 	suite.Run(t, new(EntityRepoSuite))
 }
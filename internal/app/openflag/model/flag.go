@@ -20,6 +20,9 @@ var (
 	ErrDuplicateFlagFound = errors.New("duplicate flag found")
 	// ErrInvalidFlagForUpdate represents an error for returning when we find that a flag is invalid in the update method.
 	ErrInvalidFlagForUpdate = errors.New("invalid flag for update")
+	// ErrLayerSliceOverlap represents an error for returning when the layer slice of a flag overlaps the slice
+	// of another flag in the same layer.
+	ErrLayerSliceOverlap = errors.New("layer slice overlaps another flag")
 )
 
 type (
@@ -32,6 +35,12 @@ type (
 		VariantAttachment json.RawMessage `json:"variant_attachment,omitempty"`
 	}
 
+	// WeightedVariant represents a variant with its share of the segment audience in percent.
+	WeightedVariant struct {
+		Variant
+		Weight int `json:"weight"`
+	}
+
 	// Constraint represents rules that we can use to define the audience of the segment.
 	// In other words, the audience in the segment is defined by a set of constraints.
 	Constraint struct {
@@ -40,22 +49,32 @@ type (
 	}
 
 	// Segment represents the segmentation, i.e. the set of audience we want to target.
+	// A segment assigns either its variant or one of its weighted variants to the audience.
+	// Rule keeps the source of the constraints when they are compiled from a rule.
 	Segment struct {
 		Description string                `json:"description"`
 		Constraints map[string]Constraint `json:"constraints"`
 		Expression  string                `json:"expression"`
+		Rule        string                `json:"rule,omitempty"`
 		Variant     Variant               `json:"variant"`
+		Variants    []WeightedVariant     `json:"variants,omitempty"`
 	}
 
 	// Flag represents each row of flags table in SQL database.
+	// A flag that is assigned to a layer claims the slices of the layer between the lower and upper bounds.
+	// The variants of a sticky flag are persisted for each entity on its first evaluation.
 	Flag struct {
-		ID          int64      `json:"id" gorm:"primary_key"`
-		Tags        *string    `json:"tags,omitempty"`
-		Description string     `json:"description"`
-		Flag        string     `json:"flag"`
-		Segments    string     `json:"segments"`
-		CreatedAt   time.Time  `json:"created_at"`
-		DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+		ID              int64      `json:"id" gorm:"primary_key"`
+		Tags            *string    `json:"tags,omitempty"`
+		Description     string     `json:"description"`
+		Flag            string     `json:"flag"`
+		Segments        string     `json:"segments"`
+		Layer           *string    `json:"layer,omitempty"`
+		LayerLowerBound int        `json:"layer_lower_bound"`
+		LayerUpperBound int        `json:"layer_upper_bound"`
+		Sticky          bool       `json:"sticky"`
+		CreatedAt       time.Time  `json:"created_at"`
+		DeletedAt       *time.Time `json:"deleted_at,omitempty"`
 	}
 )
 
@@ -68,6 +87,7 @@ type FlagRepo interface {
 	FindByID(id int64) (*Flag, error)
 	FindByTag(tag string) ([]Flag, error)
 	FindByFlag(flag string) ([]Flag, error)
+	FindByLayer(layer string) ([]Flag, error)
 	FindFlags(offset int, limit int, t time.Time) ([]Flag, error)
 }
 
@@ -92,6 +112,10 @@ func (s SQLFlagRepo) Create(flag *Flag) (finalErr error) {
 			return err
 		}
 
+		if err := checkLayerSlice(tx, flag); err != nil {
+			return err
+		}
+
 		if err := tx.Create(flag).Error; err != nil {
 			return err
 		}
@@ -130,6 +154,10 @@ func (s SQLFlagRepo) Update(id int64, flag *Flag) (finalErr error) {
 			return ErrInvalidFlagForUpdate
 		}
 
+		if err := checkLayerSlice(tx, flag); err != nil {
+			return err
+		}
+
 		if err := tx.Where("id = ?", id).Delete(&Flag{}).Error; err != nil {
 			return err
 		}
@@ -142,6 +170,39 @@ func (s SQLFlagRepo) Update(id int64, flag *Flag) (finalErr error) {
 	})
 }
 
+// checkLayerSlice checks that the layer slice of the given flag does not overlap the slices of the other flags
+// in its layer. It locks the row of the layer until the end of the transaction, so the concurrent creates and
+// updates of the flags in a layer check their slices one after another.
+func checkLayerSlice(tx *gorm.DB, flag *Flag) error {
+	if flag.Layer == nil {
+		return nil
+	}
+
+	err := tx.Set("gorm:query_option", "FOR UPDATE").Where("name = ?", *flag.Layer).Take(&Layer{}).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrLayerNotFound
+	} else if err != nil {
+		return err
+	}
+
+	var overlapping Flag
+
+	err = tx.Where(
+		"layer = ? and flag <> ? and layer_lower_bound <= ? and layer_upper_bound >= ?",
+		*flag.Layer, flag.Flag, flag.LayerUpperBound, flag.LayerLowerBound,
+	).Take(&overlapping).Error
+	if err == nil {
+		return fmt.Errorf(
+			"%w: %s [%d, %d]",
+			ErrLayerSliceOverlap, overlapping.Flag, overlapping.LayerLowerBound, overlapping.LayerUpperBound,
+		)
+	} else if !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+
+	return nil
+}
+
 // FindAll finds all flags from SQL database.
 func (s SQLFlagRepo) FindAll() (_ []Flag, finalErr error) {
 	startTime := time.Now()
@@ -212,6 +273,21 @@ func (s SQLFlagRepo) FindByFlag(flag string) (_ []Flag, finalErr error) {
 	return result, nil
 }
 
+// FindByLayer finds flags that are assigned to the given layer from SQL database.
+func (s SQLFlagRepo) FindByLayer(layer string) (_ []Flag, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(flagName, "find_by_layer", startTime, finalErr) }()
+
+	var result []Flag
+
+	if err := s.SlaveDB.Where("layer = ?", layer).Order("layer_lower_bound").Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // FindFlags finds flags with given offset and limit from SQL database.
 func (s SQLFlagRepo) FindFlags(offset int, limit int, t time.Time) (_ []Flag, finalErr error) {
 	startTime := time.Now()
@@ -1,6 +1,7 @@
 package model_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -40,10 +41,12 @@ func (suite *FlagRepoSuite) TearDownSuite() {
 
 func (suite *FlagRepoSuite) SetupTest() {
 	suite.NoError(suite.repo.MasterDB.Exec(`truncate table flags`).Error)
+	suite.NoError(suite.repo.MasterDB.Exec(`truncate table layers`).Error)
 }
 
 func (suite *FlagRepoSuite) TearDownTest() {
 	suite.NoError(suite.repo.MasterDB.Exec(`truncate table flags`).Error)
+	suite.NoError(suite.repo.MasterDB.Exec(`truncate table layers`).Error)
 }
 
 func (suite *FlagRepoSuite) TestScenario() {
@@ -139,6 +142,58 @@ func (suite *FlagRepoSuite) TestScenario() {
 
 	err = suite.repo.Update(findAllDbFlags[0].ID, &editedFlag)
 	suite.NoError(err)
+
+	layer := "checkout"
+
+	layerFlag := model.Flag{
+		Description:     "Description 5",
+		Flag:            "flag5",
+		Segments:        `{"foo": "bar"}`,
+		Layer:           &layer,
+		LayerLowerBound: 10,
+		LayerUpperBound: 19,
+	}
+
+	err = suite.repo.Create(&layerFlag)
+	suite.Equal(model.ErrLayerNotFound, err)
+
+	layerRepo := model.SQLLayerRepo{Driver: suite.repo.Driver, MasterDB: suite.repo.MasterDB, SlaveDB: suite.repo.SlaveDB}
+	suite.NoError(layerRepo.Create(&model.Layer{Name: layer}))
+
+	err = suite.repo.Create(&layerFlag)
+	suite.NoError(err)
+
+	overlappingFlag := model.Flag{
+		Description:     "Description 6",
+		Flag:            "flag6",
+		Segments:        `{"foo": "bar"}`,
+		Layer:           &layer,
+		LayerLowerBound: 15,
+		LayerUpperBound: 24,
+	}
+
+	err = suite.repo.Create(&overlappingFlag)
+	suite.True(errors.Is(err, model.ErrLayerSliceOverlap))
+
+	overlappingFlag.LayerLowerBound = 20
+
+	err = suite.repo.Create(&overlappingFlag)
+	suite.NoError(err)
+
+	layerFlag.LayerUpperBound = 20
+
+	err = suite.repo.Update(layerFlag.ID, &layerFlag)
+	suite.True(errors.Is(err, model.ErrLayerSliceOverlap))
+
+	findByLayerDbFlags, err := suite.repo.FindByLayer(layer)
+	suite.NoError(err)
+	suite.Equal(2, len(findByLayerDbFlags))
+	suite.Equal(layerFlag.Flag, findByLayerDbFlags[0].Flag)
+	suite.Equal(19, findByLayerDbFlags[0].LayerUpperBound)
+
+	findByLayerDbFlags, err = suite.repo.FindByLayer("pricing")
+	suite.NoError(err)
+	suite.Equal(0, len(findByLayerDbFlags))
 }
 
 func TestFlagRepoSuite(t *testing.T) {
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	frequencyCapName = "redis_frequency_cap"
+)
+
+// nolint:gochecknoglobals
+var (
+	// exposeScript increments all of the given counters only if none of them has reached its limit, so
+	// concurrent evaluations can not exceed the limits. A counter expires after its window that starts with
+	// its first exposure. The arguments are the limit and the window in milliseconds of each counter.
+	exposeScript = redis.NewScript(`
+for i, key in ipairs(KEYS) do
+	local count = tonumber(redis.call("GET", key) or "0")
+	if count >= tonumber(ARGV[2 * i - 1]) then
+		return 0
+	end
+end
+
+for i, key in ipairs(KEYS) do
+	if redis.call("INCR", key) == 1 then
+		redis.call("PEXPIRE", key, ARGV[2 * i])
+	end
+end
+
+return 1
+`)
+)
+
+// FrequencyCap represents an exposure counter of an entity that is limited to the given number of exposures
+// in each window.
+type FrequencyCap struct {
+	Counter string
+	Limit   int64
+	Window  time.Duration
+}
+
+// FrequencyCapRepo represents an interface for working with the exposure counters of the frequency caps.
+type FrequencyCapRepo interface {
+	Counts(counters []string) ([]int64, error)
+	Expose(caps []FrequencyCap) (bool, error)
+}
+
+// RedisFrequencyCapRepo is an implementation of FrequencyCapRepo for Redis. It keeps each counter in a key
+// that expires at the end of its window.
+type RedisFrequencyCapRepo struct {
+	RedisMaster redis.Cmdable
+	RedisSlave  redis.Cmdable
+}
+
+func (r RedisFrequencyCapRepo) counterKey(counter string) string {
+	return fmt.Sprintf("openflag:frequency_cap:%s", counter)
+}
+
+// Counts finds the number of the exposures of the given counters in their current windows using a single
+// request to Redis. The counters are read from the slave, so they may be behind the exposures under replication
+// lag. The limits are still kept, because Expose checks the counters on the master before increasing them.
+func (r RedisFrequencyCapRepo) Counts(counters []string) (_ []int64, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(frequencyCapName, "counts", startTime, finalErr) }()
+
+	if len(counters) == 0 {
+		return []int64{}, nil
+	}
+
+	keys := make([]string, 0, len(counters))
+
+	for _, counter := range counters {
+		keys = append(keys, r.counterKey(counter))
+	}
+
+	values, err := r.RedisSlave.MGet(keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int64, len(values))
+
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("cannot read counter")
+		}
+
+		if counts[i], err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+
+	return counts, nil
+}
+
+// Expose atomically increments the given counters if none of them has reached its limit. It returns false
+// without incrementing any counter if one of them has reached its limit.
+func (r RedisFrequencyCapRepo) Expose(caps []FrequencyCap) (_ bool, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(frequencyCapName, "expose", startTime, finalErr) }()
+
+	if len(caps) == 0 {
+		return true, nil
+	}
+
+	keys := make([]string, 0, len(caps))
+	args := make([]interface{}, 0, 2*len(caps))
+
+	for _, c := range caps {
+		keys = append(keys, r.counterKey(c.Counter))
+		args = append(args, c.Limit, c.Window.Milliseconds())
+	}
+
+	exposed, err := exposeScript.Run(r.RedisMaster, keys, args...).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	return exposed == 1, nil
+}
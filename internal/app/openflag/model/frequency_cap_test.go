@@ -0,0 +1,109 @@
+package model_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/config"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/pkg/redis"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FrequencyCapRepoSuite struct {
+	suite.Suite
+	frequencyCapRepo model.RedisFrequencyCapRepo
+}
+
+func (suite *FrequencyCapRepoSuite) SetupSuite() {
+	cfg := config.Init()
+
+	redisCfg := cfg.Redis
+
+	rMaster, _ := redis.Create(redisCfg.MasterAddress, redisCfg.Options, true)
+	rSlave, _ := redis.Create(redisCfg.SlaveAddress, redisCfg.Options, false)
+
+	suite.frequencyCapRepo = model.RedisFrequencyCapRepo{RedisMaster: rMaster, RedisSlave: rSlave}
+}
+
+func (suite *FrequencyCapRepoSuite) SetupTest() {
+	suite.NoError(suite.frequencyCapRepo.RedisMaster.FlushDB().Err())
+}
+
+func (suite *FrequencyCapRepoSuite) TearDownTest() {
+	suite.NoError(suite.frequencyCapRepo.RedisMaster.FlushDB().Err())
+}
+
+func (suite *FrequencyCapRepoSuite) TestFrequencyCapRepo() {
+	promo := model.FrequencyCap{Counter: "promo:1", Limit: 2, Window: time.Hour}
+	banner := model.FrequencyCap{Counter: "banner:1", Limit: 3, Window: time.Hour}
+
+	counts, err := suite.frequencyCapRepo.Counts([]string{"promo:1", "banner:1"})
+	suite.NoError(err)
+	suite.Equal([]int64{0, 0}, counts)
+
+	for i := 0; i < 2; i++ {
+		exposed, err := suite.frequencyCapRepo.Expose([]model.FrequencyCap{promo, banner})
+		suite.NoError(err)
+		suite.True(exposed)
+	}
+
+	exposed, err := suite.frequencyCapRepo.Expose([]model.FrequencyCap{promo, banner})
+	suite.NoError(err)
+	suite.False(exposed)
+
+	exposed, err = suite.frequencyCapRepo.Expose([]model.FrequencyCap{banner})
+	suite.NoError(err)
+	suite.True(exposed)
+
+	counts, err = suite.frequencyCapRepo.Counts([]string{"promo:1", "banner:1", "promo:2"})
+	suite.NoError(err)
+	suite.Equal([]int64{2, 3, 0}, counts)
+
+	ttl, err := suite.frequencyCapRepo.RedisMaster.TTL("openflag:frequency_cap:promo:1").Result()
+	suite.NoError(err)
+	suite.True(ttl > 0 && ttl <= time.Hour)
+}
+
+func (suite *FrequencyCapRepoSuite) TestFrequencyCapRepoConcurrentExposures() {
+	const evaluations = 50
+
+	c := model.FrequencyCap{Counter: "promo:1", Limit: 10, Window: time.Hour}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		exposed int
+	)
+
+	for i := 0; i < evaluations; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ok, err := suite.frequencyCapRepo.Expose([]model.FrequencyCap{c})
+			suite.NoError(err)
+
+			if ok {
+				mu.Lock()
+				exposed++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	suite.Equal(10, exposed)
+
+	counts, err := suite.frequencyCapRepo.Counts([]string{"promo:1"})
+	suite.NoError(err)
+	suite.Equal([]int64{10}, counts)
+}
+
+func TestFrequencyCapRepoSuite(t *testing.T) {
+	suite.Run(t, new(FrequencyCapRepoSuite))
+}
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	layerName = "sql_layer"
+)
+
+var (
+	// ErrLayerNotFound represents an error for returning when we can't find a layer with given parameters.
+	ErrLayerNotFound = errors.New("layer not found")
+	// ErrDuplicateLayerFound represents an error for returning when we find a layer with a given name
+	// in layer creation.
+	ErrDuplicateLayerFound = errors.New("duplicate layer found")
+	// ErrInvalidLayerForUpdate represents an error for returning when we find that a layer is invalid
+	// in the update method.
+	ErrInvalidLayerForUpdate = errors.New("invalid layer for update")
+)
+
+// Layer represents each row of layers table in SQL database.
+// A layer owns a hash space of 100 slices. Each flag that is assigned to the layer claims a range of the slices
+// and an entity only sees the flags that claimed its slice, so the flags of a layer are mutually exclusive.
+// The slice of an entity comes from a hash of the layer name and the given property (the entity id by default).
+// A layer with a holdout percentage is a global holdout. Flags can not be assigned to it and the entities in
+// the given percentage of its slices do not see any flag, whether it is in a layer or not.
+type Layer struct {
+	ID          int64     `json:"id" gorm:"primary_key"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Property    string    `json:"property"`
+	Holdout     int       `json:"holdout"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// LayerRepo represents an interface for working with persist layers.
+type LayerRepo interface {
+	Create(layer *Layer) error
+	Delete(id int64) error
+	Update(id int64, layer *Layer) error
+	FindAll() ([]Layer, error)
+	FindByID(id int64) (*Layer, error)
+	FindByName(name string) (*Layer, error)
+}
+
+// SQLLayerRepo is an implementation of LayerRepo for SQL databases.
+type SQLLayerRepo struct {
+	Driver   string
+	MasterDB *gorm.DB
+	SlaveDB  *gorm.DB
+}
+
+// Create creates a layer in SQL database.
+func (s SQLLayerRepo) Create(layer *Layer) (finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(layerName, "create", startTime, finalErr) }()
+
+	return s.MasterDB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("name = ?", layer.Name).Take(&Layer{}).Error
+		if err == nil {
+			return ErrDuplicateLayerFound
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		if err := tx.Create(layer).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Delete deletes a layer from SQL database.
+func (s SQLLayerRepo) Delete(id int64) (finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(layerName, "delete", startTime, finalErr) }()
+
+	return s.MasterDB.Where("id = ?", id).Delete(&Layer{}).Error
+}
+
+// Update updates a layer in SQL database. The layer name can not be changed because flags reference
+// layers by their names.
+func (s SQLLayerRepo) Update(id int64, layer *Layer) (finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(layerName, "update", startTime, finalErr) }()
+
+	return s.MasterDB.Transaction(func(tx *gorm.DB) error {
+		var l Layer
+
+		if err := tx.Where("id = ?", id).Find(&l).Error; err != nil {
+			if gorm.IsRecordNotFoundError(err) {
+				return ErrLayerNotFound
+			}
+
+			return err
+		}
+
+		if l.Name != layer.Name {
+			return ErrInvalidLayerForUpdate
+		}
+
+		layer.ID = l.ID
+		layer.CreatedAt = l.CreatedAt
+
+		return tx.Save(layer).Error
+	})
+}
+
+// FindAll finds all layers from SQL database.
+func (s SQLLayerRepo) FindAll() (_ []Layer, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(layerName, "find_all", startTime, finalErr) }()
+
+	var result []Layer
+
+	if err := s.SlaveDB.Order("id").Find(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindByID finds a layer with it's given id from SQL database.
+func (s SQLLayerRepo) FindByID(id int64) (_ *Layer, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(layerName, "find_by_id", startTime, finalErr) }()
+
+	var result Layer
+
+	if err := s.SlaveDB.Where("id = ?", id).Find(&result).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, ErrLayerNotFound
+		}
+
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// FindByName finds a layer with it's given name from SQL database.
+func (s SQLLayerRepo) FindByName(name string) (_ *Layer, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(layerName, "find_by_name", startTime, finalErr) }()
+
+	var result Layer
+
+	if err := s.SlaveDB.Where("name = ?", name).Find(&result).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, ErrLayerNotFound
+		}
+
+		return nil, err
+	}
+
+	return &result, nil
+}
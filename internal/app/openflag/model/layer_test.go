@@ -0,0 +1,119 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/config"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/pkg/database"
+	"github.com/stretchr/testify/suite"
+)
+
+type LayerRepoSuite struct {
+	suite.Suite
+	repo model.SQLLayerRepo
+}
+
+func (suite *LayerRepoSuite) SetupSuite() {
+	cfg := config.Init()
+	dbCfg := cfg.Database
+
+	masterDb, err := database.Create(dbCfg.Driver, dbCfg.MasterConnStr, dbCfg.Options)
+	suite.NoError(err)
+	suite.NotNil(masterDb)
+
+	slaveDb, err := database.Create(dbCfg.Driver, dbCfg.SlaveConnStr, dbCfg.Options)
+	suite.NoError(err)
+	suite.NotNil(slaveDb)
+
+	suite.repo = model.SQLLayerRepo{
+		Driver:   dbCfg.Driver,
+		MasterDB: masterDb,
+		SlaveDB:  slaveDb,
+	}
+}
+
+func (suite *LayerRepoSuite) TearDownSuite() {
+	suite.NoError(suite.repo.MasterDB.Close())
+}
+
+func (suite *LayerRepoSuite) SetupTest() {
+	suite.NoError(suite.repo.MasterDB.Exec(`truncate table layers`).Error)
+}
+
+func (suite *LayerRepoSuite) TearDownTest() {
+	suite.NoError(suite.repo.MasterDB.Exec(`truncate table layers`).Error)
+}
+
+func (suite *LayerRepoSuite) TestScenario() {
+	layers := []model.Layer{
+		{
+			Name:        "checkout",
+			Description: "Description 1",
+		},
+		{
+			Name:        "holdout",
+			Description: "Description 2",
+			Holdout:     5,
+		},
+	}
+
+	// nolint:scopelint
+	for _, l := range layers {
+		err := suite.repo.Create(&l)
+		suite.NoError(err)
+	}
+
+	err := suite.repo.Create(&layers[0])
+	suite.Equal(model.ErrDuplicateLayerFound, err)
+
+	findAllDbLayers, err := suite.repo.FindAll()
+	suite.NoError(err)
+	suite.Equal(len(layers), len(findAllDbLayers))
+
+	findByIDDbLayer, err := suite.repo.FindByID(findAllDbLayers[0].ID)
+	suite.NoError(err)
+	suite.Equal(findAllDbLayers[0].Name, findByIDDbLayer.Name)
+
+	findByNameDbLayer, err := suite.repo.FindByName(layers[1].Name)
+	suite.NoError(err)
+	suite.Equal(layers[1].Holdout, findByNameDbLayer.Holdout)
+
+	_, err = suite.repo.FindByID(100)
+	suite.Equal(model.ErrLayerNotFound, err)
+
+	_, err = suite.repo.FindByName("others")
+	suite.Equal(model.ErrLayerNotFound, err)
+
+	editedLayer := model.Layer{
+		Name:        "others",
+		Description: "Description 3",
+		Property:    "company_id",
+	}
+
+	err = suite.repo.Update(100, &editedLayer)
+	suite.Equal(model.ErrLayerNotFound, err)
+
+	err = suite.repo.Update(findAllDbLayers[0].ID, &editedLayer)
+	suite.Equal(model.ErrInvalidLayerForUpdate, err)
+
+	editedLayer.Name = findAllDbLayers[0].Name
+
+	err = suite.repo.Update(findAllDbLayers[0].ID, &editedLayer)
+	suite.NoError(err)
+
+	findByIDDbLayer, err = suite.repo.FindByID(findAllDbLayers[0].ID)
+	suite.NoError(err)
+	suite.Equal(editedLayer.Property, findByIDDbLayer.Property)
+
+	err = suite.repo.Delete(findAllDbLayers[0].ID)
+	suite.NoError(err)
+
+	findAllDbLayers, err = suite.repo.FindAll()
+	suite.NoError(err)
+	suite.Equal(len(layers)-1, len(findAllDbLayers))
+}
+
+func TestLayerRepoSuite(t *testing.T) {
+	suite.Run(t, new(LayerRepoSuite))
+}
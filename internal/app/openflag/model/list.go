@@ -0,0 +1,233 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	listName        = "redis_list"
+	listUploadChunk = 10000
+)
+
+var (
+	// ErrListNotFound represents an error for returning when we can't find a list with given name.
+	ErrListNotFound = errors.New("list not found")
+	// ErrEmptyList represents an error for returning when we want to upload a list without any item.
+	ErrEmptyList = errors.New("empty list")
+)
+
+type (
+	// List represents a named set of IDs that constraints can check the membership of entities in.
+	// Version is incremented on each upload of the list.
+	List struct {
+		Name    string `json:"name"`
+		Version int64  `json:"version"`
+		Size    int64  `json:"size"`
+	}
+
+	// ListLookup represents a membership lookup of a value in a list.
+	ListLookup struct {
+		List  string
+		Value string
+	}
+)
+
+// ListRepo represents an interface for working with persist lists.
+type ListRepo interface {
+	Upload(name string, ids []string) (*List, error)
+	Find(name string) (*List, error)
+	Versions(names []string) (map[string]int64, error)
+	Contains(lookups []ListLookup) ([]bool, error)
+}
+
+// RedisListRepo is an implementation of ListRepo for Redis. It keeps each list in a Redis set.
+type RedisListRepo struct {
+	RedisMaster redis.Cmdable
+	RedisSlave  redis.Cmdable
+}
+
+func (r RedisListRepo) listKey(name string) string {
+	return fmt.Sprintf("openflag:list:%s:items", name)
+}
+
+func (r RedisListRepo) listVersionKey(name string) string {
+	return fmt.Sprintf("openflag:list:%s:version", name)
+}
+
+func (r RedisListRepo) listUploadKey(name string) string {
+	return fmt.Sprintf("openflag:list:%s:upload:%d", name, time.Now().UnixNano())
+}
+
+// Upload uploads a list into Redis. The whole list is replaced atomically, so the evaluations never see
+// a partially uploaded list.
+func (r RedisListRepo) Upload(name string, ids []string) (_ *List, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(listName, "upload", startTime, finalErr) }()
+
+	if len(ids) == 0 {
+		return nil, ErrEmptyList
+	}
+
+	uploadKey := r.listUploadKey(name)
+
+	result, err := r.RedisMaster.Pipelined(func(pipeliner redis.Pipeliner) error {
+		for start := 0; start < len(ids); start += listUploadChunk {
+			end := start + listUploadChunk
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			members := make([]interface{}, 0, end-start)
+
+			for _, id := range ids[start:end] {
+				members = append(members, id)
+			}
+
+			pipeliner.SAdd(uploadKey, members...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.RedisMaster.Del(uploadKey)
+		return nil, err
+	}
+
+	var size int64
+
+	for i := range result {
+		cmd, ok := result[i].(*redis.IntCmd)
+		if !ok {
+			return nil, errors.New("cannot read pipeline result")
+		}
+
+		size += cmd.Val()
+	}
+
+	var version *redis.IntCmd
+
+	_, err = r.RedisMaster.TxPipelined(func(pipeliner redis.Pipeliner) error {
+		pipeliner.Rename(uploadKey, r.listKey(name))
+		version = pipeliner.Incr(r.listVersionKey(name))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &List{Name: name, Version: version.Val(), Size: size}, nil
+}
+
+// Find finds a list with its given name from Redis.
+func (r RedisListRepo) Find(name string) (_ *List, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(listName, "find", startTime, finalErr) }()
+
+	var (
+		version *redis.StringCmd
+		size    *redis.IntCmd
+	)
+
+	_, err := r.RedisSlave.Pipelined(func(pipeliner redis.Pipeliner) error {
+		version = pipeliner.Get(r.listVersionKey(name))
+		size = pipeliner.SCard(r.listKey(name))
+
+		return nil
+	})
+	if err == redis.Nil {
+		return nil, ErrListNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	v, err := version.Int64()
+	if err != nil {
+		return nil, err
+	}
+
+	return &List{Name: name, Version: v, Size: size.Val()}, nil
+}
+
+// Versions finds the current versions of the given lists from Redis. Lists that are not uploaded yet
+// are not in the result.
+func (r RedisListRepo) Versions(names []string) (_ map[string]int64, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(listName, "versions", startTime, finalErr) }()
+
+	result := map[string]int64{}
+
+	if len(names) == 0 {
+		return result, nil
+	}
+
+	keys := make([]string, 0, len(names))
+
+	for _, name := range names {
+		keys = append(keys, r.listVersionKey(name))
+	}
+
+	values, err := r.RedisSlave.MGet(keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		version, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		result[names[i]] = version
+	}
+
+	return result, nil
+}
+
+// Contains checks the membership of the given lookups using a single pipelined request to Redis.
+func (r RedisListRepo) Contains(lookups []ListLookup) (_ []bool, finalErr error) {
+	startTime := time.Now()
+
+	defer func() { metrics.report(listName, "contains", startTime, finalErr) }()
+
+	if len(lookups) == 0 {
+		return []bool{}, nil
+	}
+
+	result, err := r.RedisSlave.Pipelined(func(pipeliner redis.Pipeliner) error {
+		for _, lookup := range lookups {
+			pipeliner.SIsMember(r.listKey(lookup.List), lookup.Value)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]bool, len(result))
+
+	for i := range result {
+		cmd, ok := result[i].(*redis.BoolCmd)
+		if !ok {
+			return nil, errors.New("cannot read pipeline result")
+		}
+
+		members[i] = cmd.Val()
+	}
+
+	return members, nil
+}
@@ -0,0 +1,72 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/config"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/pkg/redis"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ListRepoSuite struct {
+	suite.Suite
+	listRepo model.RedisListRepo
+}
+
+func (suite *ListRepoSuite) SetupSuite() {
+	cfg := config.Init()
+
+	redisCfg := cfg.Redis
+
+	rMaster, _ := redis.Create(redisCfg.MasterAddress, redisCfg.Options, true)
+	rSlave, _ := redis.Create(redisCfg.SlaveAddress, redisCfg.Options, false)
+
+	suite.listRepo = model.RedisListRepo{RedisMaster: rMaster, RedisSlave: rSlave}
+}
+
+func (suite *ListRepoSuite) SetupTest() {
+	suite.NoError(suite.listRepo.RedisMaster.FlushDB().Err())
+}
+
+func (suite *ListRepoSuite) TearDownTest() {
+	suite.NoError(suite.listRepo.RedisMaster.FlushDB().Err())
+}
+
+func (suite *ListRepoSuite) TestListRepo() {
+	_, err := suite.listRepo.Find("beta.users")
+	suite.Equal(model.ErrListNotFound, err)
+
+	_, err = suite.listRepo.Upload("beta.users", nil)
+	suite.Equal(model.ErrEmptyList, err)
+
+	list, err := suite.listRepo.Upload("beta.users", []string{"1", "2", "3", "3"})
+	suite.NoError(err)
+	suite.Equal(model.List{Name: "beta.users", Version: 1, Size: 3}, *list)
+
+	list, err = suite.listRepo.Upload("beta.users", []string{"2", "4"})
+	suite.NoError(err)
+	suite.Equal(model.List{Name: "beta.users", Version: 2, Size: 2}, *list)
+
+	list, err = suite.listRepo.Find("beta.users")
+	suite.NoError(err)
+	suite.Equal(model.List{Name: "beta.users", Version: 2, Size: 2}, *list)
+
+	versions, err := suite.listRepo.Versions([]string{"beta.users", "countries"})
+	suite.NoError(err)
+	suite.Equal(map[string]int64{"beta.users": 2}, versions)
+
+	members, err := suite.listRepo.Contains([]model.ListLookup{
+		{List: "beta.users", Value: "1"},
+		{List: "beta.users", Value: "2"},
+		{List: "beta.users", Value: "4"},
+		{List: "countries", Value: "IR"},
+	})
+	suite.NoError(err)
+	suite.Equal([]bool{false, true, true, false}, members)
+}
+
+func TestListRepoSuite(t *testing.T) {
+	suite.Run(t, new(ListRepoSuite))
+}
@@ -0,0 +1,244 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Represents the kinds of entity context values.
+const (
+	StringKind ValueKind = iota
+	NumberKind
+	BoolKind
+	ListKind
+	ObjectKind
+)
+
+const listSeparator = ","
+
+// ErrInvalidValue represents an error for returning when an entity context value is not a string, a number,
+// a boolean, a list of strings or an object.
+var ErrInvalidValue = errors.New(
+	"context value should be a string, a number, a boolean, a list of strings or an object",
+)
+
+type (
+	// ValueKind represents the kind of an entity context value.
+	ValueKind int
+
+	// Value represents a typed value of the entity context. It is a string, a number, a boolean, a list of
+	// strings or an object of nested values, and it is encoded as the corresponding JSON type. The accessors
+	// convert the value when it is possible, so the values that old clients send as strings still work with
+	// typed constraints.
+	Value struct {
+		kind    ValueKind
+		str     string
+		number  float64
+		boolean bool
+		list    []string
+		object  Context
+	}
+
+	// Context represents the entity context, i.e. the typed values of the entity properties.
+	Context map[string]Value
+)
+
+// StringValue creates a string value.
+func StringValue(s string) Value {
+	return Value{kind: StringKind, str: s}
+}
+
+// NumberValue creates a number value.
+func NumberValue(n float64) Value {
+	return Value{kind: NumberKind, number: n}
+}
+
+// BoolValue creates a boolean value.
+func BoolValue(b bool) Value {
+	return Value{kind: BoolKind, boolean: b}
+}
+
+// ListValue creates a list of strings value.
+func ListValue(l []string) Value {
+	return Value{kind: ListKind, list: l}
+}
+
+// ObjectValue creates an object value from the given nested values.
+func ObjectValue(o Context) Value {
+	return Value{kind: ObjectKind, object: o}
+}
+
+// StringContext creates a context from string values, e.g. the context of the old clients.
+func StringContext(values map[string]string) Context {
+	if values == nil {
+		return nil
+	}
+
+	context := Context{}
+
+	for key, value := range values {
+		context[key] = StringValue(value)
+	}
+
+	return context
+}
+
+// Strings returns the string form of the context values.
+func (c Context) Strings() map[string]string {
+	if c == nil {
+		return nil
+	}
+
+	values := map[string]string{}
+
+	for key, value := range c {
+		values[key] = value.String()
+	}
+
+	return values
+}
+
+// Kind returns the kind of the value.
+func (v Value) Kind() ValueKind {
+	return v.kind
+}
+
+// String returns the string form of the value. The items of a list are separated by commas and an object
+// is encoded as JSON.
+func (v Value) String() string {
+	switch v.kind {
+	case NumberKind:
+		return strconv.FormatFloat(v.number, 'f', -1, 64)
+	case BoolKind:
+		return strconv.FormatBool(v.boolean)
+	case ListKind:
+		return strings.Join(v.list, listSeparator)
+	case ObjectKind:
+		data, _ := json.Marshal(v.object)
+		return string(data)
+	default:
+		return v.str
+	}
+}
+
+// Number returns the value as a number. A string value is parsed.
+func (v Value) Number() (float64, bool) {
+	switch v.kind {
+	case NumberKind:
+		return v.number, true
+	case StringKind:
+		n, err := strconv.ParseFloat(v.str, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Int returns the value as an integer. A number value should not have a fractional part and a string value
+// is parsed.
+func (v Value) Int() (int64, bool) {
+	switch v.kind {
+	case NumberKind:
+		if v.number != math.Trunc(v.number) || math.Abs(v.number) > math.MaxInt64 {
+			return 0, false
+		}
+
+		return int64(v.number), true
+	case StringKind:
+		n, err := strconv.ParseInt(v.str, 0, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Bool returns the value as a boolean. A string value is parsed.
+func (v Value) Bool() (bool, bool) {
+	switch v.kind {
+	case BoolKind:
+		return v.boolean, true
+	case StringKind:
+		b, err := strconv.ParseBool(v.str)
+		return b, err == nil
+	default:
+		return false, false
+	}
+}
+
+// List returns the value as a list of strings. Other values are returned as a list with a single item.
+func (v Value) List() []string {
+	if v.kind == ListKind {
+		return v.list
+	}
+
+	return []string{v.String()}
+}
+
+// Object returns the nested values of an object value.
+func (v Value) Object() (Context, bool) {
+	return v.object, v.kind == ObjectKind
+}
+
+// MarshalJSON is an implementation for the json.Marshaler interface.
+func (v Value) MarshalJSON() ([]byte, error) {
+	switch v.kind {
+	case NumberKind:
+		return json.Marshal(v.number)
+	case BoolKind:
+		return json.Marshal(v.boolean)
+	case ListKind:
+		if v.list == nil {
+			return []byte("[]"), nil
+		}
+
+		return json.Marshal(v.list)
+	case ObjectKind:
+		if v.object == nil {
+			return []byte("{}"), nil
+		}
+
+		return json.Marshal(v.object)
+	default:
+		return json.Marshal(v.str)
+	}
+}
+
+// UnmarshalJSON is an implementation for the json.Unmarshaler interface.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return ErrInvalidValue
+	}
+
+	var err error
+
+	switch data[0] {
+	case '"':
+		*v = Value{kind: StringKind}
+		err = json.Unmarshal(data, &v.str)
+	case 't', 'f':
+		*v = Value{kind: BoolKind}
+		err = json.Unmarshal(data, &v.boolean)
+	case '[':
+		*v = Value{kind: ListKind, list: []string{}}
+		err = json.Unmarshal(data, &v.list)
+	case '{':
+		*v = Value{kind: ObjectKind, object: Context{}}
+		err = json.Unmarshal(data, &v.object)
+	case 'n':
+		return ErrInvalidValue
+	default:
+		*v = Value{kind: NumberKind}
+		err = json.Unmarshal(data, &v.number)
+	}
+
+	if err != nil {
+		return ErrInvalidValue
+	}
+
+	return nil
+}
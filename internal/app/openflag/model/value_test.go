@@ -0,0 +1,94 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextJSON(t *testing.T) {
+	context := model.Context{}
+
+	err := json.Unmarshal(
+		[]byte(`{"country": "IR", "age": 21, "beta": true, "roles": ["admin", "editor"], "score": "4.5"}`),
+		&context,
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, model.Context{
+		"country": model.StringValue("IR"),
+		"age":     model.NumberValue(21),
+		"beta":    model.BoolValue(true),
+		"roles":   model.ListValue([]string{"admin", "editor"}),
+		"score":   model.StringValue("4.5"),
+	}, context)
+
+	data, err := json.Marshal(context)
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t, `{"country": "IR", "age": 21, "beta": true, "roles": ["admin", "editor"], "score": "4.5"}`, string(data),
+	)
+
+	assert.Equal(t, map[string]string{
+		"country": "IR", "age": "21", "beta": "true", "roles": "admin,editor", "score": "4.5",
+	}, context.Strings())
+
+	for _, invalid := range []string{`{"a": null}`, `{"a": {"b": null}}`, `{"a": [1, 2]}`} {
+		assert.Error(t, json.Unmarshal([]byte(invalid), &model.Context{}), invalid)
+	}
+}
+
+func TestNestedContextJSON(t *testing.T) {
+	context := model.Context{}
+
+	err := json.Unmarshal([]byte(`{"device": {"os": {"name": "ios", "version": "14.2"}, "tablet": false}}`), &context)
+	assert.NoError(t, err)
+
+	device, ok := context["device"].Object()
+	assert.True(t, ok)
+	assert.Equal(t, model.BoolValue(false), device["tablet"])
+
+	os, ok := device["os"].Object()
+	assert.True(t, ok)
+	assert.Equal(t, model.StringValue("14.2"), os["version"])
+
+	data, err := json.Marshal(context)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"device": {"os": {"name": "ios", "version": "14.2"}, "tablet": false}}`, string(data))
+	assert.JSONEq(t, `{"name": "ios", "version": "14.2"}`, device["os"].String())
+
+	_, ok = model.StringValue("ios").Object()
+	assert.False(t, ok)
+}
+
+func TestValueAccessors(t *testing.T) {
+	n, ok := model.StringValue("4.5").Number()
+	assert.True(t, ok)
+	assert.Equal(t, 4.5, n)
+
+	_, ok = model.StringValue("four").Number()
+	assert.False(t, ok)
+
+	_, ok = model.BoolValue(true).Number()
+	assert.False(t, ok)
+
+	i, ok := model.NumberValue(42).Int()
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), i)
+
+	_, ok = model.NumberValue(4.2).Int()
+	assert.False(t, ok)
+
+	b, ok := model.StringValue("true").Bool()
+	assert.True(t, ok)
+	assert.True(t, b)
+
+	_, ok = model.NumberValue(1).Bool()
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"a", "b"}, model.ListValue([]string{"a", "b"}).List())
+	assert.Equal(t, []string{"a"}, model.StringValue("a").List())
+	assert.Equal(t, model.StringContext(map[string]string{"a": "b"}), model.Context{"a": model.StringValue("b")})
+}
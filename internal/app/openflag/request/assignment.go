@@ -0,0 +1,44 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	// ResetAssignmentsRequest represents a request for resetting the sticky variant assignments of a flag.
+	ResetAssignmentsRequest struct {
+		Flag string
+	}
+
+	// ResetEntityAssignmentRequest represents a request for resetting the sticky variant assignment of
+	// an entity in a flag.
+	ResetEntityAssignmentRequest struct {
+		Flag   string
+		Entity Entity
+	}
+)
+
+// Validate validates ResetAssignmentsRequest struct.
+func (r ResetAssignmentsRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(
+			&r.Flag,
+			validation.Required,
+			validation.Match(nameRegex),
+		),
+	)
+}
+
+// Validate validates ResetEntityAssignmentRequest struct.
+func (r ResetEntityAssignmentRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(
+			&r.Flag,
+			validation.Required,
+			validation.Match(nameRegex),
+		),
+		validation.Field(
+			&r.Entity,
+		),
+	)
+}
@@ -0,0 +1,69 @@
+package request
+
+import (
+	"errors"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	// Audience represents a named and reusable set of constraints that segments can reference
+	// using the audience constraint.
+	Audience struct {
+		Name        string                `json:"name"`
+		Description string                `json:"description"`
+		Constraints map[string]Constraint `json:"constraints"`
+		Expression  string                `json:"expression"`
+	}
+
+	// CreateAudienceRequest represents a request body for creating an audience.
+	CreateAudienceRequest struct {
+		Audience
+	}
+
+	// UpdateAudienceRequest represents a request body for updating an audience.
+	UpdateAudienceRequest struct {
+		Audience
+	}
+)
+
+// Validate validates Audience struct.
+func (a Audience) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(
+			&a.Name,
+			validation.Required,
+			validation.Match(nameRegex),
+		),
+		validation.Field(
+			&a.Description,
+			validation.Required,
+		),
+		validation.Field(
+			&a.Constraints,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				for _, c := range a.Constraints {
+					if c.Name == constraint.AudienceConstraintName {
+						return errors.New("audiences can not reference other audiences")
+					}
+
+					if c.Name == constraint.PrerequisiteConstraintName {
+						return errors.New("audiences can not have prerequisite flags")
+					}
+				}
+
+				return validateConstraints(a.Constraints)
+			}),
+		),
+		validation.Field(
+			&a.Expression,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				return validateExpression(a.Expression, a.Constraints)
+			}),
+		),
+	)
+}
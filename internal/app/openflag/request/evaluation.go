@@ -3,6 +3,8 @@ package request
 import (
 	"errors"
 
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
@@ -15,17 +17,19 @@ type (
 	// Usually, OpenFlag expects the context coming with the entity,
 	// so that one can define constraints based on the context of the entity.
 	Entity struct {
-		EntityID      int64             `json:"entity_id"`
-		EntityType    string            `json:"entity_type"`
-		EntityContext map[string]string `json:"entity_context,omitempty"`
+		EntityID      int64         `json:"entity_id"`
+		EntityType    string        `json:"entity_type"`
+		EntityContext model.Context `json:"entity_context,omitempty"`
 	}
 
 	// EvaluationRequest represents a request for evaluation of some entities.
+	// If Explain is set, the response explains how each flag is evaluated for each entity.
 	EvaluationRequest struct {
 		Entities          []Entity `json:"entities"`
 		Flags             []string `json:"flags,omitempty"`
 		SaveContexts      bool     `json:"save_contexts,omitempty"`
 		UseStoredContexts bool     `json:"use_stored_contexts,omitempty"`
+		Explain           bool     `json:"explain,omitempty"`
 	}
 )
 
@@ -17,6 +17,10 @@ const (
 	minSegmentLen = 1
 	maxLimit      = 100
 
+	minVariantWeight = 1
+	maxVariantWeight = 100
+	totalWeight      = 100
+
 	nameFormat = `^[a-z0-9]+(?:\.[a-z0-9]+)*$`
 )
 
@@ -35,6 +39,12 @@ type (
 		VariantAttachment json.RawMessage `json:"variant_attachment,omitempty"`
 	}
 
+	// WeightedVariant represents a variant with its share of the segment audience in percent.
+	WeightedVariant struct {
+		Variant
+		Weight int `json:"weight"`
+	}
+
 	// Constraint represents rules that we can use to define the audience of the segment.
 	// In other words, the audience in the segment is defined by a set of constraints.
 	Constraint struct {
@@ -43,19 +53,29 @@ type (
 	}
 
 	// Segment represents the segmentation, i.e. the set of audience we want to target.
+	// A segment assigns either its variant or one of its weighted variants to the audience.
+	// The audience is defined either by the constraints and the expression or by a rule,
+	// e.g. `country in ["IR", "DE"] and age >= 18`.
 	Segment struct {
 		Description string                `json:"description"`
-		Constraints map[string]Constraint `json:"constraints"`
-		Expression  string                `json:"expression"`
+		Constraints map[string]Constraint `json:"constraints,omitempty"`
+		Expression  string                `json:"expression,omitempty"`
+		Rule        string                `json:"rule,omitempty"`
 		Variant     Variant               `json:"variant"`
+		Variants    []WeightedVariant     `json:"variants,omitempty"`
 	}
 
 	// Flag represents a feature flag, an experiment, or a configuration.
+	// A flag in a layer is only evaluated for the entities in the slices of the layer that it claims.
+	// A sticky flag keeps serving the first variant of each entity when its segments change. It can not have
+	// frequency caps, because the entities that are assigned to a variant do not go through the segments again.
 	Flag struct {
-		Tags        []string  `json:"tags,omitempty"`
-		Description string    `json:"description"`
-		Flag        string    `json:"flag"`
-		Segments    []Segment `json:"segments"`
+		Tags        []string    `json:"tags,omitempty"`
+		Description string      `json:"description"`
+		Flag        string      `json:"flag"`
+		Segments    []Segment   `json:"segments"`
+		Layer       *LayerSlice `json:"layer,omitempty"`
+		Sticky      bool        `json:"sticky,omitempty"`
 	}
 
 	// CreateFlagRequest represents a request body for creating a flag.
@@ -68,6 +88,11 @@ type (
 		Flag
 	}
 
+	// LintFlagRequest represents a request body for analyzing the segments of a flag without saving it.
+	LintFlagRequest struct {
+		Flag
+	}
+
 	// FindFlagsByTagRequest represents a request body for finding flags that hav given tag.
 	FindFlagsByTagRequest struct {
 		Tag string `json:"tag"`
@@ -134,6 +159,22 @@ func (v Variant) Validate() error {
 	)
 }
 
+// Validate validates WeightedVariant struct.
+func (w WeightedVariant) Validate() error {
+	if err := w.Variant.Validate(); err != nil {
+		return err
+	}
+
+	return validation.ValidateStruct(&w,
+		validation.Field(
+			&w.Weight,
+			validation.Required,
+			validation.Min(minVariantWeight),
+			validation.Max(maxVariantWeight),
+		),
+	)
+}
+
 // Validate validates Segment struct.
 // nolint:funlen
 func (s Segment) Validate() error {
@@ -144,64 +185,146 @@ func (s Segment) Validate() error {
 		),
 		validation.Field(
 			&s.Constraints,
-			validation.Required,
+			s.ruleAlternativeRules(validation.By(func(value interface{}) error {
+				return validateConstraints(s.Constraints)
+			}))...,
+		),
+		validation.Field(
+			&s.Expression,
+			s.ruleAlternativeRules(validation.By(func(value interface{}) error {
+				return validateExpression(s.Expression, s.Constraints)
+			}))...,
+		),
+		validation.Field(
+			&s.Rule,
 			validation.By(func(value interface{}) error {
-				for _, c := range s.Constraints {
-					find := false
-
-					for _, name := range constraint.BasicConstraints() {
-						if c.Name == name {
-							find = true
-							break
-						}
-					}
-
-					if !find {
-						return errors.New("invalid segment constraints")
-					}
+				if s.Rule == "" {
+					return nil
+				}
 
-					if err := constraint.Validate(c.Name, c.Parameters); err != nil {
-						return err
-					}
+				c, err := constraint.CompileRule(s.Rule)
+				if err != nil {
+					return err
 				}
 
-				return nil
+				return constraint.Validate(c.Name, c.Parameters)
 			}),
 		),
 		validation.Field(
-			&s.Expression,
-			validation.Required,
+			&s.Variant,
+			s.variantRules()...,
+		),
+		validation.Field(
+			&s.Variants,
 			validation.By(func(value interface{}) error {
-				parser := constraint.Parser{}
+				if len(s.Variants) == 0 {
+					return nil
+				}
 
-				constraints := map[string]model.Constraint{}
+				sum := 0
+				keys := map[string]struct{}{}
 
-				for k, v := range s.Constraints {
-					constraints[k] = model.Constraint{
-						Name:       v.Name,
-						Parameters: v.Parameters,
+				for _, v := range s.Variants {
+					if _, ok := keys[v.VariantKey]; ok {
+						return errors.New("duplicate segment variant")
 					}
-				}
 
-				c, err := parser.Parse(s.Expression, constraints)
-				if err != nil {
-					return err
+					keys[v.VariantKey] = struct{}{}
+					sum += v.Weight
 				}
 
-				if err := constraint.Validate(c.Name, c.Parameters); err != nil {
-					return err
+				if sum != totalWeight {
+					return errors.New("segment variant weights should add up to 100")
 				}
 
 				return nil
 			}),
 		),
-		validation.Field(
-			&s.Variant,
-			validation.Required,
-		),
 	)
 }
 
+func validateConstraints(constraints map[string]Constraint) error {
+	for _, c := range constraints {
+		find := false
+
+		for _, name := range constraint.BasicConstraints() {
+			if c.Name == name {
+				find = true
+				break
+			}
+		}
+
+		if !find {
+			return errors.New("invalid segment constraints")
+		}
+
+		if err := constraint.Validate(c.Name, c.Parameters); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateExpression(expression string, constraints map[string]Constraint) error {
+	parser := constraint.Parser{}
+
+	c, err := parser.Parse(expression, modelConstraints(constraints))
+	if err != nil {
+		return err
+	}
+
+	return constraint.Validate(c.Name, c.Parameters)
+}
+
+func modelConstraints(constraints map[string]Constraint) map[string]model.Constraint {
+	result := map[string]model.Constraint{}
+
+	for k, v := range constraints {
+		result[k] = model.Constraint{
+			Name:       v.Name,
+			Parameters: v.Parameters,
+		}
+	}
+
+	return result
+}
+
+// ruleAlternativeRules returns the validation rules of the fields that a rule replaces. They should be
+// empty when the segment has a rule.
+func (s Segment) ruleAlternativeRules(rule validation.Rule) []validation.Rule {
+	if s.Rule == "" {
+		return []validation.Rule{validation.Required, rule}
+	}
+
+	return []validation.Rule{
+		validation.By(func(value interface{}) error {
+			if !validation.IsEmpty(value) {
+				return errors.New("rule can not be used with constraints and expression")
+			}
+
+			return nil
+		}),
+	}
+}
+
+func (s Segment) variantRules() []validation.Rule {
+	if len(s.Variants) == 0 {
+		return []validation.Rule{validation.Required}
+	}
+
+	return []validation.Rule{
+		validation.By(func(value interface{}) error {
+			if s.Variant.VariantKey != "" {
+				return errors.New("variant and variants can not be used together")
+			}
+
+			return nil
+		}),
+		validation.Skip,
+	}
+}
+
 // Validate validates Flag struct.
 func (f Flag) Validate() error {
 	return validation.ValidateStruct(&f,
@@ -218,6 +341,17 @@ func (f Flag) Validate() error {
 			&f.Segments,
 			validation.Required,
 			validation.Length(minSegmentLen, 0),
+			validation.By(func(value interface{}) error {
+				for _, segment := range f.Segments {
+					for _, flag := range constraint.PrerequisiteFlags(modelConstraints(segment.Constraints)) {
+						if flag == f.Flag {
+							return errors.New("flag can not be a prerequisite of itself")
+						}
+					}
+				}
+
+				return nil
+			}),
 		),
 		validation.Field(
 			&f.Tags,
@@ -228,8 +362,40 @@ func (f Flag) Validate() error {
 					}
 				}
 
+				return nil
+			}),
+		),
+		validation.Field(
+			&f.Layer,
+		),
+		validation.Field(
+			&f.Sticky,
+			validation.By(func(value interface{}) error {
+				if f.Sticky && f.hasFrequencyCaps() {
+					return errors.New("sticky flag can not have frequency caps")
+				}
+
 				return nil
 			}),
 		),
 	)
 }
+
+// hasFrequencyCaps checks whether the segments of the flag have a frequency cap constraint. The invalid
+// constraints are reported by the validation of the segments.
+func (f Flag) hasFrequencyCaps() bool {
+	for _, segment := range f.Segments {
+		for _, c := range segment.Constraints {
+			co, err := constraint.New(c.Name, c.Parameters)
+			if err != nil {
+				continue
+			}
+
+			if len(constraint.FrequencyCapConstraints(co)) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
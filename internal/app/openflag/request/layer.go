@@ -0,0 +1,88 @@
+package request
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	maxLayerSlice   = 99
+	maxLayerHoldout = 100
+)
+
+type (
+	// Layer represents a hash space that the flags of mutually exclusive experiments share. A layer with
+	// a holdout percentage is a global holdout that the given percentage of entities never leave.
+	Layer struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Property    string `json:"property,omitempty"`
+		Holdout     int    `json:"holdout,omitempty"`
+	}
+
+	// LayerSlice represents the slices of a layer between 0 and 99 that a flag claims.
+	LayerSlice struct {
+		Name       string `json:"name"`
+		LowerBound int    `json:"lower_bound"`
+		UpperBound int    `json:"upper_bound"`
+	}
+
+	// CreateLayerRequest represents a request body for creating a layer.
+	CreateLayerRequest struct {
+		Layer
+	}
+
+	// UpdateLayerRequest represents a request body for updating a layer.
+	UpdateLayerRequest struct {
+		Layer
+	}
+)
+
+// Validate validates Layer struct.
+func (l Layer) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(
+			&l.Name,
+			validation.Required,
+			validation.Match(nameRegex),
+		),
+		validation.Field(
+			&l.Description,
+			validation.Required,
+		),
+		validation.Field(
+			&l.Property,
+			validation.By(func(value interface{}) error {
+				_, err := constraint.CompilePath(l.Property)
+				return err
+			}),
+		),
+		validation.Field(
+			&l.Holdout,
+			validation.Min(0),
+			validation.Max(maxLayerHoldout),
+		),
+	)
+}
+
+// Validate validates LayerSlice struct.
+func (l LayerSlice) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(
+			&l.Name,
+			validation.Required,
+			validation.Match(nameRegex),
+		),
+		validation.Field(
+			&l.LowerBound,
+			validation.Min(0),
+			validation.Max(maxLayerSlice),
+		),
+		validation.Field(
+			&l.UpperBound,
+			validation.Min(l.LowerBound),
+			validation.Max(maxLayerSlice),
+		),
+	)
+}
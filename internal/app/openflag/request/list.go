@@ -0,0 +1,31 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	minListItemsLen = 1
+)
+
+// UploadListRequest represents a request for uploading a list of IDs.
+type UploadListRequest struct {
+	Name string
+	IDs  []string
+}
+
+// Validate validates UploadListRequest struct.
+func (u UploadListRequest) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(
+			&u.Name,
+			validation.Required,
+			validation.Match(nameRegex),
+		),
+		validation.Field(
+			&u.IDs,
+			validation.Required,
+			validation.Length(minListItemsLen, 0),
+		),
+	)
+}
@@ -0,0 +1,42 @@
+package request
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// RenderRuleRequest represents a request for turning the constraints and the expression of a segment
+// into a rule.
+type RenderRuleRequest struct {
+	Constraints map[string]Constraint `json:"constraints"`
+	Expression  string                `json:"expression"`
+}
+
+// Validate validates RenderRuleRequest struct.
+func (r RenderRuleRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(
+			&r.Constraints,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				return validateConstraints(r.Constraints)
+			}),
+		),
+		validation.Field(
+			&r.Expression,
+			validation.Required,
+			validation.By(func(value interface{}) error {
+				return validateExpression(r.Expression, r.Constraints)
+			}),
+		),
+	)
+}
+
+// Constraint returns the constraint tree of the request.
+func (r RenderRuleRequest) Constraint() (*model.Constraint, error) {
+	parser := constraint.Parser{}
+
+	return parser.Parse(r.Expression, modelConstraints(r.Constraints))
+}
@@ -0,0 +1,17 @@
+package response
+
+import (
+	"time"
+)
+
+// Audience represents a named and reusable set of constraints that segments can reference
+// using the audience constraint.
+type Audience struct {
+	ID          int64                 `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Constraints map[string]Constraint `json:"constraints"`
+	Expression  string                `json:"expression"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
@@ -1,13 +1,24 @@
 package response
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+)
+
 type (
 	// Entity represents the context of what we are going to assign the variant on.
 	// Usually, OpenFlag expects the context coming with the entity,
 	// so that one can define constraints based on the context of the entity.
+	// FirstSeen and LastSeen are only set for the explain requests that use the stored contexts or evaluate
+	// entity age constraints.
 	Entity struct {
-		EntityID      int64             `json:"entity_id"`
-		EntityType    string            `json:"entity_type"`
-		EntityContext map[string]string `json:"entity_context,omitempty"`
+		EntityID      int64         `json:"entity_id"`
+		EntityType    string        `json:"entity_type"`
+		EntityContext model.Context `json:"entity_context,omitempty"`
+		FirstSeen     *time.Time    `json:"first_seen,omitempty"`
+		LastSeen      *time.Time    `json:"last_seen,omitempty"`
 	}
 
 	// Evaluation represents evaluation result for a flag.
@@ -16,9 +27,40 @@ type (
 		Variant Variant `json:"variant"`
 	}
 
+	// Trace represents the evaluation of a node of a constraint tree with the values of the properties that
+	// it reads and the properties that the entity does not have. Result is not set for the skipped nodes.
+	Trace struct {
+		Name       string                 `json:"name"`
+		Parameters json.RawMessage        `json:"parameters,omitempty"`
+		Result     *bool                  `json:"result,omitempty"`
+		Properties map[string]model.Value `json:"properties,omitempty"`
+		Missing    []string               `json:"missing,omitempty"`
+		Children   []Trace                `json:"children,omitempty"`
+	}
+
+	// SegmentExplanation represents the evaluation of a segment with the trace of its constraint tree.
+	SegmentExplanation struct {
+		Description string `json:"description"`
+		Constraint  Trace  `json:"constraint"`
+	}
+
+	// Explanation represents how a flag is evaluated for an entity. Segment is the index of the segment
+	// that won and Excluded is set when the entity is held out or outside the layer slice of the flag.
+	// Assigned is set when the variant comes from an earlier sticky assignment of the entity.
+	Explanation struct {
+		Flag     string               `json:"flag"`
+		Excluded bool                 `json:"excluded,omitempty"`
+		Segments []SegmentExplanation `json:"segments"`
+		Segment  *int                 `json:"segment,omitempty"`
+		Variant  *Variant             `json:"variant,omitempty"`
+		Assigned bool                 `json:"assigned,omitempty"`
+	}
+
 	// EvaluationResponse represents a response to an evaluation request.
+	// Explanations are only set for the explain requests.
 	EvaluationResponse struct {
-		Entity      Entity       `json:"entity"`
-		Evaluations []Evaluation `json:"evaluations"`
+		Entity       Entity        `json:"entity"`
+		Evaluations  []Evaluation  `json:"evaluations"`
+		Explanations []Explanation `json:"explanations,omitempty"`
 	}
 )
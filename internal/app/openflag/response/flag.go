@@ -15,6 +15,12 @@ type (
 		VariantAttachment json.RawMessage `json:"variant_attachment,omitempty"`
 	}
 
+	// WeightedVariant represents a variant with its share of the segment audience in percent.
+	WeightedVariant struct {
+		Variant
+		Weight int `json:"weight"`
+	}
+
 	// Constraint represents rules that we can use to define the audience of the segment.
 	// In other words, the audience in the segment is defined by a set of constraints.
 	Constraint struct {
@@ -27,17 +33,38 @@ type (
 		Description string                `json:"description"`
 		Constraints map[string]Constraint `json:"constraints"`
 		Expression  string                `json:"expression"`
+		Rule        string                `json:"rule,omitempty"`
 		Variant     Variant               `json:"variant"`
+		Variants    []WeightedVariant     `json:"variants,omitempty"`
+	}
+
+	// Warning represents a problem in the segments of a flag that does not make the flag invalid, e.g.
+	// a segment that can not be reached. Segment is the index of the segment that has the problem.
+	Warning struct {
+		Segment int    `json:"segment"`
+		Kind    string `json:"kind"`
+		Message string `json:"message"`
 	}
 
 	// Flag represents a feature flag, an experiment, or a configuration.
+	// Lists contains the current versions of the lists that the segments of the flag use.
+	// Warnings are only set in the responses of creating and updating a flag.
 	Flag struct {
-		ID          int64      `json:"id"`
-		Tags        []string   `json:"tags,omitempty"`
-		Description string     `json:"description"`
-		Flag        string     `json:"flag"`
-		Segments    []Segment  `json:"segments"`
-		CreatedAt   time.Time  `json:"created_at"`
-		DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+		ID          int64            `json:"id"`
+		Tags        []string         `json:"tags,omitempty"`
+		Description string           `json:"description"`
+		Flag        string           `json:"flag"`
+		Segments    []Segment        `json:"segments"`
+		Layer       *LayerSlice      `json:"layer,omitempty"`
+		Sticky      bool             `json:"sticky,omitempty"`
+		Lists       map[string]int64 `json:"lists,omitempty"`
+		CreatedAt   time.Time        `json:"created_at"`
+		DeletedAt   *time.Time       `json:"deleted_at,omitempty"`
+		Warnings    []Warning        `json:"warnings,omitempty"`
+	}
+
+	// Lint represents the result of analyzing the segments of a flag.
+	Lint struct {
+		Warnings []Warning `json:"warnings"`
 	}
 )
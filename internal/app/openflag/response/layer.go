@@ -0,0 +1,26 @@
+package response
+
+import (
+	"time"
+)
+
+type (
+	// Layer represents a hash space that the flags of mutually exclusive experiments share. A layer with
+	// a holdout percentage is a global holdout that the given percentage of entities never leave.
+	Layer struct {
+		ID          int64     `json:"id"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		Property    string    `json:"property,omitempty"`
+		Holdout     int       `json:"holdout,omitempty"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
+	}
+
+	// LayerSlice represents the slices of a layer between 0 and 99 that a flag claims.
+	LayerSlice struct {
+		Name       string `json:"name"`
+		LowerBound int    `json:"lower_bound"`
+		UpperBound int    `json:"upper_bound"`
+	}
+)
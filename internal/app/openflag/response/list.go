@@ -0,0 +1,8 @@
+package response
+
+// List represents an uploaded list of IDs. Version is incremented on each upload of the list.
+type List struct {
+	Name    string `json:"name"`
+	Version int64  `json:"version"`
+	Size    int64  `json:"size"`
+}
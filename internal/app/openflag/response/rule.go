@@ -0,0 +1,6 @@
+package response
+
+// Rule represents the rule of a segment.
+type Rule struct {
+	Rule string `json:"rule"`
+}
@@ -0,0 +1,147 @@
+package constraint
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+)
+
+// Represents the JSON types of constraint parameters.
+const (
+	StringParameter  = constraint.StringParameter
+	NumberParameter  = constraint.NumberParameter
+	BooleanParameter = constraint.BooleanParameter
+	ArrayParameter   = constraint.ArrayParameter
+	ObjectParameter  = constraint.ObjectParameter
+)
+
+var (
+	// ErrDuplicateConstraint represents an error for returning when we want to register a constraint
+	// with a name that is already in use.
+	ErrDuplicateConstraint = constraint.ErrDuplicateConstraint
+	// ErrInvalidRegistration represents an error for returning when we want to register a constraint
+	// without a name or a valid factory.
+	ErrInvalidRegistration = constraint.ErrInvalidRegistration
+)
+
+type (
+	// ParameterType represents the JSON type of a constraint parameter.
+	ParameterType = constraint.ParameterType
+
+	// Parameter represents a parameter in the schema of a registered constraint.
+	Parameter = constraint.Parameter
+
+	// Schema represents the parameters of a registered constraint by their names.
+	Schema = constraint.Schema
+
+	// Constraint represents an interface for defining custom OpenFlag constraints.
+	Constraint interface {
+		// Name returns the constraint name.
+		Name() string
+		// Validate validates the constraint parameters.
+		Validate() error
+		// Initialize initializes the constraint.
+		Initialize() error
+		// Evaluate evaluates an entity in a constraint.
+		Evaluate(Entity) bool
+	}
+
+	// Factory creates a new instance of a registered constraint. The constraint parameters are unmarshalled
+	// into the returned value, so it should be a pointer.
+	Factory func() Constraint
+
+	// Entity represents the entity that a custom constraint evaluates. The context values are strings, float64
+	// numbers, booleans, lists of strings or objects of nested values with the map[string]interface{} type.
+	// FirstSeen and LastSeen are only set when they are loaded for the evaluation.
+	Entity struct {
+		ID        int64
+		Type      string
+		Context   map[string]interface{}
+		FirstSeen *time.Time
+		LastSeen  *time.Time
+	}
+
+	// registered adapts a custom constraint to the constraints of the evaluation engine.
+	registered struct {
+		constraint Constraint
+	}
+)
+
+// Register registers a custom constraint type, so that segments can use it like the basic constraints.
+// It is meant to be called by the applications that embed OpenFlag before they start the server.
+// Registering a name that is already in use fails with ErrDuplicateConstraint.
+func Register(name string, factory Factory, schema Schema) error {
+	if factory == nil {
+		return ErrInvalidRegistration
+	}
+
+	if factory() == nil {
+		return fmt.Errorf("%w: factory of %s should create a constraint", ErrInvalidRegistration, name)
+	}
+
+	return constraint.Register(name, func() constraint.Constraint {
+		return &registered{constraint: factory()}
+	}, schema)
+}
+
+// Name is an implementation for the Constraint interface.
+func (r registered) Name() string {
+	return r.constraint.Name()
+}
+
+// Validate is an implementation for the Constraint interface.
+func (r registered) Validate() error {
+	return r.constraint.Validate()
+}
+
+// Initialize is an implementation for the Constraint interface.
+func (r *registered) Initialize() error {
+	return r.constraint.Initialize()
+}
+
+// Evaluate is an implementation for the Constraint interface.
+func (r registered) Evaluate(e model.Entity) bool {
+	return r.constraint.Evaluate(Entity{
+		ID:        e.EntityID,
+		Type:      e.EntityType,
+		Context:   contextValues(e.EntityContext),
+		FirstSeen: e.FirstSeen,
+		LastSeen:  e.LastSeen,
+	})
+}
+
+// UnmarshalJSON is an implementation for the json.Unmarshaler interface. It unmarshals the parameters into
+// the custom constraint.
+func (r *registered) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, r.constraint)
+}
+
+// contextValues converts the typed values of the entity context into plain Go values.
+func contextValues(context model.Context) map[string]interface{} {
+	if context == nil {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(context))
+
+	for key, value := range context {
+		switch value.Kind() {
+		case model.NumberKind:
+			values[key], _ = value.Number()
+		case model.BoolKind:
+			values[key], _ = value.Bool()
+		case model.ListKind:
+			values[key] = value.List()
+		case model.ObjectKind:
+			object, _ := value.Object()
+			values[key] = contextValues(object)
+		default:
+			values[key] = value.String()
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,118 @@
+package constraint_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	internal "github.com/OpenFlag/OpenFlag/internal/app/openflag/constraint"
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/model"
+	"github.com/OpenFlag/OpenFlag/pkg/constraint"
+	"github.com/stretchr/testify/suite"
+)
+
+const tierConstraintName = "tier"
+
+type tierConstraint struct {
+	Tiers []string `json:"tiers"`
+}
+
+func (t tierConstraint) Name() string {
+	return tierConstraintName
+}
+
+func (t tierConstraint) Validate() error {
+	if len(t.Tiers) == 0 {
+		return errors.New("tiers are required")
+	}
+
+	return nil
+}
+
+func (t *tierConstraint) Initialize() error {
+	return nil
+}
+
+func (t tierConstraint) Evaluate(e constraint.Entity) bool {
+	account, ok := e.Context["account"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	seats, _ := account["seats"].(float64)
+
+	for _, tier := range t.Tiers {
+		if account["tier"] == tier && seats > 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ConstraintSuite struct {
+	suite.Suite
+}
+
+func (suite *ConstraintSuite) SetupSuite() {
+	err := constraint.Register(
+		tierConstraintName,
+		func() constraint.Constraint { return &tierConstraint{} },
+		constraint.Schema{"tiers": {Type: constraint.ArrayParameter, Required: true}},
+	)
+	suite.NoError(err)
+}
+
+func (suite *ConstraintSuite) TestRegister() {
+	suite.Contains(internal.BasicConstraints(), tierConstraintName)
+
+	c, err := internal.New(tierConstraintName, json.RawMessage(`{"tiers": ["gold"]}`))
+	suite.NoError(err)
+	suite.Equal(tierConstraintName, c.Name())
+
+	account := func(tier string, seats float64) model.Entity {
+		return model.Entity{EntityID: 1, EntityType: "user", EntityContext: model.Context{
+			"account": model.ObjectValue(model.Context{
+				"tier":  model.StringValue(tier),
+				"seats": model.NumberValue(seats),
+			}),
+		}}
+	}
+
+	suite.True(c.Evaluate(account("gold", 5)))
+	suite.False(c.Evaluate(account("gold", 1)))
+	suite.False(c.Evaluate(account("silver", 5)))
+	suite.False(c.Evaluate(model.Entity{EntityID: 1}))
+
+	c, err = internal.New(
+		internal.NotConstraintName, json.RawMessage(`{"constraint": {"name": "tier", "parameters": {"tiers": ["gold"]}}}`),
+	)
+	suite.NoError(err)
+	suite.False(c.Evaluate(account("gold", 5)))
+
+	_, err = internal.New(tierConstraintName, json.RawMessage(`{"tiers": []}`))
+	suite.Error(err)
+
+	_, err = internal.New(tierConstraintName, json.RawMessage(`{"tiers": ["gold"], "seats": 2}`))
+	suite.Error(err)
+}
+
+func (suite *ConstraintSuite) TestRegisterFailures() {
+	factory := func() constraint.Constraint { return &tierConstraint{} }
+
+	err := constraint.Register(tierConstraintName, factory, nil)
+	suite.True(errors.Is(err, constraint.ErrDuplicateConstraint))
+
+	err = constraint.Register("platinum", factory, nil)
+	suite.True(errors.Is(err, constraint.ErrInvalidRegistration))
+
+	err = constraint.Register("platinum", nil, nil)
+	suite.True(errors.Is(err, constraint.ErrInvalidRegistration))
+
+	err = constraint.Register("platinum", func() constraint.Constraint { return nil }, nil)
+	suite.True(errors.Is(err, constraint.ErrInvalidRegistration))
+}
+
+func TestConstraintSuite(t *testing.T) {
+	suite.Run(t, new(ConstraintSuite))
+}
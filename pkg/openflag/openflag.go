@@ -0,0 +1,12 @@
+package openflag
+
+import (
+	"github.com/OpenFlag/OpenFlag/internal/app/openflag/cmd"
+	"github.com/spf13/cobra"
+)
+
+// NewRootCommand creates a new openflag root command. The applications that embed OpenFlag register their
+// custom constraints using the constraint package and then execute the root command in their main function.
+func NewRootCommand() *cobra.Command {
+	return cmd.NewRootCommand()
+}